@@ -0,0 +1,125 @@
+// Package bracket คำนวณผลของคู่น็อกเอาต์ (สกอร์รวม ประตูทีมเยือน ต่อเวลา ยิงจุดโทษ) จากแมตช์ในแต่ละเลก
+package bracket
+
+import "go-ballthai-scraper/models"
+
+// วิธีที่ใช้ตัดสินผู้ชนะของคู่
+const (
+	DecidedByScore     = "score"      // นัดเดียว ชนะในเวลา
+	DecidedByAggregate = "aggregate"  // สองเลก สกอร์รวม
+	DecidedByAwayGoals = "away_goals" // สองเลก สกอร์รวมเท่ากัน ตัดสินด้วยประตูทีมเยือน
+	DecidedByExtraTime = "extra_time"
+	DecidedByPenalties = "penalties"
+	DecidedByManual    = "manual" // กำหนดผู้ชนะเอง เช่น ชนะบาย/ปรับแพ้
+)
+
+// Pair คือค่าของทีม a และทีม b
+type Pair struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+// Result คือผลของคู่หลังคำนวณ
+type Result struct {
+	Aggregate    *Pair  `json:"aggregate"`
+	AwayGoals    *Pair  `json:"away_goals,omitempty"`
+	Penalties    *Pair  `json:"penalties,omitempty"`
+	Complete     bool   `json:"complete"`
+	WinnerTeamID *int   `json:"winner_team_id"`
+	DecidedBy    string `json:"decided_by,omitempty"`
+}
+
+// Resolve คำนวณสกอร์รวมและผู้ชนะของคู่ legs คือจำนวนเลกของรอบ (1 หรือ 2)
+// awayGoals = ใช้กฎประตูทีมเยือนเมื่อสกอร์รวมเท่ากัน (เฉพาะรอบสองเลก)
+func Resolve(tie models.KnockoutTieDB, legs int, awayGoals bool) Result {
+	var res Result
+	if !tie.TeamAID.Valid || !tie.TeamBID.Valid {
+		return res.withManual(tie)
+	}
+	teamA := int(tie.TeamAID.Int64)
+
+	// สกอร์ต่อเวลา/จุดโทษระดับแมตช์มาก่อนค่าที่กรอกไว้ในคู่
+	extraTime := tie.ExtraTime
+	var legPens *Pair
+	var agg, away Pair
+	played := 0
+	for _, leg := range []*models.KnockoutLeg{tie.Leg1, tie.Leg2} {
+		if leg == nil || !leg.Finished || leg.HomeScore == nil || leg.AwayScore == nil {
+			continue
+		}
+		played++
+		home, awayScore := *leg.HomeScore, *leg.AwayScore
+		if leg.HomeScoreET != nil && leg.AwayScoreET != nil {
+			home, awayScore = *leg.HomeScoreET, *leg.AwayScoreET
+			extraTime = true
+		}
+		var pens *Pair
+		if leg.HomePenalties != nil && leg.AwayPenalties != nil {
+			pens = &Pair{A: *leg.HomePenalties, B: *leg.AwayPenalties}
+		}
+		if leg.HomeTeamID == teamA {
+			agg.A += home
+			agg.B += awayScore
+			away.B += awayScore
+		} else {
+			agg.A += awayScore
+			agg.B += home
+			away.A += awayScore
+			if pens != nil {
+				pens.A, pens.B = pens.B, pens.A
+			}
+		}
+		if pens != nil {
+			legPens = pens
+		}
+	}
+	if played > 0 {
+		res.Aggregate = &agg
+	}
+	if legs < 1 {
+		legs = 1
+	}
+	res.Complete = played >= legs
+	if legPens != nil {
+		res.Penalties = legPens
+	} else if tie.PenaltiesA.Valid && tie.PenaltiesB.Valid {
+		res.Penalties = &Pair{A: int(tie.PenaltiesA.Int64), B: int(tie.PenaltiesB.Int64)}
+	}
+	if !res.Complete {
+		return res.withManual(tie)
+	}
+
+	teamB := int(tie.TeamBID.Int64)
+	pick := func(aWins bool, by string) {
+		w := teamB
+		if aWins {
+			w = teamA
+		}
+		res.WinnerTeamID, res.DecidedBy = &w, by
+	}
+	switch {
+	case agg.A != agg.B:
+		by := DecidedByScore
+		if extraTime {
+			by = DecidedByExtraTime
+		} else if legs == 2 {
+			by = DecidedByAggregate
+		}
+		pick(agg.A > agg.B, by)
+	case legs == 2 && awayGoals && away.A != away.B:
+		res.AwayGoals = &away
+		pick(away.A > away.B, DecidedByAwayGoals)
+	case res.Penalties != nil && res.Penalties.A != res.Penalties.B:
+		pick(res.Penalties.A > res.Penalties.B, DecidedByPenalties)
+	}
+	return res.withManual(tie)
+}
+
+// withManual ใช้ผู้ชนะที่กำหนดเองแทนผลที่คำนวณได้
+func (r Result) withManual(tie models.KnockoutTieDB) Result {
+	if tie.WinnerTeamID.Valid {
+		w := int(tie.WinnerTeamID.Int64)
+		r.WinnerTeamID, r.DecidedBy = &w, DecidedByManual
+	}
+	return r
+}
@@ -0,0 +1,79 @@
+package bracket
+
+import (
+	"database/sql"
+	"testing"
+
+	"go-ballthai-scraper/models"
+)
+
+func intp(v int) *int { return &v }
+
+// leg คือแมตช์ที่จบแล้วหนึ่งนัด
+func leg(home, away, hs, as int) *models.KnockoutLeg {
+	return &models.KnockoutLeg{HomeTeamID: home, AwayTeamID: away, HomeScore: intp(hs), AwayScore: intp(as), Finished: true}
+}
+
+func tie(legs ...*models.KnockoutLeg) models.KnockoutTieDB {
+	t := models.KnockoutTieDB{TeamAID: sql.NullInt64{Int64: 1, Valid: true}, TeamBID: sql.NullInt64{Int64: 2, Valid: true}}
+	if len(legs) > 0 {
+		t.Leg1 = legs[0]
+	}
+	if len(legs) > 1 {
+		t.Leg2 = legs[1]
+	}
+	return t
+}
+
+func TestResolve(t *testing.T) {
+	extraTime := leg(1, 2, 1, 1)
+	extraTime.HomeScoreET, extraTime.AwayScoreET = intp(1), intp(2)
+	shootout := leg(2, 1, 1, 0)
+	shootout.HomePenalties, shootout.AwayPenalties = intp(3), intp(4)
+	unfinished := leg(2, 1, 0, 0)
+	unfinished.Finished = false
+	manual := tie()
+	manual.WinnerTeamID = sql.NullInt64{Int64: 2, Valid: true}
+	tiePens := tie(leg(1, 2, 2, 2))
+	tiePens.PenaltiesA, tiePens.PenaltiesB = sql.NullInt64{Int64: 5, Valid: true}, sql.NullInt64{Int64: 3, Valid: true}
+
+	tests := []struct {
+		name         string
+		tie          models.KnockoutTieDB
+		legs         int
+		awayGoals    bool
+		wantWinner   int // 0 = ยังไม่มีผู้ชนะ
+		wantBy       string
+		wantAgg      *Pair
+		wantComplete bool
+	}{
+		{"single leg win", tie(leg(1, 2, 2, 1)), 1, false, 1, DecidedByScore, &Pair{2, 1}, true},
+		{"aggregate across legs with swapped home team", tie(leg(1, 2, 1, 0), leg(2, 1, 3, 1)), 2, false, 2, DecidedByAggregate, &Pair{2, 3}, true},
+		{"away goals break a level aggregate", tie(leg(1, 2, 2, 1), leg(2, 1, 1, 0)), 2, true, 2, DecidedByAwayGoals, &Pair{2, 2}, true},
+		{"level aggregate without away goals rule stays open", tie(leg(1, 2, 2, 1), leg(2, 1, 1, 0)), 2, false, 0, "", &Pair{2, 2}, true},
+		{"extra time score counts", tie(extraTime), 1, false, 2, DecidedByExtraTime, &Pair{1, 2}, true},
+		{"shoot-out in the second leg is mapped to team a/b", tie(leg(1, 2, 1, 0), shootout), 2, false, 1, DecidedByPenalties, &Pair{1, 1}, true},
+		{"tie-level penalties when the match has none", tiePens, 1, false, 1, DecidedByPenalties, &Pair{2, 2}, true},
+		{"second leg not finished", tie(leg(1, 2, 1, 0), unfinished), 2, false, 0, "", &Pair{1, 0}, false},
+		{"manual winner without matches", manual, 1, false, 2, DecidedByManual, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Resolve(tt.tie, tt.legs, tt.awayGoals)
+			winner := 0
+			if res.WinnerTeamID != nil {
+				winner = *res.WinnerTeamID
+			}
+			if winner != tt.wantWinner || res.DecidedBy != tt.wantBy {
+				t.Errorf("winner = %d by %q, want %d by %q", winner, res.DecidedBy, tt.wantWinner, tt.wantBy)
+			}
+			if (res.Aggregate == nil) != (tt.wantAgg == nil) || (res.Aggregate != nil && *res.Aggregate != *tt.wantAgg) {
+				t.Errorf("aggregate = %v, want %v", res.Aggregate, tt.wantAgg)
+			}
+			if res.Complete != tt.wantComplete {
+				t.Errorf("complete = %v, want %v", res.Complete, tt.wantComplete)
+			}
+		})
+	}
+}
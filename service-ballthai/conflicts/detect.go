@@ -0,0 +1,169 @@
+// Package conflicts ตรวจโปรแกรมการแข่งขันที่ชนกันจากรายการแมตช์ โดยไม่แตะฐานข้อมูล
+package conflicts
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go-ballthai-scraper/models"
+)
+
+// ChannelSlot คือช่วงเวลาที่ช่องถ่ายทอดถูกใช้ต่อหนึ่งนัด (นับจากเวลาเตะ)
+const ChannelSlot = 2 * time.Hour
+
+// Match คือแมตช์ที่ใช้ตรวจ (ไม่รวมนัดที่เลื่อน/ยกเลิก)
+type Match struct {
+	ID         int
+	LeagueID   int
+	SeasonID   int       // ฤดูกาลของ stage, 0 = ไม่ทราบ (ตรวจกับทุกฤดูกาลของลีก)
+	StartDate  string    // YYYY-MM-DD เวลาท้องถิ่นของสนาม
+	Kickoff    time.Time // UTC
+	HomeTeamID int
+	AwayTeamID int
+	StadiumID  int   // 0 = ไม่ทราบ
+	ChannelIDs []int // ช่องหลักและช่องถ่ายทอดสด
+	RestHours  int   // เวลาพักขั้นต่ำของลีก, 0 = models.DefaultMinRestHours
+}
+
+// Season คือช่วงวันที่ของฤดูกาล (Start/End ว่าง = ไม่ทราบ)
+type Season struct {
+	ID       int
+	LeagueID int
+	Name     string
+	Start    string
+	End      string
+}
+
+func (s Season) dated() bool { return s.Start != "" && s.End != "" }
+
+func (s Season) covers(date string) bool { return s.Start <= date && date <= s.End }
+
+func conflict(typ string, later, earlier Match, subject int, detail string) models.MatchConflict {
+	c := models.MatchConflict{Type: typ, MatchID: later.ID, Detail: detail}
+	if earlier.ID != 0 {
+		other := earlier.ID
+		c.OtherMatchID = &other
+	}
+	if subject != 0 {
+		c.SubjectID = &subject
+	}
+	return c
+}
+
+// byKickoff เรียงแมตช์ตามเวลาเตะ (เท่ากันเรียงตาม id)
+func byKickoff(ms []Match) {
+	sort.Slice(ms, func(a, b int) bool {
+		if !ms[a].Kickoff.Equal(ms[b].Kickoff) {
+			return ms[a].Kickoff.Before(ms[b].Kickoff)
+		}
+		return ms[a].ID < ms[b].ID
+	})
+}
+
+// hours แสดงระยะเวลาเป็นชั่วโมง เช่น 40h หรือ 1h30m
+func hours(d time.Duration) string {
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if m == 0 {
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
+
+// Detect คืนโปรแกรมที่ชนกันทั้งหมด แต่ละคู่รายงานครั้งเดียวที่นัดที่เตะทีหลัง
+//   - rest: ทีมเดียวกันเตะสองนัดติดกันห่างน้อยกว่าเวลาพักขั้นต่ำของลีกของนัดหลัง
+//   - stadium: สนามเดียวกันมีมากกว่าหนึ่งนัดในวันเดียวกัน (วันที่ท้องถิ่น)
+//   - channel: ช่องเดียวกันมีสองนัดที่เวลาเตะห่างกันน้อยกว่า ChannelSlot
+//   - season: วันแข่งอยู่นอกฤดูกาลของ stage หรือไม่อยู่ในฤดูกาลใดของลีกเลย (ตรวจเฉพาะฤดูกาลที่มีวันที่)
+func Detect(matches []Match, seasons []Season) []models.MatchConflict {
+	ms := append([]Match(nil), matches...)
+	byKickoff(ms)
+	out := []models.MatchConflict{}
+
+	lastByTeam := map[int]Match{}
+	for _, m := range ms {
+		for _, team := range []int{m.HomeTeamID, m.AwayTeamID} {
+			if team == 0 {
+				continue
+			}
+			if prev, ok := lastByTeam[team]; ok {
+				rest := m.RestHours
+				if rest <= 0 {
+					rest = models.DefaultMinRestHours
+				}
+				window := time.Duration(rest) * time.Hour
+				if gap := m.Kickoff.Sub(prev.Kickoff); gap < window {
+					out = append(out, conflict(models.ConflictRest, m, prev, team,
+						fmt.Sprintf("rest %s < %dh", hours(gap), rest)))
+				}
+			}
+			lastByTeam[team] = m
+		}
+	}
+
+	type stadiumDay struct {
+		stadium int
+		date    string
+	}
+	lastAtStadium := map[stadiumDay]Match{}
+	for _, m := range ms {
+		if m.StadiumID == 0 {
+			continue
+		}
+		key := stadiumDay{m.StadiumID, m.StartDate}
+		if prev, ok := lastAtStadium[key]; ok {
+			out = append(out, conflict(models.ConflictStadium, m, prev, m.StadiumID,
+				"same stadium on "+m.StartDate))
+		}
+		lastAtStadium[key] = m
+	}
+
+	lastOnChannel := map[int]Match{}
+	for _, m := range ms {
+		seen := map[int]bool{}
+		for _, ch := range m.ChannelIDs {
+			if ch == 0 || seen[ch] {
+				continue
+			}
+			seen[ch] = true
+			if prev, ok := lastOnChannel[ch]; ok {
+				if gap := m.Kickoff.Sub(prev.Kickoff); gap < ChannelSlot {
+					out = append(out, conflict(models.ConflictChannel, m, prev, ch,
+						fmt.Sprintf("kick-offs %s apart (slot %s)", hours(gap), hours(ChannelSlot))))
+				}
+			}
+			lastOnChannel[ch] = m
+		}
+	}
+
+	byID := map[int]Season{}
+	byLeague := map[int][]Season{}
+	for _, s := range seasons {
+		byID[s.ID] = s
+		if s.dated() {
+			byLeague[s.LeagueID] = append(byLeague[s.LeagueID], s)
+		}
+	}
+	for _, m := range ms {
+		if s, ok := byID[m.SeasonID]; ok && m.SeasonID != 0 {
+			if s.dated() && !s.covers(m.StartDate) {
+				out = append(out, conflict(models.ConflictSeason, m, Match{}, 0,
+					fmt.Sprintf("%s is outside season %s (%s to %s)", m.StartDate, s.Name, s.Start, s.End)))
+			}
+			continue
+		}
+		candidates := byLeague[m.LeagueID]
+		if len(candidates) == 0 {
+			continue
+		}
+		covered := false
+		for _, s := range candidates {
+			covered = covered || s.covers(m.StartDate)
+		}
+		if !covered {
+			out = append(out, conflict(models.ConflictSeason, m, Match{}, 0,
+				fmt.Sprintf("%s is outside every season of the league", m.StartDate)))
+		}
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package conflicts
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-ballthai-scraper/models"
+)
+
+// match สร้างแมตช์ของลีก 1 ที่เตะเวลา kickoff (UTC, "2006-01-02 15:04") วันที่ท้องถิ่นคือวันเดียวกัน
+func match(id, home, away int, kickoff string) Match {
+	k, err := time.Parse("2006-01-02 15:04", kickoff)
+	if err != nil {
+		panic(err)
+	}
+	return Match{ID: id, LeagueID: 1, StartDate: k.Format("2006-01-02"), Kickoff: k, HomeTeamID: home, AwayTeamID: away}
+}
+
+// found ย่อผลเป็น "type:match<-other@subject" เพื่อเทียบง่าย
+func found(cs []models.MatchConflict) []string {
+	out := []string{}
+	for _, c := range cs {
+		s := c.Type + ":" + strconv.Itoa(c.MatchID)
+		if c.OtherMatchID != nil {
+			s += "<-" + strconv.Itoa(*c.OtherMatchID)
+		}
+		if c.SubjectID != nil {
+			s += "@" + strconv.Itoa(*c.SubjectID)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestDetect(t *testing.T) {
+	withRest := func(m Match, hours int) Match { m.RestHours = hours; return m }
+	atStadium := func(m Match, stadium int) Match { m.StadiumID = stadium; return m }
+	onChannels := func(m Match, channels ...int) Match { m.ChannelIDs = channels; return m }
+	inSeason := func(m Match, season int) Match { m.SeasonID = season; return m }
+	seasons := []Season{
+		{ID: 10, LeagueID: 1, Name: "2025/26", Start: "2025-08-01", End: "2026-05-31"},
+		{ID: 11, LeagueID: 1, Name: "2026/27", Start: "2026-08-01", End: "2027-05-31"},
+		{ID: 20, LeagueID: 2, Name: "2026"}, // ไม่มีวันที่ ไม่ตรวจ
+	}
+
+	tests := []struct {
+		name    string
+		matches []Match
+		want    []string
+	}{
+		{
+			name:    "team plays again inside the default rest window",
+			matches: []Match{match(2, 3, 1, "2025-09-03 10:00"), match(1, 1, 2, "2025-09-01 12:00")},
+			want:    []string{"rest:2<-1@1"},
+		},
+		{
+			name:    "exactly the minimum rest is allowed",
+			matches: []Match{match(1, 1, 2, "2025-09-01 12:00"), match(2, 1, 3, "2025-09-03 12:00")},
+			want:    []string{},
+		},
+		{
+			name:    "league rest hours override the default",
+			matches: []Match{match(1, 1, 2, "2025-09-01 12:00"), withRest(match(2, 3, 2, "2025-09-04 11:00"), 72)},
+			want:    []string{"rest:2<-1@2"},
+		},
+		{
+			name: "same stadium on the same local day",
+			matches: []Match{
+				atStadium(match(1, 1, 2, "2025-09-01 08:00"), 5),
+				atStadium(match(2, 3, 4, "2025-09-01 12:00"), 5),
+				atStadium(match(3, 5, 6, "2025-09-02 12:00"), 5),
+			},
+			want: []string{"stadium:2<-1@5"},
+		},
+		{
+			name: "channel used by two kick-offs within the slot",
+			matches: []Match{
+				onChannels(match(1, 1, 2, "2025-09-01 11:00"), 7, 8),
+				onChannels(match(2, 3, 4, "2025-09-01 12:30"), 0, 8),
+				onChannels(match(3, 5, 6, "2025-09-01 14:00"), 8, 8),
+			},
+			want: []string{"channel:2<-1@8", "channel:3<-2@8"},
+		},
+		{
+			name: "stage season and league seasons bound the match date",
+			matches: []Match{
+				inSeason(match(1, 1, 2, "2026-06-10 12:00"), 10),
+				match(2, 3, 4, "2026-07-01 12:00"),
+				match(3, 5, 6, "2026-08-15 12:00"),
+				{ID: 4, LeagueID: 2, StartDate: "2030-01-01", HomeTeamID: 7, AwayTeamID: 8},
+			},
+			want: []string{"season:1", "season:2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := found(Detect(tt.matches, seasons))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+
+	"go-ballthai-scraper/bracket"
+	"go-ballthai-scraper/models"
+)
+
+// knockoutCompetitionTypes คือ leagues.competition_type ที่ใช้สายการแข่งขันแบบน็อกเอาต์
+const knockoutCompetitionTypes = "'cup', 'super_cup'"
+
+// IsKnockoutLeague บอกว่าลีกนี้ใช้สายการแข่งขันแบบน็อกเอาต์หรือไม่ (ดูจาก leagues.competition_type)
+// ลีกที่ไม่พบคืน false
+func IsKnockoutLeague(db *sql.DB, leagueID int) (bool, error) {
+	var knockout bool
+	err := db.QueryRow("SELECT competition_type IN ("+knockoutCompetitionTypes+") FROM leagues WHERE id = ?", leagueID).Scan(&knockout)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read competition type of league %d: %w", leagueID, err)
+	}
+	return knockout, nil
+}
+
+// SyncKnockoutMatch ผูกแมตช์ถ้วย (อ้างอิงด้วย match_ref_id) เข้ากับรอบและคู่ในสายการแข่งขัน
+// แมตช์แรกของสองทีมในรอบเป็นเลกแรก แมตช์ที่สองของคู่เดิมเป็นเลกสองเฉพาะรอบที่ตั้ง legs = 2 ไว้
+// (รอบนัดเดียวที่เจอกันซ้ำ เช่น นัดรีเพลย์ จะไม่ถูกผูกและไม่เปลี่ยนจำนวนเลกของรอบ)
+func SyncKnockoutMatch(db *sql.DB, matchRefID int) error {
+	var matchID int
+	var leagueID, stageID, homeID, awayID sql.NullInt64
+	var startDate string
+	var knockout sql.NullBool
+	err := db.QueryRow(`
+		SELECT m.id, m.league_id, m.stage_id, m.home_team_id, m.away_team_id, DATE_FORMAT(m.start_date, '%Y-%m-%d'),
+		       l.competition_type IN (`+knockoutCompetitionTypes+`)
+		FROM matches m LEFT JOIN leagues l ON l.id = m.league_id
+		WHERE m.match_ref_id = ?`, matchRefID).Scan(&matchID, &leagueID, &stageID, &homeID, &awayID, &startDate, &knockout)
+	if err != nil {
+		return fmt.Errorf("failed to load match %d for bracket: %w", matchRefID, err)
+	}
+	if !leagueID.Valid || !knockout.Bool || !stageID.Valid || !homeID.Valid || !awayID.Valid {
+		return nil
+	}
+
+	// แมตช์นี้อยู่ในคู่ใดคู่หนึ่งแล้ว
+	var existing int
+	err = db.QueryRow("SELECT id FROM knockout_ties WHERE leg1_match_id = ? OR leg2_match_id = ?", matchID, matchID).Scan(&existing)
+	if err == nil {
+		return nil
+	} else if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to query tie for match %d: %w", matchID, err)
+	}
+
+	seasonID, err := GetSeasonIDAt(db, int(leagueID.Int64), startDate)
+	if err != nil {
+		return err
+	}
+	var roundID int64
+	legs := 1
+	err = db.QueryRow("SELECT id, legs FROM knockout_rounds WHERE league_id = ? AND stage_id = ? AND season_id <=> ?",
+		leagueID, stageID, seasonID).Scan(&roundID, &legs)
+	if err == sql.ErrNoRows {
+		res, err := db.Exec("INSERT INTO knockout_rounds (league_id, season_id, stage_id) VALUES (?, ?, ?)", leagueID, seasonID, stageID)
+		if err != nil {
+			return fmt.Errorf("failed to create knockout round for league %d stage %d: %w", leagueID.Int64, stageID.Int64, err)
+		}
+		roundID, _ = res.LastInsertId()
+	} else if err != nil {
+		return fmt.Errorf("failed to query knockout round: %w", err)
+	}
+
+	var tieID int64
+	var leg1, leg2 sql.NullInt64
+	err = db.QueryRow(`
+		SELECT id, leg1_match_id, leg2_match_id FROM knockout_ties
+		WHERE round_id = ? AND ((team_a_id = ? AND team_b_id = ?) OR (team_a_id = ? AND team_b_id = ?))
+		LIMIT 1`, roundID, homeID, awayID, awayID, homeID).Scan(&tieID, &leg1, &leg2)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = db.Exec(`
+			INSERT INTO knockout_ties (round_id, bracket_position, team_a_id, team_b_id, leg1_match_id)
+			SELECT ?, COALESCE(MAX(bracket_position), 0) + 1, ?, ?, ? FROM knockout_ties WHERE round_id = ?`,
+			roundID, homeID, awayID, matchID, roundID)
+	case err != nil:
+		return fmt.Errorf("failed to query knockout tie: %w", err)
+	case !leg1.Valid:
+		// คู่ที่สร้างจากผู้ชนะรอบก่อน ยังไม่มีแมตช์
+		_, err = db.Exec("UPDATE knockout_ties SET leg1_match_id = ?, team_a_id = ?, team_b_id = ? WHERE id = ?",
+			matchID, homeID, awayID, tieID)
+	case legs < 2:
+		log.Printf("Warning: round %d is single-leg, match %d of tie %d not linked (set legs = 2 for two-legged rounds)", roundID, matchID, tieID)
+		return nil
+	case !leg2.Valid:
+		_, err = db.Exec("UPDATE knockout_ties SET leg2_match_id = ? WHERE id = ?", matchID, tieID)
+	default:
+		log.Printf("Warning: tie %d already has two legs, match %d not linked", tieID, matchID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to link match %d to bracket: %w", matchID, err)
+	}
+	return nil
+}
+
+// BracketRound คือหนึ่งรอบพร้อมคู่การแข่งขันทั้งหมด
+type BracketRound struct {
+	Round models.KnockoutRoundDB
+	Ties  []models.KnockoutTieDB
+}
+
+// GetBracket คืนทุกรอบของถ้วยในฤดูกาล เรียงตาม round_order แล้วตามวันที่นัดแรกของรอบ
+// seasonID ไม่ Valid = ใช้ฤดูกาลล่าสุดที่มีข้อมูลสายการแข่งขัน
+func GetBracket(db *sql.DB, leagueID int, seasonID sql.NullInt64) ([]BracketRound, sql.NullInt64, error) {
+	if !seasonID.Valid {
+		err := db.QueryRow("SELECT season_id FROM knockout_rounds WHERE league_id = ? ORDER BY season_id IS NULL, season_id DESC LIMIT 1", leagueID).Scan(&seasonID)
+		if err == sql.ErrNoRows {
+			return nil, seasonID, nil
+		} else if err != nil {
+			return nil, seasonID, fmt.Errorf("failed to find bracket season for league %d: %w", leagueID, err)
+		}
+	}
+
+	rows, err := db.Query(`
+		SELECT r.id, r.league_id, r.season_id, r.stage_id, s.stage_name, r.round_order, r.legs, r.away_goals,
+			(SELECT DATE_FORMAT(MIN(m.start_date), '%Y-%m-%d') FROM knockout_ties kt
+				JOIN matches m ON m.id = kt.leg1_match_id WHERE kt.round_id = r.id)
+		FROM knockout_rounds r
+		JOIN stage s ON s.id = r.stage_id
+		WHERE r.league_id = ? AND r.season_id <=> ?`, leagueID, seasonID)
+	if err != nil {
+		return nil, seasonID, fmt.Errorf("failed to query knockout rounds for league %d: %w", leagueID, err)
+	}
+	var rounds []BracketRound
+	index := map[int]int{}
+	for rows.Next() {
+		var r models.KnockoutRoundDB
+		if err := rows.Scan(&r.ID, &r.LeagueID, &r.SeasonID, &r.StageID, &r.StageName, &r.RoundOrder, &r.Legs, &r.AwayGoals, &r.FirstDate); err != nil {
+			rows.Close()
+			return nil, seasonID, err
+		}
+		index[r.ID] = len(rounds)
+		rounds = append(rounds, BracketRound{Round: r})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, seasonID, err
+	}
+	sort.SliceStable(rounds, func(i, j int) bool {
+		a, b := rounds[i].Round, rounds[j].Round
+		if a.RoundOrder.Valid != b.RoundOrder.Valid {
+			return a.RoundOrder.Valid
+		}
+		if a.RoundOrder.Valid && a.RoundOrder.Int64 != b.RoundOrder.Int64 {
+			return a.RoundOrder.Int64 < b.RoundOrder.Int64
+		}
+		return a.FirstDate.String < b.FirstDate.String
+	})
+	for i, r := range rounds {
+		index[r.Round.ID] = i
+	}
+
+	ties, err := loadKnockoutTies(db, "r.league_id = ? AND r.season_id <=> ?", leagueID, seasonID)
+	if err != nil {
+		return nil, seasonID, err
+	}
+	for _, t := range ties {
+		if i, ok := index[t.RoundID]; ok {
+			rounds[i].Ties = append(rounds[i].Ties, t)
+		}
+	}
+	return rounds, seasonID, nil
+}
+
+// loadKnockoutTies โหลดคู่การแข่งขันพร้อมผลของแต่ละเลกตามเงื่อนไขของรอบ (alias r)
+func loadKnockoutTies(db *sql.DB, where string, args ...interface{}) ([]models.KnockoutTieDB, error) {
+	rows, err := db.Query(`
+		SELECT kt.id, kt.round_id, kt.bracket_position, kt.team_a_id, kt.team_b_id, kt.extra_time,
+			kt.penalties_a, kt.penalties_b, kt.winner_team_id, kt.next_tie_id, kt.next_slot,
+			m1.id, DATE_FORMAT(m1.start_date, '%Y-%m-%d'), m1.home_team_id, m1.away_team_id, m1.home_score, m1.away_score,
+			(m1.home_score IS NOT NULL AND m1.away_score IS NOT NULL AND m1.kickoff_at <= UTC_TIMESTAMP()
+				AND m1.match_status NOT IN `+noResultStatuses+`),
+			m1.home_score_et, m1.away_score_et, m1.home_penalties, m1.away_penalties, m1.decided_by,
+			DATE_FORMAT(m1.kickoff_at, '%Y-%m-%d %H:%i:%s'), m1.timezone,
+			m2.id, DATE_FORMAT(m2.start_date, '%Y-%m-%d'), m2.home_team_id, m2.away_team_id, m2.home_score, m2.away_score,
+			(m2.home_score IS NOT NULL AND m2.away_score IS NOT NULL AND m2.kickoff_at <= UTC_TIMESTAMP()
+				AND m2.match_status NOT IN `+noResultStatuses+`),
+			m2.home_score_et, m2.away_score_et, m2.home_penalties, m2.away_penalties, m2.decided_by,
+			DATE_FORMAT(m2.kickoff_at, '%Y-%m-%d %H:%i:%s'), m2.timezone
+		FROM knockout_ties kt
+		JOIN knockout_rounds r ON r.id = kt.round_id
+		LEFT JOIN matches m1 ON m1.id = kt.leg1_match_id
+		LEFT JOIN matches m2 ON m2.id = kt.leg2_match_id
+		WHERE `+where+`
+		ORDER BY kt.round_id, kt.bracket_position IS NULL, kt.bracket_position, kt.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query knockout ties: %w", err)
+	}
+	defer rows.Close()
+	var ties []models.KnockoutTieDB
+	for rows.Next() {
+		var t models.KnockoutTieDB
+		var legs [2]struct {
+			id, home, away, hs, as   sql.NullInt64
+			hsET, asET, hPens, aPens sql.NullInt64
+			date, decidedBy          sql.NullString
+			kickoffAt, zone          sql.NullString
+			finished                 sql.NullBool
+		}
+		if err := rows.Scan(&t.ID, &t.RoundID, &t.BracketPosition, &t.TeamAID, &t.TeamBID, &t.ExtraTime,
+			&t.PenaltiesA, &t.PenaltiesB, &t.WinnerTeamID, &t.NextTieID, &t.NextSlot,
+			&legs[0].id, &legs[0].date, &legs[0].home, &legs[0].away, &legs[0].hs, &legs[0].as, &legs[0].finished,
+			&legs[0].hsET, &legs[0].asET, &legs[0].hPens, &legs[0].aPens, &legs[0].decidedBy,
+			&legs[0].kickoffAt, &legs[0].zone,
+			&legs[1].id, &legs[1].date, &legs[1].home, &legs[1].away, &legs[1].hs, &legs[1].as, &legs[1].finished,
+			&legs[1].hsET, &legs[1].asET, &legs[1].hPens, &legs[1].aPens, &legs[1].decidedBy,
+			&legs[1].kickoffAt, &legs[1].zone); err != nil {
+			return nil, err
+		}
+		for i, l := range legs {
+			if !l.id.Valid {
+				continue
+			}
+			leg := &models.KnockoutLeg{
+				MatchID: int(l.id.Int64), StartDate: l.date.String,
+				HomeTeamID: int(l.home.Int64), AwayTeamID: int(l.away.Int64),
+				HomeScore: nullIntPtr(l.hs), AwayScore: nullIntPtr(l.as), Finished: l.finished.Bool,
+				HomeScoreET: nullIntPtr(l.hsET), AwayScoreET: nullIntPtr(l.asET),
+				HomePenalties: nullIntPtr(l.hPens), AwayPenalties: nullIntPtr(l.aPens),
+				DecidedBy: nullStringPtr(l.decidedBy),
+				KickoffAt: l.kickoffAt, Timezone: l.zone,
+			}
+			if i == 0 {
+				t.Leg1 = leg
+			} else {
+				t.Leg2 = leg
+			}
+		}
+		ties = append(ties, t)
+	}
+	return ties, rows.Err()
+}
+
+// ApplyBracketProgression ส่งผู้ชนะของคู่ที่จบแล้วเข้าช่องของคู่ถัดไป (next_tie_id/next_slot)
+// ถ้าช่องนั้นมีทีมจากคู่นี้อยู่แล้ว (ผู้ชนะเดิมก่อนแก้ผลหรือกำหนด winner_team_id ใหม่) จะเขียนทับด้วยผู้ชนะปัจจุบัน
+// ช่องที่เป็นทีมอื่น (เช่น ผูกจากแมตช์จริงหรือกรอกเอง) จะไม่ถูกแตะ
+func ApplyBracketProgression(db *sql.DB, leagueID int) error {
+	ties, err := loadKnockoutTies(db, "r.league_id = ? AND kt.next_tie_id IS NOT NULL", leagueID)
+	if err != nil {
+		return err
+	}
+	rounds := map[int][2]int{} // round_id -> legs, away_goals
+	for _, t := range ties {
+		cfg, ok := rounds[t.RoundID]
+		if !ok {
+			var legs int
+			var away bool
+			if err := db.QueryRow("SELECT legs, away_goals FROM knockout_rounds WHERE id = ?", t.RoundID).Scan(&legs, &away); err != nil {
+				return fmt.Errorf("failed to load knockout round %d: %w", t.RoundID, err)
+			}
+			cfg = [2]int{legs, 0}
+			if away {
+				cfg[1] = 1
+			}
+			rounds[t.RoundID] = cfg
+		}
+		res := bracket.Resolve(t, cfg[0], cfg[1] == 1)
+		if res.WinnerTeamID == nil {
+			continue
+		}
+		column := "team_a_id"
+		if t.NextSlot.String == "b" {
+			column = "team_b_id"
+		}
+		if _, err := db.Exec("UPDATE knockout_ties SET "+column+" = ? WHERE id = ? AND ("+column+" IS NULL OR "+column+" IN (?, ?))",
+			*res.WinnerTeamID, t.NextTieID.Int64, t.TeamAID, t.TeamBID); err != nil {
+			return fmt.Errorf("failed to advance winner of tie %d: %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+// KnockoutTieUpdate คือค่าที่แก้ไขได้ของคู่ (nil = ไม่เปลี่ยน)
+type KnockoutTieUpdate struct {
+	BracketPosition *int    `json:"bracket_position"`
+	ExtraTime       *bool   `json:"extra_time"`
+	PenaltiesA      *int    `json:"penalties_a"`
+	PenaltiesB      *int    `json:"penalties_b"`
+	WinnerTeamID    *int    `json:"winner_team_id"` // 0 = ล้างค่า
+	NextTieID       *int    `json:"next_tie_id"`    // 0 = ล้างค่า
+	NextSlot        *string `json:"next_slot"`
+}
+
+// UpdateKnockoutTie แก้ไขข้อมูลของคู่ ค่าลบของจุดโทษหมายถึงล้างค่า
+func UpdateKnockoutTie(db *sql.DB, tieID int, u KnockoutTieUpdate) error {
+	sets := ""
+	var args []interface{}
+	add := func(col string, v interface{}) {
+		if sets != "" {
+			sets += ", "
+		}
+		sets += col + " = ?"
+		args = append(args, v)
+	}
+	optionalID := func(v int) sql.NullInt64 { return sql.NullInt64{Int64: int64(v), Valid: v > 0} }
+	if u.BracketPosition != nil {
+		add("bracket_position", *u.BracketPosition)
+	}
+	if u.ExtraTime != nil {
+		add("extra_time", *u.ExtraTime)
+	}
+	if u.PenaltiesA != nil {
+		add("penalties_a", sql.NullInt64{Int64: int64(*u.PenaltiesA), Valid: *u.PenaltiesA >= 0})
+	}
+	if u.PenaltiesB != nil {
+		add("penalties_b", sql.NullInt64{Int64: int64(*u.PenaltiesB), Valid: *u.PenaltiesB >= 0})
+	}
+	if u.WinnerTeamID != nil {
+		add("winner_team_id", optionalID(*u.WinnerTeamID))
+	}
+	if u.NextTieID != nil {
+		add("next_tie_id", optionalID(*u.NextTieID))
+	}
+	if u.NextSlot != nil {
+		if *u.NextSlot != "a" && *u.NextSlot != "b" {
+			return fmt.Errorf("next_slot must be a or b")
+		}
+		add("next_slot", *u.NextSlot)
+	}
+	if sets == "" {
+		return nil
+	}
+	args = append(args, tieID)
+	if _, err := db.Exec("UPDATE knockout_ties SET "+sets+" WHERE id = ?", args...); err != nil {
+		return fmt.Errorf("failed to update knockout tie %d: %w", tieID, err)
+	}
+	return nil
+}
+
+// GetSeasonIDByName คืน id ของฤดูกาลตามชื่อในลีก
+func GetSeasonIDByName(db *sql.DB, name string, leagueID int) (int, error) {
+	var id int
+	if err := db.QueryRow("SELECT id FROM seasons WHERE name = ? AND league_id = ? LIMIT 1", name, leagueID).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get season %s for league %d: %w", name, leagueID, err)
+	}
+	return id, nil
+}
+
+// KnockoutRoundUpdate คือค่าที่แก้ไขได้ของรอบ (nil = ไม่เปลี่ยน)
+type KnockoutRoundUpdate struct {
+	RoundOrder *int  `json:"round_order"`
+	Legs       *int  `json:"legs"`
+	AwayGoals  *bool `json:"away_goals"`
+}
+
+// UpdateKnockoutRound แก้ไขลำดับ จำนวนเลก และกฎประตูทีมเยือนของรอบ
+func UpdateKnockoutRound(db *sql.DB, roundID int, u KnockoutRoundUpdate) error {
+	if u.Legs != nil && *u.Legs != 1 && *u.Legs != 2 {
+		return fmt.Errorf("legs must be 1 or 2")
+	}
+	_, err := db.Exec(`
+		UPDATE knockout_rounds SET
+			round_order = IF(?, ?, round_order),
+			legs = COALESCE(?, legs),
+			away_goals = COALESCE(?, away_goals)
+		WHERE id = ?`, u.RoundOrder != nil, u.RoundOrder, u.Legs, u.AwayGoals, roundID)
+	if err != nil {
+		return fmt.Errorf("failed to update knockout round %d: %w", roundID, err)
+	}
+	return nil
+}
+
+// GetKnockoutTieLeagueID คืน league_id ของคู่
+func GetKnockoutTieLeagueID(db *sql.DB, tieID int) (int, error) {
+	var leagueID int
+	err := db.QueryRow(`
+		SELECT r.league_id FROM knockout_ties kt JOIN knockout_rounds r ON r.id = kt.round_id
+		WHERE kt.id = ?`, tieID).Scan(&leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get league for tie %d: %w", tieID, err)
+	}
+	return leagueID, nil
+}
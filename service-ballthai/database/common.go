@@ -120,27 +120,40 @@ func GetLeagueID(db *sql.DB, leagueName, leagueNameThai string) (int, error) {
 	return leagueID, nil
 }
 
-// GetStageID checks if stage exists by name, inserts if not, and returns ID
+// GetStageID คืน stage ของลีกในฤดูกาลปัจจุบันตามชื่อ ถ้าไม่มีจะสร้างใหม่
+// (ชื่อเดียวกันในลีกหรือฤดูกาลอื่นเป็นคนละ stage เช่น "โซนเหนือ" ของ T3 แต่ละปี)
+// ใช้กับข้อมูลที่เป็นของวันนี้ เช่นตารางคะแนนที่ดึงมา แมตช์ใช้ GetStageIDAt กับวันแข่ง
 func GetStageID(db *sql.DB, stageName string, leagueID int) (int, error) {
-	var stageID int
-	query := "SELECT id FROM stage WHERE REPLACE(stage_name, ' ', '') = REPLACE(?, ' ', '')"
-	err := db.QueryRow(query, stageName).Scan(&stageID)
+	seasonID, err := GetCurrentSeasonID(db, leagueID)
+	if err != nil {
+		return 0, err
+	}
+	return GetOrCreateStage(db, leagueID, seasonID, stageName)
+}
 
-	if err == sql.ErrNoRows {
-		insertQuery := `INSERT INTO stage (stage_name) VALUES (?)`
-		result, err := db.Exec(insertQuery, stageName)
-		if err != nil {
-			return 0, fmt.Errorf("failed to insert new stage %s: %w", stageName, err)
-		}
-		newID, err := result.LastInsertId()
-		if err != nil {
-			return 0, fmt.Errorf("failed to get last insert ID for stage %s: %w", stageName, err)
-		}
-		log.Printf("Inserted new stage: %s (ID: %d)", stageName, newID)
-		return int(newID), nil
-	} else if err != nil {
-		return 0, fmt.Errorf("failed to query stage by name %s: %w", stageName, err)
+// GetStageIDAt เหมือน GetStageID แต่ใช้ฤดูกาลที่ครอบคลุมวันที่ date (YYYY-MM-DD)
+// เช่น แมตช์ย้อนหลังของฤดูกาลก่อนจะได้ stage ของฤดูกาลนั้น ไม่ใช่ของฤดูกาลปัจจุบัน
+func GetStageIDAt(db *sql.DB, stageName string, leagueID int, date string) (int, error) {
+	seasonID, err := GetSeasonIDAt(db, leagueID, date)
+	if err != nil {
+		return 0, err
+	}
+	return GetOrCreateStage(db, leagueID, seasonID, stageName)
+}
+
+// nullIntPtr แปลง sql.NullInt64 เป็น *int (nil เมื่อเป็น NULL) สำหรับส่งออก JSON
+func nullIntPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+// nullStringPtr แปลง sql.NullString เป็น *string (nil เมื่อเป็น NULL)
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
 	}
-	log.Printf("Found existing stage: %s (ID: %d)", stageName, stageID)
-	return stageID, nil
+	return &s.String
 }
@@ -0,0 +1,197 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go-ballthai-scraper/models"
+)
+
+// GetDisciplineRules คืนเกณฑ์โทษแบนของลีก ถ้าไม่มีแถวใน discipline_rules จะคืนค่า default
+func GetDisciplineRules(db *sql.DB, leagueID int) (models.DisciplineRules, error) {
+	rules := models.DisciplineRules{
+		LeagueID: leagueID, YellowThreshold: 4, YellowBanMatches: 1, RedBanMatches: 1,
+		FairPlayYellowPoints: 1, FairPlayRedPoints: 3,
+	}
+	err := db.QueryRow(`
+		SELECT yellow_threshold, yellow_ban_matches, red_ban_matches, fair_play_yellow_points, fair_play_red_points
+		FROM discipline_rules WHERE league_id = ?`, leagueID).
+		Scan(&rules.YellowThreshold, &rules.YellowBanMatches, &rules.RedBanMatches, &rules.FairPlayYellowPoints, &rules.FairPlayRedPoints)
+	if err != nil && err != sql.ErrNoRows {
+		return rules, fmt.Errorf("failed to query discipline rules for league %d: %w", leagueID, err)
+	}
+	return rules, nil
+}
+
+// RecordDisciplineChanges บันทึกโทษแบนเมื่อใบเหลืองสะสมข้ามเกณฑ์หรือใบแดงเพิ่มขึ้นจากค่าเดิม
+// เรียกจาก InsertOrUpdatePlayer หลังอัปเดตสถิติผู้เล่นที่มีอยู่แล้ว
+func RecordDisciplineChanges(db *sql.DB, playerID int, leagueID, teamID sql.NullInt64, oldYellow, newYellow, oldRed, newRed int) error {
+	if !leagueID.Valid || (newYellow <= oldYellow && newRed <= oldRed) {
+		return nil
+	}
+	rules, err := GetDisciplineRules(db, int(leagueID.Int64))
+	if err != nil {
+		return err
+	}
+	// ผู้เล่นที่ถูกรวมแล้ว บันทึกโทษไว้ที่ record หลัก
+	if err := db.QueryRow("SELECT COALESCE(merged_into_id, id) FROM players WHERE id = ?", playerID).Scan(&playerID); err != nil {
+		return fmt.Errorf("failed to resolve player %d: %w", playerID, err)
+	}
+	incurred := lastTeamMatchDate(db, leagueID, teamID)
+	// ใบสะสมนับใหม่ทุกฤดูกาล จึงผูกโทษกับฤดูกาลของวันที่ได้รับโทษ
+	seasonID, err := GetSeasonIDAt(db, int(leagueID.Int64), incurred)
+	if err != nil {
+		return err
+	}
+
+	insert := func(reason string, count, banned int) error {
+		_, err := db.Exec(`
+			INSERT IGNORE INTO suspensions (player_id, league_id, season_id, team_id, reason, card_count, matches_banned, incurred_date)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, playerID, leagueID, seasonID, teamID, reason, count, banned, incurred)
+		if err != nil {
+			return fmt.Errorf("failed to record %s suspension for player %d: %w", reason, playerID, err)
+		}
+		log.Printf("Recorded %s suspension for player %d (count %d, %d match(es))", reason, playerID, count, banned)
+		return nil
+	}
+	if rules.YellowThreshold > 0 {
+		for n := (oldYellow/rules.YellowThreshold + 1) * rules.YellowThreshold; n <= newYellow; n += rules.YellowThreshold {
+			if err := insert("yellow_cards", n, rules.YellowBanMatches); err != nil {
+				return err
+			}
+		}
+	}
+	for n := oldRed + 1; n <= newRed; n++ {
+		if err := insert("red_card", n, rules.RedBanMatches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastTeamMatchDate คืนวันที่ของนัดล่าสุดที่จบแล้วของทีมในลีก (ถ้าไม่พบใช้วันนี้)
+func lastTeamMatchDate(db *sql.DB, leagueID, teamID sql.NullInt64) string {
+	today := time.Now().Format("2006-01-02")
+	if !teamID.Valid {
+		return today
+	}
+	var date sql.NullString
+	err := db.QueryRow(`
+		SELECT DATE_FORMAT(MAX(start_date), '%Y-%m-%d') FROM matches
+		WHERE league_id = ? AND (home_team_id = ? OR away_team_id = ?)
+			AND home_score IS NOT NULL AND away_score IS NOT NULL
+			AND kickoff_at <= UTC_TIMESTAMP()
+			AND match_status NOT IN `+noResultStatuses, leagueID, teamID, teamID).Scan(&date)
+	if err != nil || !date.Valid {
+		return today
+	}
+	return date.String
+}
+
+// GetActiveSuspensions คืนโทษแบนในลีกที่ยังรับโทษไม่ครบ
+// จำนวนนัดที่รับโทษแล้ว = นัดที่จบแล้วของทีมในลีกหลัง incurred_date
+func GetActiveSuspensions(db *sql.DB, leagueID int) ([]models.SuspensionDB, error) {
+	rows, err := db.Query(`
+		SELECT s.id, s.player_id, p.name, s.team_id, t.name_th, s.reason, s.card_count, s.matches_banned,
+			DATE_FORMAT(s.incurred_date, '%Y-%m-%d'),
+			(SELECT COUNT(*) FROM matches m
+				WHERE m.league_id = s.league_id AND (m.home_team_id = s.team_id OR m.away_team_id = s.team_id)
+					AND m.start_date > s.incurred_date
+					AND m.home_score IS NOT NULL AND m.away_score IS NOT NULL
+					AND m.kickoff_at <= UTC_TIMESTAMP()
+					AND m.match_status NOT IN `+noResultStatuses+`) AS served
+		FROM suspensions s
+		JOIN players p ON s.player_id = p.id
+		LEFT JOIN teams t ON s.team_id = t.id
+		WHERE s.league_id = ?
+		HAVING served < s.matches_banned
+		ORDER BY t.name_th ASC, p.name ASC`, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suspensions for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+	suspensions := []models.SuspensionDB{}
+	for rows.Next() {
+		var s models.SuspensionDB
+		var teamID sql.NullInt64
+		var teamName sql.NullString
+		if err := rows.Scan(&s.ID, &s.PlayerID, &s.PlayerName, &teamID, &teamName, &s.Reason, &s.CardCount,
+			&s.MatchesBanned, &s.IncurredDate, &s.MatchesServed); err != nil {
+			return nil, err
+		}
+		s.TeamID, s.TeamName = nullIntPtr(teamID), nullStringPtr(teamName)
+		suspensions = append(suspensions, s)
+	}
+	return suspensions, rows.Err()
+}
+
+// GetPlayersOneBookingFromBan คืนผู้เล่นในลีกที่ขาดอีกหนึ่งใบเหลืองจะถูกแบน (ไม่รวม record ที่ถูกรวมเข้ากับผู้เล่นอื่นแล้ว)
+func GetPlayersOneBookingFromBan(db *sql.DB, leagueID, threshold int) ([]models.PlayerBookingDB, error) {
+	players := []models.PlayerBookingDB{}
+	if threshold < 2 {
+		return players, nil
+	}
+	rows, err := db.Query(`
+		SELECT p.id, p.name, p.team_id, t.name_th, p.yellow_cards, p.red_cards
+		FROM players p
+		LEFT JOIN teams t ON p.team_id = t.id
+		WHERE p.league_id = ? AND p.merged_into_id IS NULL AND p.yellow_cards > 0 AND MOD(p.yellow_cards, ?) = ?
+		ORDER BY t.name_th ASC, p.name ASC`, leagueID, threshold, threshold-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players near suspension for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p models.PlayerBookingDB
+		var teamID sql.NullInt64
+		var teamName sql.NullString
+		if err := rows.Scan(&p.PlayerID, &p.PlayerName, &teamID, &teamName, &p.YellowCards, &p.RedCards); err != nil {
+			return nil, err
+		}
+		p.TeamID, p.TeamName = nullIntPtr(teamID), nullStringPtr(teamName)
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// GetFairPlayTable คืนตาราง fair play ของลีกจากใบเหลือง/ใบแดงสะสมของผู้เล่น เรียงจากคะแนนน้อยไปมาก
+func GetFairPlayTable(db *sql.DB, rules models.DisciplineRules) ([]models.FairPlayRow, error) {
+	rows, err := db.Query(`
+		SELECT t.id, t.name_th, COALESCE(SUM(p.yellow_cards), 0) AS yellows, COALESCE(SUM(p.red_cards), 0) AS reds
+		FROM players p
+		JOIN teams t ON p.team_id = t.id
+		WHERE p.league_id = ? AND p.merged_into_id IS NULL
+		GROUP BY t.id, t.name_th`, rules.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fair play table for league %d: %w", rules.LeagueID, err)
+	}
+	defer rows.Close()
+	table := []models.FairPlayRow{}
+	for rows.Next() {
+		var r models.FairPlayRow
+		if err := rows.Scan(&r.TeamID, &r.TeamName, &r.YellowCards, &r.RedCards); err != nil {
+			return nil, err
+		}
+		r.Points = r.YellowCards*rules.FairPlayYellowPoints + r.RedCards*rules.FairPlayRedPoints
+		table = append(table, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points < table[j].Points
+		}
+		return table[i].RedCards < table[j].RedCards
+	})
+	for i := range table {
+		table[i].Rank = i + 1
+		if i > 0 && table[i].Points == table[i-1].Points && table[i].RedCards == table[i-1].RedCards {
+			table[i].Rank = table[i-1].Rank
+		}
+	}
+	return table, nil
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/models"
+)
+
+// CommitFixtures บันทึกแมตช์จากโปรแกรมที่สร้าง (ตรวจด้วย models.ValidateMatch มาก่อนแล้ว) ใน transaction เดียว
+// ทุกแมตช์เป็น scheduled และได้ match_ref_id ติดลบแบบเดียวกับแมตช์ที่สร้างเอง
+// ถ้าแมตช์ใดบันทึกไม่ได้จะไม่บันทึกเลยสักนัด; คืน id ของแมตช์ตามลำดับ matches
+func CommitFixtures(db *sql.DB, matches []models.MatchInput) ([]int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// จองเลขทั้งชุดในครั้งเดียว (ล็อกแถวตัวนับไว้จน commit/rollback)
+	first, err := models.AllocateManualMatchRefs(tx, len(matches))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(matches))
+	for i, m := range matches {
+		ref := first - i
+		var stageID interface{}
+		if m.StageID != nil && *m.StageID > 0 {
+			stageID = *m.StageID
+		}
+		zone, kickoffAt := models.MatchKickoff(db, sql.NullInt64{Int64: int64(m.LeagueID), Valid: m.LeagueID > 0},
+			sql.NullInt64{Int64: int64(m.HomeTeamID), Valid: m.HomeTeamID > 0}, m.StartDate, m.StartTime)
+		result, err := tx.Exec(`
+			INSERT INTO matches (
+				match_ref_id, league_id, stage_id, start_date, start_time,
+				home_team_id, away_team_id, match_status, timezone, kickoff_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			ref, m.LeagueID, stageID, m.StartDate, m.StartTime,
+			m.HomeTeamID, m.AwayTeamID, string(models.StatusScheduled), zone, kickoffAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert fixture %d vs %d on %s: %w", m.HomeTeamID, m.AwayTeamID, m.StartDate, err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last insert ID for fixture: %w", err)
+		}
+		ids = append(ids, int(id))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit fixtures: %w", err)
+	}
+	return ids, nil
+}
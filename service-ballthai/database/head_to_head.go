@@ -0,0 +1,38 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/models"
+)
+
+// GetTeamMeetings คืนทุกนัดที่ทีม a และ b พบกัน (ทุกรายการแข่งขัน) เรียงจากเก่าไปใหม่
+// Finished = มีสกอร์ เวลาเตะผ่านไปแล้ว และไม่ใช่สถานะที่ไม่มีผล (เลื่อน/ยกเลิก/ยุติ/พัก)
+func GetTeamMeetings(db *sql.DB, teamA, teamB int) ([]models.TeamMeetingDB, error) {
+	rows, err := db.Query(`
+		SELECT m.id, DATE_FORMAT(m.start_date, '%Y-%m-%d'), TIME_FORMAT(m.start_time, '%H:%i:%s'),
+			m.league_id, l.name, l.name_en, m.home_team_id, m.away_team_id, m.home_score, m.away_score, m.match_status,
+			(m.home_score IS NOT NULL AND m.away_score IS NOT NULL AND m.kickoff_at <= UTC_TIMESTAMP()
+				AND m.match_status NOT IN `+noResultStatuses+`) AS finished,
+			DATE_FORMAT(m.kickoff_at, '%Y-%m-%d %H:%i:%s'), m.timezone
+		FROM matches m
+		LEFT JOIN leagues l ON m.league_id = l.id
+		WHERE (m.home_team_id = ? AND m.away_team_id = ?) OR (m.home_team_id = ? AND m.away_team_id = ?)
+		ORDER BY m.start_date ASC, m.start_time ASC`, teamA, teamB, teamB, teamA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query meetings between team %d and %d: %w", teamA, teamB, err)
+	}
+	defer rows.Close()
+	var meetings []models.TeamMeetingDB
+	for rows.Next() {
+		var m models.TeamMeetingDB
+		if err := rows.Scan(&m.ID, &m.StartDate, &m.StartTime, &m.LeagueID, &m.LeagueName, &m.LeagueNameEN,
+			&m.HomeTeamID, &m.AwayTeamID, &m.HomeScore, &m.AwayScore, &m.MatchStatus, &m.Finished,
+			&m.KickoffAt, &m.Timezone); err != nil {
+			return nil, err
+		}
+		meetings = append(meetings, m)
+	}
+	return meetings, rows.Err()
+}
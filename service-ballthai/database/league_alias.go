@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-ballthai-scraper/romanize"
+)
+
+// NormaliseLeagueCode ทำ slug/alias ให้อยู่ในรูปเดียวกัน (ตัวพิมพ์เล็ก, ตัดช่องว่าง)
+// alias เก่าอย่าง league_cup ยังใช้ขีดล่างได้ จึงไม่แปลงเป็น slug เต็มรูปแบบ
+func NormaliseLeagueCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// GetLeagueSlugs คืน map league_id -> slug และ league_id -> aliases ของทุกลีก
+func GetLeagueSlugs(db *sql.DB) (map[int]string, map[int][]string, error) {
+	slugs := map[int]string{}
+	rows, err := db.Query("SELECT id, slug FROM leagues WHERE slug IS NOT NULL AND slug != ''")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query league slugs: %w", err)
+	}
+	for rows.Next() {
+		var id int
+		var slug string
+		if err := rows.Scan(&id, &slug); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		slugs[id] = slug
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	aliases := map[int][]string{}
+	rows, err = db.Query("SELECT league_id, alias FROM league_aliases ORDER BY league_id, alias")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query league aliases: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var alias string
+		if err := rows.Scan(&id, &alias); err != nil {
+			return nil, nil, err
+		}
+		aliases[id] = append(aliases[id], alias)
+	}
+	return slugs, aliases, rows.Err()
+}
+
+// SetLeagueSlugAndAliases บันทึก slug และแทนที่ alias ทั้งหมดของลีก
+// slug ว่างจะสร้างจากชื่อลีก (name_en ก่อน) ส่วน aliases == nil คือคงค่าเดิม
+// slug/alias ที่ชนกับลีกอื่นจะได้ error duplicate entry จาก unique key
+func SetLeagueSlugAndAliases(db *sql.DB, leagueID int, slug string, aliases []string) error {
+	slug = NormaliseLeagueCode(slug)
+	if slug == "" {
+		var current, name string
+		err := db.QueryRow("SELECT COALESCE(slug, ''), COALESCE(NULLIF(name_en, ''), name) FROM leagues WHERE id = ?", leagueID).Scan(&current, &name)
+		if err != nil {
+			return fmt.Errorf("failed to load league %d: %w", leagueID, err)
+		}
+		slug = current
+		if slug == "" {
+			slug = romanize.Slug(name)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE leagues SET slug = NULLIF(?, '') WHERE id = ?", slug, leagueID); err != nil {
+		return fmt.Errorf("failed to set slug for league %d: %w", leagueID, err)
+	}
+	if aliases != nil {
+		if _, err := tx.Exec("DELETE FROM league_aliases WHERE league_id = ?", leagueID); err != nil {
+			return fmt.Errorf("failed to clear aliases for league %d: %w", leagueID, err)
+		}
+		seen := map[string]bool{slug: true}
+		for _, alias := range aliases {
+			alias = NormaliseLeagueCode(alias)
+			if alias == "" || seen[alias] {
+				continue
+			}
+			seen[alias] = true
+			if _, err := tx.Exec("INSERT INTO league_aliases (league_id, alias) VALUES (?, ?)", leagueID, alias); err != nil {
+				return fmt.Errorf("failed to add alias %q for league %d: %w", alias, leagueID, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// FindLeagueCodeConflict คืน code แรกที่ใช้เป็น slug หรือ alias ของลีกอื่นอยู่แล้ว ("" = ไม่ชน)
+// code ที่เป็นตัวเลขล้วนถือว่าชนเสมอ เพราะ resolver ตีความตัวเลขเป็น league id
+func FindLeagueCodeConflict(db *sql.DB, leagueID int, codes []string) (string, error) {
+	for _, code := range codes {
+		code = NormaliseLeagueCode(code)
+		if code == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(code); err == nil {
+			return code, nil
+		}
+		var taken bool
+		err := db.QueryRow(`
+			SELECT EXISTS(SELECT 1 FROM leagues WHERE slug = ? AND id != ?)
+				OR EXISTS(SELECT 1 FROM league_aliases WHERE alias = ? AND league_id != ?)`,
+			code, leagueID, code, leagueID).Scan(&taken)
+		if err != nil {
+			return "", fmt.Errorf("failed to check league code %q: %w", code, err)
+		}
+		if taken {
+			return code, nil
+		}
+	}
+	return "", nil
+}
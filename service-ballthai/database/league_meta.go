@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/models"
+)
+
+// LeagueMetadataColumns คือคอลัมน์ข้อมูลประกอบลีกตามลำดับที่ ScanLeagueMetadata อ่าน
+const LeagueMetadataColumns = "competition_type, tier, country, gender, age_group, logo_url, sponsor_name, primary_color, secondary_color, sort_order, min_rest_hours"
+
+// LeagueMetadataScanner เก็บค่าที่ scan จาก LeagueMetadataColumns ก่อนแปลงเป็น models.LeagueMetadata
+type LeagueMetadataScanner struct {
+	m                                              models.LeagueMetadata
+	tier, minRest                                  sql.NullInt64
+	country, ageGroup, logo, sponsor, prim, second sql.NullString
+}
+
+// NewLeagueMetadataScanner คืน scanner พร้อม dest สำหรับ rows.Scan (ต่อท้าย dest อื่นได้)
+func NewLeagueMetadataScanner() *LeagueMetadataScanner {
+	return &LeagueMetadataScanner{}
+}
+
+// Dest คืน pointer ของคอลัมน์ตามลำดับ LeagueMetadataColumns
+func (s *LeagueMetadataScanner) Dest() []interface{} {
+	return []interface{}{&s.m.CompetitionType, &s.tier, &s.country, &s.m.Gender, &s.ageGroup,
+		&s.logo, &s.sponsor, &s.prim, &s.second, &s.m.SortOrder, &s.minRest}
+}
+
+// Metadata คืนค่าที่ scan ได้
+func (s *LeagueMetadataScanner) Metadata() models.LeagueMetadata {
+	m := s.m
+	m.Tier, m.MinRestHours = nullIntPtr(s.tier), nullIntPtr(s.minRest)
+	m.Country, m.AgeGroup = nullStringPtr(s.country), nullStringPtr(s.ageGroup)
+	m.LogoURL, m.SponsorName = nullStringPtr(s.logo), nullStringPtr(s.sponsor)
+	m.PrimaryColor, m.SecondaryColor = nullStringPtr(s.prim), nullStringPtr(s.second)
+	return m
+}
+
+// GetLeagueMetadata คืนข้อมูลประกอบของลีก (sql.ErrNoRows ถ้าไม่พบ)
+func GetLeagueMetadata(db *sql.DB, leagueID int) (models.LeagueMetadata, error) {
+	s := NewLeagueMetadataScanner()
+	if err := db.QueryRow("SELECT "+LeagueMetadataColumns+" FROM leagues WHERE id = ?", leagueID).Scan(s.Dest()...); err != nil {
+		return models.LeagueMetadata{}, err
+	}
+	return s.Metadata(), nil
+}
+
+// SetLeagueMetadata บันทึกข้อมูลประกอบของลีก (ค่าควรผ่าน Validate มาแล้ว)
+func SetLeagueMetadata(db *sql.DB, leagueID int, m models.LeagueMetadata) error {
+	_, err := db.Exec(`
+		UPDATE leagues SET competition_type = ?, tier = ?, country = ?, gender = ?, age_group = ?,
+			logo_url = ?, sponsor_name = ?, primary_color = ?, secondary_color = ?, sort_order = ?,
+			min_rest_hours = ?
+		WHERE id = ?`,
+		m.CompetitionType, m.Tier, m.Country, m.Gender, m.AgeGroup,
+		m.LogoURL, m.SponsorName, m.PrimaryColor, m.SecondaryColor, m.SortOrder, m.MinRestHours, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to update metadata for league %d: %w", leagueID, err)
+	}
+	return nil
+}
+
+// SetLeagueLogo บันทึก path โลโก้ลีกที่อัปโหลด
+func SetLeagueLogo(db *sql.DB, leagueID int, logoURL string) error {
+	if _, err := db.Exec("UPDATE leagues SET logo_url = ? WHERE id = ?", logoURL, leagueID); err != nil {
+		return fmt.Errorf("failed to update logo for league %d: %w", leagueID, err)
+	}
+	return nil
+}
@@ -8,8 +8,18 @@ import (
 	"go-ballthai-scraper/models" // ตรวจสอบให้แน่ใจว่าชื่อโมดูลตรงกับ go.mod ของคุณ
 )
 
+// noResultStatuses คือสถานะที่สกอร์ไม่ใช่ผลการแข่งขัน ใช้ตัดออกจากเงื่อนไข "แมตช์ที่จบแล้ว"
+const noResultStatuses = "('postponed', 'cancelled', 'abandoned', 'suspended')"
+
+// inPlayStatuses คือสถานะที่แมตช์ยังเตะไม่จบ สกอร์เป็นผลระหว่างเกม ไม่นับเป็นผลในตารางคะแนน/ฟอร์ม
+const inPlayStatuses = "('live', 'half_time')"
+
 // InsertOrUpdateMatch inserts or updates a match record in the database
 func InsertOrUpdateMatch(db *sql.DB, match models.MatchDB) error {
+	if !match.KickoffAt.Valid {
+		zone, kickoffAt := models.MatchKickoff(db, match.LeagueID, match.HomeTeamID, match.StartDate, match.StartTime)
+		match.Timezone, match.KickoffAt = sql.NullString{String: zone, Valid: true}, kickoffAt
+	}
 	var existingMatchID int
 	query := "SELECT id FROM matches WHERE match_ref_id = ?"
 	err := db.QueryRow(query, match.MatchRefID).Scan(&existingMatchID)
@@ -20,13 +30,17 @@ func InsertOrUpdateMatch(db *sql.DB, match models.MatchDB) error {
 			INSERT INTO matches (
 				match_ref_id, start_date, start_time, league_id, stage_id,
 				home_team_id, away_team_id, channel_id, live_channel_id,
-				home_score, away_score, match_status
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				home_score, away_score, match_status,
+				home_score_ht, away_score_ht, home_score_et, away_score_et,
+				home_penalties, away_penalties, decided_by, timezone, kickoff_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 		_, err := db.Exec(insertQuery,
 			match.MatchRefID, match.StartDate, match.StartTime, match.LeagueID, match.StageID,
 			match.HomeTeamID, match.AwayTeamID, match.ChannelID, match.LiveChannelID,
 			match.HomeScore, match.AwayScore, match.MatchStatus,
+			match.HomeScoreHT, match.AwayScoreHT, match.HomeScoreET, match.AwayScoreET,
+			match.HomePenalties, match.AwayPenalties, match.DecidedBy, match.Timezone, match.KickoffAt,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert match %d: %w", match.MatchRefID, err)
@@ -36,17 +50,26 @@ func InsertOrUpdateMatch(db *sql.DB, match models.MatchDB) error {
 		return fmt.Errorf("failed to query existing match %d: %w", match.MatchRefID, err)
 	} else {
 		// Update existing match
+		// สกอร์ครึ่งแรก/ต่อเวลา/จุดโทษ ไม่ทับค่าที่บรรณาธิการกรอกไว้เมื่อ API ไม่ส่งมา
 		updateQuery := `
 			UPDATE matches SET
 				start_date = ?, start_time = ?, league_id = ?, stage_id = ?,
 				home_team_id = ?, away_team_id = ?, channel_id = ?, live_channel_id = ?,
-				home_score = ?, away_score = ?, match_status = ?
+				home_score = ?, away_score = ?, match_status = ?,
+				home_score_ht = COALESCE(?, home_score_ht), away_score_ht = COALESCE(?, away_score_ht),
+				home_score_et = COALESCE(?, home_score_et), away_score_et = COALESCE(?, away_score_et),
+				home_penalties = COALESCE(?, home_penalties), away_penalties = COALESCE(?, away_penalties),
+				decided_by = COALESCE(?, decided_by),
+				timezone = ?, kickoff_at = ?
 			WHERE match_ref_id = ?
 		`
 		_, err := db.Exec(updateQuery,
 			match.StartDate, match.StartTime, match.LeagueID, match.StageID,
 			match.HomeTeamID, match.AwayTeamID, match.ChannelID, match.LiveChannelID,
 			match.HomeScore, match.AwayScore, match.MatchStatus,
+			match.HomeScoreHT, match.AwayScoreHT, match.HomeScoreET, match.AwayScoreET,
+			match.HomePenalties, match.AwayPenalties, match.DecidedBy,
+			match.Timezone, match.KickoffAt,
 			match.MatchRefID,
 		)
 		if err != nil {
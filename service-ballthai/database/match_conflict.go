@@ -0,0 +1,218 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"go-ballthai-scraper/conflicts"
+	"go-ballthai-scraper/kickoff"
+	"go-ballthai-scraper/models"
+)
+
+// GetConflictCandidates คืนแมตช์ตั้งแต่วันที่ from (YYYY-MM-DD) ที่ยังไม่เลื่อน/ยกเลิก และฤดูกาลทั้งหมด สำหรับ conflicts.Detect
+// สนามใช้ matches.stadium_id ก่อน ถ้าไม่มีใช้สนามของทีมเหย้า, เวลาเตะใช้ kickoff_at (UTC)
+// ถ้าไม่มี kickoff_at จะแปลงวันเวลาท้องถิ่นเป็น UTC ด้วย timezone ของแมตช์ (แมตช์ > สนามทีมเหย้า > ลีก)
+// แมตช์ที่ไม่มีเวลาเตะเลยจะไม่ถูกตรวจ
+func GetConflictCandidates(db *sql.DB, from string) ([]conflicts.Match, []conflicts.Season, error) {
+	rows, err := db.Query(`
+		SELECT m.id, COALESCE(m.league_id, 0), COALESCE(st.season_id, 0), DATE_FORMAT(m.start_date, '%Y-%m-%d'),
+			DATE_FORMAT(m.kickoff_at, '%Y-%m-%d %H:%i:%s'), TIME_FORMAT(m.start_time, '%H:%i:%s'),
+			COALESCE(m.timezone, hs.timezone, l.timezone, ''),
+			COALESCE(m.home_team_id, 0), COALESCE(m.away_team_id, 0), COALESCE(m.stadium_id, ht.stadium_id, 0),
+			COALESCE(m.channel_id, 0), COALESCE(m.live_channel_id, 0), COALESCE(l.min_rest_hours, 0)
+		FROM matches m
+		LEFT JOIN stage st ON st.id = m.stage_id
+		LEFT JOIN teams ht ON ht.id = m.home_team_id
+		LEFT JOIN stadiums hs ON hs.id = ht.stadium_id
+		LEFT JOIN leagues l ON l.id = m.league_id
+		WHERE m.start_date >= ? AND COALESCE(m.match_status, '') NOT IN ('postponed', 'cancelled')`, from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query matches for conflict check: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []conflicts.Match
+	for rows.Next() {
+		var m conflicts.Match
+		var kickoffAt, startTime sql.NullString
+		var zone string
+		var channel, live int
+		if err := rows.Scan(&m.ID, &m.LeagueID, &m.SeasonID, &m.StartDate, &kickoffAt, &startTime, &zone,
+			&m.HomeTeamID, &m.AwayTeamID, &m.StadiumID, &channel, &live, &m.RestHours); err != nil {
+			return nil, nil, err
+		}
+		utc := kickoffAt.String
+		if !kickoffAt.Valid {
+			if utc, err = kickoff.UTC(m.StartDate, startTime.String, zone); err != nil {
+				log.Printf("Warning: skipping match %d in conflict check: no kickoff time (%v)", m.ID, err)
+				continue
+			}
+		}
+		if m.Kickoff, err = time.Parse(kickoff.DBLayout, utc); err != nil {
+			return nil, nil, fmt.Errorf("invalid kickoff %q for match %d: %w", utc, m.ID, err)
+		}
+		m.ChannelIDs = []int{channel, live}
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	seasonRows, err := db.Query(`
+		SELECT id, league_id, name, COALESCE(DATE_FORMAT(season_start_date, '%Y-%m-%d'), ''),
+			COALESCE(DATE_FORMAT(season_end_date, '%Y-%m-%d'), '')
+		FROM seasons`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query seasons for conflict check: %w", err)
+	}
+	defer seasonRows.Close()
+	var seasons []conflicts.Season
+	for seasonRows.Next() {
+		var s conflicts.Season
+		if err := seasonRows.Scan(&s.ID, &s.LeagueID, &s.Name, &s.Start, &s.End); err != nil {
+			return nil, nil, err
+		}
+		seasons = append(seasons, s)
+	}
+	return matches, seasons, seasonRows.Err()
+}
+
+// conflictKey คือคีย์เดียวกับ uq_match_conflicts
+func conflictKey(c models.MatchConflict) string {
+	other, subject := 0, 0
+	if c.OtherMatchID != nil {
+		other = *c.OtherMatchID
+	}
+	if c.SubjectID != nil {
+		subject = *c.SubjectID
+	}
+	return fmt.Sprintf("%s|%d|%d|%d", c.Type, c.MatchID, other, subject)
+}
+
+// SaveMatchConflicts แทนที่ผลการตรวจของแมตช์ตั้งแต่วันที่ from ด้วย found ใน transaction เดียว
+// รายการที่ยังชนอยู่คง detected_at เดิมไว้ (รู้ได้ว่าชนมาตั้งแต่เมื่อไร)
+func SaveMatchConflicts(db *sql.DB, from string, found []models.MatchConflict) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT mc.conflict_type, mc.match_id, mc.other_match_id, mc.subject_id, DATE_FORMAT(mc.detected_at, '%Y-%m-%d %H:%i:%s')
+		FROM match_conflicts mc JOIN matches m ON m.id = mc.match_id
+		WHERE m.start_date >= ?`, from)
+	if err != nil {
+		return fmt.Errorf("failed to query existing match conflicts: %w", err)
+	}
+	detected := map[string]string{}
+	for rows.Next() {
+		var c models.MatchConflict
+		var other, subject int
+		var at string
+		if err := rows.Scan(&c.Type, &c.MatchID, &other, &subject, &at); err != nil {
+			rows.Close()
+			return err
+		}
+		c.OtherMatchID, c.SubjectID = &other, &subject
+		detected[conflictKey(c)] = at
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`
+		DELETE mc FROM match_conflicts mc JOIN matches m ON m.id = mc.match_id
+		WHERE m.start_date >= ?`, from); err != nil {
+		return fmt.Errorf("failed to clear match conflicts: %w", err)
+	}
+	for _, c := range found {
+		var at interface{}
+		if v, ok := detected[conflictKey(c)]; ok {
+			at = v
+		}
+		var other, subject int
+		if c.OtherMatchID != nil {
+			other = *c.OtherMatchID
+		}
+		if c.SubjectID != nil {
+			subject = *c.SubjectID
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO match_conflicts (conflict_type, match_id, other_match_id, subject_id, detail, detected_at)
+			VALUES (?, ?, ?, ?, ?, COALESCE(?, NOW()))`,
+			c.Type, c.MatchID, other, subject, c.Detail, at); err != nil {
+			return fmt.Errorf("failed to insert %s conflict for match %d: %w", c.Type, c.MatchID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetMatchConflicts คืนผลการตรวจที่บันทึกไว้ของแมตช์ตั้งแต่วันที่ from พร้อมข้อมูลแมตช์สำหรับแสดงผล เรียงตามวันแข่ง
+// leagueID 0 = ทุกลีก, conflictType ว่าง = ทุกประเภท
+func GetMatchConflicts(db *sql.DB, leagueID int, conflictType, from string) ([]models.MatchConflict, error) {
+	query := `
+		SELECT mc.id, mc.conflict_type, mc.match_id, mc.other_match_id, mc.subject_id, mc.detail,
+			DATE_FORMAT(mc.detected_at, '%Y-%m-%d %H:%i:%s'),
+			m.league_id, l.name, DATE_FORMAT(m.start_date, '%Y-%m-%d'), TIME_FORMAT(m.start_time, '%H:%i'),
+			ht.name_th, at.name_th,
+			DATE_FORMAT(om.start_date, '%Y-%m-%d'), TIME_FORMAT(om.start_time, '%H:%i'), oht.name_th, oat.name_th,
+			CASE mc.conflict_type WHEN 'rest' THEN st.name_th WHEN 'stadium' THEN sd.name WHEN 'channel' THEN ch.name END
+		FROM match_conflicts mc
+		JOIN matches m ON m.id = mc.match_id
+		LEFT JOIN leagues l ON l.id = m.league_id
+		LEFT JOIN teams ht ON ht.id = m.home_team_id
+		LEFT JOIN teams at ON at.id = m.away_team_id
+		LEFT JOIN matches om ON om.id = mc.other_match_id
+		LEFT JOIN teams oht ON oht.id = om.home_team_id
+		LEFT JOIN teams oat ON oat.id = om.away_team_id
+		LEFT JOIN teams st ON st.id = mc.subject_id
+		LEFT JOIN stadiums sd ON sd.id = mc.subject_id
+		LEFT JOIN channels ch ON ch.id = mc.subject_id
+		WHERE m.start_date >= ?`
+	args := []interface{}{from}
+	if leagueID > 0 {
+		query += " AND m.league_id = ?"
+		args = append(args, leagueID)
+	}
+	if conflictType != "" {
+		query += " AND mc.conflict_type = ?"
+		args = append(args, conflictType)
+	}
+	query += " ORDER BY m.start_date, m.start_time, mc.match_id, mc.id"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	out := []models.MatchConflict{}
+	for rows.Next() {
+		var c models.MatchConflict
+		var other, subject int
+		var league sql.NullInt64
+		var leagueName, home, away, otherDate, otherTime, otherHome, otherAway, subjectName sql.NullString
+		if err := rows.Scan(&c.ID, &c.Type, &c.MatchID, &other, &subject, &c.Detail, &c.DetectedAt,
+			&league, &leagueName, &c.StartDate, &c.StartTime, &home, &away,
+			&otherDate, &otherTime, &otherHome, &otherAway, &subjectName); err != nil {
+			return nil, err
+		}
+		if other != 0 {
+			c.OtherMatchID = &other
+		}
+		if subject != 0 {
+			c.SubjectID = &subject
+		}
+		c.LeagueID, c.LeagueName = nullIntPtr(league), nullStringPtr(leagueName)
+		c.HomeTeam, c.AwayTeam = nullStringPtr(home), nullStringPtr(away)
+		c.OtherDate, c.OtherTime = nullStringPtr(otherDate), nullStringPtr(otherTime)
+		c.OtherHome, c.OtherAway = nullStringPtr(otherHome), nullStringPtr(otherAway)
+		c.SubjectName = nullStringPtr(subjectName)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
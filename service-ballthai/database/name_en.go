@@ -0,0 +1,81 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// fillNameEN เติมชื่อภาษาอังกฤษให้แถวที่ยังไม่มีค่า (ไม่ทับชื่อที่ผู้ดูแลแก้ไว้)
+func fillNameEN(db *sql.DB, table, column string, id int, nameEN string) error {
+	nameEN = strings.TrimSpace(nameEN)
+	if id <= 0 || nameEN == "" {
+		return nil
+	}
+	query := fmt.Sprintf("UPDATE `%s` SET `%s` = ? WHERE id = ? AND (`%s` IS NULL OR `%s` = '')", table, column, column, column)
+	if _, err := db.Exec(query, nameEN, id); err != nil {
+		return fmt.Errorf("failed to set %s.%s for id %d: %w", table, column, id, err)
+	}
+	return nil
+}
+
+// SetLeagueNameEN บันทึกชื่อลีกภาษาอังกฤษจาก API ถ้ายังไม่มี
+func SetLeagueNameEN(db *sql.DB, leagueID int, nameEN string) error {
+	return fillNameEN(db, "leagues", "name_en", leagueID, nameEN)
+}
+
+// SetStageNameEN บันทึกชื่อ stage ภาษาอังกฤษจาก API ถ้ายังไม่มี
+func SetStageNameEN(db *sql.DB, stageID int, nameEN string) error {
+	return fillNameEN(db, "stage", "stage_name_en", stageID, nameEN)
+}
+
+// SetTeamNameEN บันทึกชื่อทีมภาษาอังกฤษจาก API ถ้ายังไม่มี
+func SetTeamNameEN(db *sql.DB, teamID int, nameEN string) error {
+	return fillNameEN(db, "teams", "name_en", teamID, nameEN)
+}
+
+// GetTeamNamesEN คืน map team_id -> name_en เฉพาะทีมที่มีชื่อภาษาอังกฤษ
+func GetTeamNamesEN(db *sql.DB, teamIDs []int) (map[int]string, error) {
+	names := map[int]string{}
+	if len(teamIDs) == 0 {
+		return names, nil
+	}
+	placeholders := make([]string, len(teamIDs))
+	args := make([]interface{}, len(teamIDs))
+	for i, id := range teamIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := db.Query("SELECT id, name_en FROM teams WHERE name_en IS NOT NULL AND name_en != '' AND id IN ("+strings.Join(placeholders, ",")+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query english team names: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
+// GetStageNameENByID คืน stage_name_en จาก stage_id ("" ถ้ายังไม่มีชื่อภาษาอังกฤษ)
+func GetStageNameENByID(db *sql.DB, stageID int64) (string, error) {
+	var name sql.NullString
+	if err := db.QueryRow("SELECT stage_name_en FROM stage WHERE id = ?", stageID).Scan(&name); err != nil {
+		return "", err
+	}
+	return name.String, nil
+}
+
+// GetLeagueNames คืนชื่อลีกภาษาไทยและภาษาอังกฤษ (name_en ว่างถ้ายังไม่มี)
+func GetLeagueNames(db *sql.DB, leagueID int) (name, nameEN string, err error) {
+	var en sql.NullString
+	if err = db.QueryRow("SELECT name, name_en FROM leagues WHERE id = ?", leagueID).Scan(&name, &en); err != nil {
+		return "", "", err
+	}
+	return name, en.String, nil
+}
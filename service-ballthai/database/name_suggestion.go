@@ -0,0 +1,167 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/models"
+	"go-ballthai-scraper/romanize"
+)
+
+// nameSuggestionEntity คือตารางและคอลัมน์ชื่อไทย/อังกฤษของ entity ที่สร้างคำแนะนำได้
+type nameSuggestionEntity struct {
+	table, thCol, enCol string
+}
+
+var nameSuggestionEntities = map[string]nameSuggestionEntity{
+	"team":    {"teams", "name_th", "name_en"},
+	"player":  {"players", "name", "full_name_en"},
+	"stadium": {"stadiums", "name", "name_en"},
+}
+
+// IsNameSuggestionEntity บอกว่า entity รองรับการสร้างคำแนะนำชื่อหรือไม่
+func IsNameSuggestionEntity(entity string) bool {
+	_, ok := nameSuggestionEntities[entity]
+	return ok
+}
+
+// GenerateNameSuggestions ถอดชื่อไทยเป็นชื่ออังกฤษ/slug ให้แถวที่ยังไม่มีชื่อภาษาอังกฤษ
+// คำแนะนำเดิมที่ยัง pending จะถูกคำนวณใหม่ ส่วนที่ตรวจแล้ว (accepted/rejected) จะไม่ถูกแตะ
+func GenerateNameSuggestions(db *sql.DB, entity string) (int, error) {
+	e, ok := nameSuggestionEntities[entity]
+	if !ok {
+		return 0, fmt.Errorf("unknown entity %q", entity)
+	}
+	rows, err := db.Query(fmt.Sprintf("SELECT id, `%s` FROM `%s` WHERE (`%s` IS NULL OR `%s` = '') AND `%s` IS NOT NULL AND `%s` != ''",
+		e.thCol, e.table, e.enCol, e.enCol, e.thCol, e.thCol))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s without english names: %w", e.table, err)
+	}
+	type source struct {
+		id   int
+		name string
+	}
+	var sources []source
+	for rows.Next() {
+		var s source
+		if err := rows.Scan(&s.id, &s.name); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		sources = append(sources, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, s := range sources {
+		nameEN, slug := romanize.Suggest(s.name)
+		if nameEN == "" || slug == "" {
+			continue
+		}
+		_, err := db.Exec(`
+			INSERT INTO name_suggestions (entity_type, entity_id, source_name, suggested_name, suggested_slug)
+			VALUES (?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				source_name = IF(status = 'pending', VALUES(source_name), source_name),
+				suggested_name = IF(status = 'pending', VALUES(suggested_name), suggested_name),
+				suggested_slug = IF(status = 'pending', VALUES(suggested_slug), suggested_slug)`,
+			entity, s.id, s.name, nameEN, slug)
+		if err != nil {
+			return count, fmt.Errorf("failed to save name suggestion for %s %d: %w", entity, s.id, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// GetNameSuggestions คืนคำแนะนำชื่อ กรองด้วย entity และ status (ค่าว่าง = ทั้งหมด)
+func GetNameSuggestions(db *sql.DB, entity, status string) ([]models.NameSuggestionDB, error) {
+	query := `SELECT id, entity_type, entity_id, source_name, suggested_name, suggested_slug, status,
+			DATE_FORMAT(created_at, '%Y-%m-%d %H:%i:%s'), DATE_FORMAT(reviewed_at, '%Y-%m-%d %H:%i:%s')
+		FROM name_suggestions WHERE 1=1`
+	var args []interface{}
+	if entity != "" {
+		query += " AND entity_type = ?"
+		args = append(args, entity)
+	}
+	if status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY entity_type, source_name"
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query name suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []models.NameSuggestionDB{}
+	for rows.Next() {
+		var s models.NameSuggestionDB
+		var reviewedAt sql.NullString
+		if err := rows.Scan(&s.ID, &s.EntityType, &s.EntityID, &s.SourceName, &s.SuggestedName, &s.SuggestedSlug,
+			&s.Status, &s.CreatedAt, &reviewedAt); err != nil {
+			return nil, err
+		}
+		s.ReviewedAt = nullStringPtr(reviewedAt)
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
+
+// ReviewNameSuggestion บันทึกผลการตรวจคำแนะนำชื่อ
+// accept จะเขียนชื่ออังกฤษ (nameEN/slug ที่แก้ไข หรือค่าที่แนะนำถ้าว่าง) ลงตารางของ entity
+// โดยไม่ทับชื่อภาษาอังกฤษที่มีอยู่แล้ว
+func ReviewNameSuggestion(db *sql.DB, id int, accept bool, nameEN, slug string) error {
+	var entity, suggestedName, suggestedSlug string
+	var entityID int
+	err := db.QueryRow("SELECT entity_type, entity_id, suggested_name, suggested_slug FROM name_suggestions WHERE id = ?", id).
+		Scan(&entity, &entityID, &suggestedName, &suggestedSlug)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status := "rejected"
+	if accept {
+		status = "accepted"
+		if nameEN == "" {
+			nameEN = suggestedName
+		}
+		// slug ที่ผู้ตรวจแก้เองจะแทนของเดิม ส่วน slug ที่แนะนำใช้เฉพาะแถวที่ยังไม่มี slug (URL เดิมไม่เปลี่ยน)
+		override := romanize.Slug(slug) != ""
+		if !override {
+			slug = suggestedSlug
+		}
+		e := nameSuggestionEntities[entity]
+		if slug, err = uniqueSlug(tx, e.table, slug, entityID); err != nil {
+			return err
+		}
+		query := fmt.Sprintf("UPDATE `%s` SET `%s` = COALESCE(NULLIF(`%s`, ''), ?), slug = IF(? OR slug IS NULL OR slug = '', ?, slug) WHERE id = ?", e.table, e.enCol, e.enCol)
+		if _, err := tx.Exec(query, nameEN, override, slug, entityID); err != nil {
+			return fmt.Errorf("failed to apply name suggestion to %s %d: %w", entity, entityID, err)
+		}
+	}
+	if _, err := tx.Exec("UPDATE name_suggestions SET status = ?, suggested_name = ?, suggested_slug = ?, reviewed_at = NOW() WHERE id = ?",
+		status, firstNonEmpty(nameEN, suggestedName), firstNonEmpty(slug, suggestedSlug), id); err != nil {
+		return fmt.Errorf("failed to update name suggestion %d: %w", id, err)
+	}
+	return tx.Commit()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
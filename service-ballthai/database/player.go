@@ -12,8 +12,13 @@ import (
 func InsertOrUpdatePlayer(db *sql.DB, player models.PlayerDB) error {
 	var existingPlayerID int
 	var existingStatus int
-	query := "SELECT id, status FROM players WHERE player_ref_id = ?"
-	err := db.QueryRow(query, player.PlayerRefID).Scan(&existingPlayerID, &existingStatus)
+	var existingYellow, existingRed int
+	var pooled bool
+	// pooled = ผู้เล่นที่ถูกรวมแล้ว หรือเป็น record หลักที่มีผู้เล่นอื่นรวมเข้ามา (มีหลาย player_ref_id)
+	query := `SELECT id, status, COALESCE(yellow_cards, 0), COALESCE(red_cards, 0),
+		merged_into_id IS NOT NULL OR EXISTS (SELECT 1 FROM players c WHERE c.merged_into_id = players.id)
+		FROM players WHERE player_ref_id = ?`
+	err := db.QueryRow(query, player.PlayerRefID).Scan(&existingPlayerID, &existingStatus, &existingYellow, &existingRed, &pooled)
 
 	if err == sql.ErrNoRows {
 		// Insert new player
@@ -21,18 +26,25 @@ func InsertOrUpdatePlayer(db *sql.DB, player models.PlayerDB) error {
 			INSERT INTO players (
 				player_ref_id, league_id, team_id, nationality_id,
 				name, full_name_en, shirt_number, position, photo_url,
-				matches_played, goals, yellow_cards, red_cards, status
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				matches_played, goals, yellow_cards, red_cards, status,
+				assists, minutes_played
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
-		_, err := db.Exec(insertQuery,
+		result, err := db.Exec(insertQuery,
 			player.PlayerRefID, player.LeagueID, player.TeamID, player.NationalityID,
 			player.Name, player.FullNameEN, player.ShirtNumber, player.Position, player.PhotoURL,
 			player.MatchesPlayed, player.Goals, player.YellowCards, player.RedCards, player.Status,
+			player.Assists, player.MinutesPlayed,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert player %s: %w", player.Name, err)
 		}
 		log.Printf("Inserted new player: %s", player.Name)
+		if newID, err := result.LastInsertId(); err == nil {
+			if err := AssignSlug(db, "players", int(newID)); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}
 	} else if err != nil {
 		return fmt.Errorf("failed to query existing player %d: %w", player.PlayerRefID, err)
 	} else {
@@ -41,24 +53,49 @@ func InsertOrUpdatePlayer(db *sql.DB, player models.PlayerDB) error {
 			log.Printf("Skip update player: %s (ID: %d) because status=1", player.Name, existingPlayerID)
 			return nil
 		}
-		// Update existing player
+		if pooled {
+			// สถิติสะสมของผู้เล่นหลาย ref คือผลรวมของทุก ref: SavePlayerSeasonStats เป็นผู้คำนวณและตรวจโทษแบน
+			_, err := db.Exec(`
+				UPDATE players SET
+					league_id = ?, team_id = ?, nationality_id = ?,
+					name = ?, full_name_en = ?, shirt_number = ?, position = ?, photo_url = IF(photo_pinned = 1, photo_url, ?),
+					status = ?
+				WHERE player_ref_id = ?`,
+				player.LeagueID, player.TeamID, player.NationalityID,
+				player.Name, player.FullNameEN, player.ShirtNumber, player.Position, player.PhotoURL,
+				player.Status, player.PlayerRefID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to update player %d: %w", player.PlayerRefID.Int64, err)
+			}
+			log.Printf("Updated existing player: %s (ID: %d, counters pooled across refs)", player.Name, existingPlayerID)
+			return nil
+		}
+		// Update existing player (รูปที่ปักหมุดไว้ photo_pinned = 1 จะไม่ถูกเขียนทับ)
 		updateQuery := `
 			UPDATE players SET
 				league_id = ?, team_id = ?, nationality_id = ?,
-				name = ?, full_name_en = ?, shirt_number = ?, position = ?, photo_url = ?,
-				matches_played = ?, goals = ?, yellow_cards = ?, red_cards = ?, status = ?
+				name = ?, full_name_en = ?, shirt_number = ?, position = ?, photo_url = IF(photo_pinned = 1, photo_url, ?),
+				matches_played = ?, goals = ?, yellow_cards = ?, red_cards = ?, status = ?,
+				assists = COALESCE(?, assists), minutes_played = COALESCE(?, minutes_played)
 			WHERE player_ref_id = ?
 		`
 		_, err := db.Exec(updateQuery,
 			player.LeagueID, player.TeamID, player.NationalityID,
 			player.Name, player.FullNameEN, player.ShirtNumber, player.Position, player.PhotoURL,
 			player.MatchesPlayed, player.Goals, player.YellowCards, player.RedCards, player.Status,
+			player.Assists, player.MinutesPlayed,
 			player.PlayerRefID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to update player %d: %w", player.PlayerRefID, err)
 		}
 		log.Printf("Updated existing player: %s (ID: %d)", player.Name, existingPlayerID)
+		// ตรวจว่าใบเหลืองครบเกณฑ์หรือได้ใบแดงเพิ่มหรือไม่ เพื่อบันทึกโทษแบน
+		if err := RecordDisciplineChanges(db, existingPlayerID, player.LeagueID, player.TeamID,
+			existingYellow, player.YellowCards, existingRed, player.RedCards); err != nil {
+			log.Printf("Warning: %v", err)
+		}
 	}
 	return nil
 }
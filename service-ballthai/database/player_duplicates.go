@@ -0,0 +1,319 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-ballthai-scraper/models"
+)
+
+// duplicateCandidate คือผู้เล่นพร้อมค่าที่ normalize แล้วสำหรับเปรียบเทียบ
+type duplicateCandidate struct {
+	player        models.DuplicatePlayer
+	name, nameEN  string
+	birthDate     string
+	nationalityID int64
+}
+
+// normalizeName ตัดช่องว่างซ้ำ/ตัวพิมพ์ใหญ่เล็ก เพื่อเทียบชื่อ
+func normalizeName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// FindDuplicatePlayers คืนกลุ่มผู้เล่นที่น่าจะซ้ำกัน
+// คู่ที่ถือว่าซ้ำต้องมีชื่อไทยหรือชื่ออังกฤษตรงกัน และตรงกันอย่างน้อย 2 ใน 4 (ชื่อ, ชื่ออังกฤษ, วันเกิด, สัญชาติ)
+// ถ้าวันเกิดหรือสัญชาติมีทั้งสองฝั่งแต่ไม่ตรงกันจะไม่นับเป็นคู่ซ้ำ
+func FindDuplicatePlayers(db *sql.DB) ([]models.DuplicateGroup, error) {
+	rows, err := db.Query(`
+		SELECT p.id, p.player_ref_id, p.name, p.full_name_en, DATE_FORMAT(p.birth_date, '%Y-%m-%d'),
+			p.nationality_id, n.name, p.league_id, t.name_th, COALESCE(p.goals, 0), COALESCE(p.matches_played, 0)
+		FROM players p
+		LEFT JOIN nationalities n ON p.nationality_id = n.id
+		LEFT JOIN teams t ON p.team_id = t.id
+		WHERE p.merged_into_id IS NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players for duplicate check: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []duplicateCandidate
+	byName := map[string][]int{}
+	for rows.Next() {
+		var c duplicateCandidate
+		var refID, nationalityID, leagueID sql.NullInt64
+		var nameEN, birthDate, nationality, teamName sql.NullString
+		if err := rows.Scan(&c.player.PlayerID, &refID, &c.player.Name, &nameEN, &birthDate,
+			&nationalityID, &nationality, &leagueID, &teamName, &c.player.Goals, &c.player.Matches); err != nil {
+			return nil, err
+		}
+		c.player.PlayerRefID, c.player.LeagueID = nullIntPtr(refID), nullIntPtr(leagueID)
+		c.player.FullNameEN, c.player.BirthDate = nullStringPtr(nameEN), nullStringPtr(birthDate)
+		c.player.Nationality, c.player.TeamName = nullStringPtr(nationality), nullStringPtr(teamName)
+		c.name, c.nameEN, c.birthDate, c.nationalityID = normalizeName(c.player.Name), normalizeName(nameEN.String), birthDate.String, nationalityID.Int64
+
+		idx := len(candidates)
+		candidates = append(candidates, c)
+		byName["th:"+c.name] = append(byName["th:"+c.name], idx)
+		if c.nameEN != "" {
+			byName["en:"+c.nameEN] = append(byName["en:"+c.nameEN], idx)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ignored, err := loadDuplicateIgnores(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// union-find รวมคู่ที่ซ้ำเป็นกลุ่ม
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	reasons := map[int]map[string]bool{}
+	seen := map[[2]int]bool{}
+	for _, idxs := range byName {
+		for x := 0; x < len(idxs); x++ {
+			for y := x + 1; y < len(idxs); y++ {
+				a, b := idxs[x], idxs[y]
+				if a > b {
+					a, b = b, a
+				}
+				if seen[[2]int{a, b}] {
+					continue
+				}
+				seen[[2]int{a, b}] = true
+				ca, cb := candidates[a], candidates[b]
+				if ignored[[2]int{minInt(ca.player.PlayerID, cb.player.PlayerID), maxInt(ca.player.PlayerID, cb.player.PlayerID)}] {
+					continue
+				}
+				matched := duplicateReasons(ca, cb)
+				if matched == nil {
+					continue
+				}
+				ra, rb := find(a), find(b)
+				parent[rb] = ra
+				if reasons[ra] == nil {
+					reasons[ra] = map[string]bool{}
+				}
+				for k, v := range reasons[rb] {
+					reasons[ra][k] = v
+				}
+				for _, m := range matched {
+					reasons[ra][m] = true
+				}
+			}
+		}
+	}
+
+	members := map[int][]models.DuplicatePlayer{}
+	for i, c := range candidates {
+		members[find(i)] = append(members[find(i)], c.player)
+	}
+	groups := []models.DuplicateGroup{}
+	for root, players := range members {
+		if len(players) < 2 {
+			continue
+		}
+		g := models.DuplicateGroup{Players: players}
+		for r := range reasons[root] {
+			g.Reasons = append(g.Reasons, r)
+		}
+		sort.Strings(g.Reasons)
+		sort.Slice(g.Players, func(i, j int) bool { return g.Players[i].PlayerID < g.Players[j].PlayerID })
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Players[0].Name < groups[j].Players[0].Name })
+	return groups, nil
+}
+
+// duplicateReasons คืนรายการ field ที่ตรงกัน หรือ nil ถ้าไม่ถือว่าเป็นคนเดียวกัน
+func duplicateReasons(a, b duplicateCandidate) []string {
+	if a.birthDate != "" && b.birthDate != "" && a.birthDate != b.birthDate {
+		return nil
+	}
+	if a.nationalityID != 0 && b.nationalityID != 0 && a.nationalityID != b.nationalityID {
+		return nil
+	}
+	var matched []string
+	if a.name == b.name {
+		matched = append(matched, "name")
+	}
+	if a.nameEN != "" && a.nameEN == b.nameEN {
+		matched = append(matched, "full_name_en")
+	}
+	if a.birthDate != "" && a.birthDate == b.birthDate {
+		matched = append(matched, "birth_date")
+	}
+	if a.nationalityID != 0 && a.nationalityID == b.nationalityID {
+		matched = append(matched, "nationality")
+	}
+	if len(matched) < 2 {
+		return nil
+	}
+	return matched
+}
+
+func loadDuplicateIgnores(db *sql.DB) (map[[2]int]bool, error) {
+	rows, err := db.Query("SELECT player_a_id, player_b_id FROM player_duplicate_ignores")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query duplicate ignores: %w", err)
+	}
+	defer rows.Close()
+	ignored := map[[2]int]bool{}
+	for rows.Next() {
+		var a, b int
+		if err := rows.Scan(&a, &b); err != nil {
+			return nil, err
+		}
+		ignored[[2]int{a, b}] = true
+	}
+	return ignored, rows.Err()
+}
+
+// IgnoreDuplicatePair บันทึกว่าผู้เล่นสองคนนี้ไม่ใช่คนเดียวกัน (จะไม่แสดงในรายการซ้ำอีก)
+func IgnoreDuplicatePair(db *sql.DB, playerA, playerB int) error {
+	_, err := db.Exec("INSERT IGNORE INTO player_duplicate_ignores (player_a_id, player_b_id) VALUES (?, ?)",
+		minInt(playerA, playerB), maxInt(playerA, playerB))
+	if err != nil {
+		return fmt.Errorf("failed to ignore duplicate pair %d/%d: %w", playerA, playerB, err)
+	}
+	return nil
+}
+
+// ErrAlreadyMerged คือผู้เล่นที่ถูกรวมเข้ากับ record อื่นไปแล้ว (ทั้ง keep_id และ merge_ids)
+var ErrAlreadyMerged = errors.New("player is already merged into another player")
+
+// mergedCounter คือค่าใหม่ของคอลัมน์สถิติใน players ของ record หลัก (k) เมื่อรวม record m เข้ามา
+// ลีกเดียวกัน (หรือ k ยังไม่มีลีก) คือสถิติชุดเดียวกันซ้ำ ใช้ค่ามากกว่า ต่างลีกให้บวกกัน
+func mergedCounter(col string) string {
+	return fmt.Sprintf(`IF(k.league_id IS NULL OR k.league_id = m.league_id,
+		GREATEST(COALESCE(k.%[1]s, 0), COALESCE(m.%[1]s, 0)), COALESCE(k.%[1]s, 0) + COALESCE(m.%[1]s, 0))`, col)
+}
+
+// MergePlayers รวมผู้เล่น mergeIDs เข้ากับ keepID ในทรานแซกชันเดียว
+//   - ย้ายสถิติรายฤดูกาล (ถ้าลีก/ฤดูกาลเดียวกันซ้ำ ใช้ค่ามากกว่า) และประวัติโทษแบนไปยัง keepID
+//   - ย้ายสถิติสะสมในตาราง players ไปที่ keepID (ดู mergedCounter) แล้วล้างค่าใน record ที่ถูกรวม
+//   - เติมข้อมูลโปรไฟล์ที่ keepID ยังไม่มีจากผู้เล่นที่ถูกรวม
+//   - ตั้ง merged_into_id ของผู้เล่นที่ถูกรวม (ยังเก็บ record ไว้เพื่อให้ scraper อ้างอิง player_ref_id เดิมได้)
+//   - ถ้ามีค่าราย ref ใน player_ref_season_stats คำนวณสถิติของ keepID ใหม่เป็นผลรวมของทุก ref
+//
+// ผู้เล่นที่ถูกรวมเข้ากับผู้เล่นอื่นไปแล้วจะถูกปฏิเสธ ส่วนที่รวมเข้ากับ keepID อยู่แล้วจะข้ามไป
+func MergePlayers(db *sql.DB, keepID int, mergeIDs []int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin merge transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var keepMerged sql.NullInt64
+	if err := tx.QueryRow("SELECT merged_into_id FROM players WHERE id = ? FOR UPDATE", keepID).Scan(&keepMerged); err != nil {
+		return fmt.Errorf("failed to load player %d: %w", keepID, err)
+	}
+	if keepMerged.Valid {
+		return fmt.Errorf("%w: player %d into %d", ErrAlreadyMerged, keepID, keepMerged.Int64)
+	}
+
+	for _, id := range mergeIDs {
+		if id == keepID {
+			continue
+		}
+		var merged sql.NullInt64
+		if err := tx.QueryRow("SELECT merged_into_id FROM players WHERE id = ? FOR UPDATE", id).Scan(&merged); err != nil {
+			return fmt.Errorf("failed to load player %d: %w", id, err)
+		}
+		if merged.Valid {
+			if int(merged.Int64) == keepID {
+				continue
+			}
+			return fmt.Errorf("%w: player %d into %d", ErrAlreadyMerged, id, merged.Int64)
+		}
+		steps := []struct {
+			query string
+			args  []interface{}
+		}{
+			{`INSERT INTO player_season_stats (player_id, league_id, season_id, team_id,
+					matches_played, minutes_played, goals, assists, yellow_cards, red_cards)
+				SELECT ?, league_id, season_id, team_id, matches_played, minutes_played, goals, assists, yellow_cards, red_cards
+				FROM player_season_stats WHERE player_id = ?
+				ON DUPLICATE KEY UPDATE
+					matches_played = GREATEST(matches_played, VALUES(matches_played)),
+					minutes_played = GREATEST(COALESCE(minutes_played, 0), COALESCE(VALUES(minutes_played), 0)),
+					goals = GREATEST(goals, VALUES(goals)),
+					assists = GREATEST(COALESCE(assists, 0), COALESCE(VALUES(assists), 0)),
+					yellow_cards = GREATEST(yellow_cards, VALUES(yellow_cards)),
+					red_cards = GREATEST(red_cards, VALUES(red_cards))`, []interface{}{keepID, id}},
+			{"DELETE FROM player_season_stats WHERE player_id = ?", []interface{}{id}},
+			{"UPDATE IGNORE suspensions SET player_id = ? WHERE player_id = ?", []interface{}{keepID, id}},
+			{"DELETE FROM suspensions WHERE player_id = ?", []interface{}{id}},
+			{`UPDATE players k JOIN players m ON m.id = ?
+				SET k.matches_played = ` + mergedCounter("matches_played") + `,
+					k.goals = ` + mergedCounter("goals") + `,
+					k.yellow_cards = ` + mergedCounter("yellow_cards") + `,
+					k.red_cards = ` + mergedCounter("red_cards") + `,
+					k.assists = IF(k.assists IS NULL AND m.assists IS NULL, NULL, ` + mergedCounter("assists") + `),
+					k.minutes_played = IF(k.minutes_played IS NULL AND m.minutes_played IS NULL, NULL, ` + mergedCounter("minutes_played") + `)
+				WHERE k.id = ?`, []interface{}{id, keepID}},
+			{`UPDATE players SET matches_played = 0, goals = 0, yellow_cards = 0, red_cards = 0,
+					assists = NULL, minutes_played = NULL
+				WHERE id = ?`, []interface{}{id}},
+			{`UPDATE players k JOIN players m ON m.id = ?
+				SET k.league_id = COALESCE(k.league_id, m.league_id),
+					k.full_name_en = COALESCE(k.full_name_en, m.full_name_en),
+					k.nationality_id = COALESCE(k.nationality_id, m.nationality_id),
+					k.photo_url = COALESCE(k.photo_url, m.photo_url),
+					k.birth_date = COALESCE(k.birth_date, m.birth_date),
+					k.height_cm = COALESCE(k.height_cm, m.height_cm),
+					k.weight_kg = COALESCE(k.weight_kg, m.weight_kg),
+					k.preferred_foot = COALESCE(k.preferred_foot, m.preferred_foot),
+					k.birthplace = COALESCE(k.birthplace, m.birthplace),
+					k.career_start = COALESCE(k.career_start, m.career_start)
+				WHERE k.id = ?`, []interface{}{id, keepID}},
+			// ผู้เล่นที่เคยถูกรวมเข้ากับ id นี้ ให้ชี้ไปที่ record หลักใหม่
+			{"UPDATE players SET merged_into_id = ? WHERE merged_into_id = ?", []interface{}{keepID, id}},
+			{"UPDATE players SET merged_into_id = ? WHERE id = ?", []interface{}{keepID, id}},
+		}
+		for _, step := range steps {
+			if _, err := tx.Exec(step.query, step.args...); err != nil {
+				return fmt.Errorf("failed to merge player %d into %d: %w", id, keepID, err)
+			}
+		}
+	}
+	// ลีก/ฤดูกาลที่มีค่าราย ref ให้เป็นผลรวมของทุก ref แทนค่ามากกว่าจากขั้นตอนข้างบน
+	if err := saveSeasonTotals(tx, keepID); err != nil {
+		return err
+	}
+	if err := foldRefCounters(tx, keepID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merge into player %d: %w", keepID, err)
+	}
+	return nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
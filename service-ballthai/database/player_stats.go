@@ -0,0 +1,238 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-ballthai-scraper/models"
+)
+
+// leaderStatColumns แปลงชื่อ stat ใน query string เป็นคอลัมน์ (ใช้เป็น whitelist ก่อนต่อ SQL)
+var leaderStatColumns = map[string]string{
+	"goals":          "goals",
+	"assists":        "assists",
+	"yellow_cards":   "yellow_cards",
+	"red_cards":      "red_cards",
+	"matches_played": "matches_played",
+	"minutes":        "minutes_played",
+}
+
+// IsLeaderStat บอกว่า stat นี้ใช้ทำ leaderboard ได้หรือไม่
+func IsLeaderStat(stat string) bool {
+	_, ok := leaderStatColumns[stat]
+	return ok
+}
+
+// GetCurrentSeasonID คืน season ของลีกที่ครอบคลุมวันนี้ ถ้าไม่มีใช้ season ที่เริ่มล่าสุด
+func GetCurrentSeasonID(db *sql.DB, leagueID int) (sql.NullInt64, error) {
+	return GetSeasonIDAt(db, leagueID, time.Now().Format("2006-01-02"))
+}
+
+// GetSeasonIDAt คืน season ของลีกที่ครอบคลุมวันที่ date (YYYY-MM-DD)
+// ถ้าไม่มีใช้ season ล่าสุดที่เริ่มก่อนวันนั้น แล้วจึง season ที่เริ่มล่าสุด (ไม่ Valid = ลีกไม่มี season)
+func GetSeasonIDAt(db *sql.DB, leagueID int, date string) (sql.NullInt64, error) {
+	var id sql.NullInt64
+	err := db.QueryRow(`
+		SELECT id FROM seasons
+		WHERE league_id = ?
+		ORDER BY (season_start_date <= ? AND (season_end_date IS NULL OR season_end_date >= ?)) DESC,
+			(season_start_date <= ?) DESC, season_start_date DESC, id DESC
+		LIMIT 1`, leagueID, date, date, date).Scan(&id)
+	if err == sql.ErrNoRows {
+		return id, nil
+	} else if err != nil {
+		return id, fmt.Errorf("failed to get season of %s for league %d: %w", date, leagueID, err)
+	}
+	return id, nil
+}
+
+// SavePlayerSeasonStats บันทึกสถิติสะสมของผู้เล่นในฤดูกาล (อ้างอิงผู้เล่นด้วย player_ref_id)
+// ค่าของ ref นี้เก็บใน player_ref_season_stats แล้วคำนวณ player_season_stats ของ record หลักใหม่จากทุก ref
+// ผู้เล่นที่มีหลาย ref (ดู MergePlayers) จะอัปเดตสถิติสะสมในตาราง players เป็นผลรวมด้วย
+// assists/minutes_played ที่ไม่ได้ส่งมาจะใช้ค่าที่กรอกไว้ในตาราง players (เฉพาะผู้เล่นที่มี ref เดียว
+// เพราะค่าในตาราง players ของผู้เล่นหลาย ref เป็นผลรวมแล้ว)
+func SavePlayerSeasonStats(db *sql.DB, seasonID int, player models.PlayerDB) error {
+	_, err := db.Exec(`
+		INSERT INTO player_ref_season_stats (
+			player_ref_id, league_id, season_id, team_id,
+			matches_played, minutes_played, goals, assists, yellow_cards, red_cards
+		)
+		SELECT p.player_ref_id, ?, ?, ?, ?, COALESCE(?, IF(pooled, NULL, p.minutes_played)),
+			?, COALESCE(?, IF(pooled, NULL, p.assists)), ?, ?
+		FROM players p
+		JOIN (SELECT p.merged_into_id IS NOT NULL OR EXISTS (SELECT 1 FROM players c WHERE c.merged_into_id = p.id) AS pooled
+			FROM players p WHERE p.player_ref_id = ?) ref
+		WHERE p.player_ref_id = ?
+		ON DUPLICATE KEY UPDATE
+			team_id = VALUES(team_id), matches_played = VALUES(matches_played),
+			minutes_played = COALESCE(VALUES(minutes_played), minutes_played),
+			goals = VALUES(goals), assists = COALESCE(VALUES(assists), assists),
+			yellow_cards = VALUES(yellow_cards), red_cards = VALUES(red_cards)`,
+		player.LeagueID, seasonID, player.TeamID,
+		player.MatchesPlayed, player.MinutesPlayed, player.Goals, player.Assists, player.YellowCards, player.RedCards,
+		player.PlayerRefID, player.PlayerRefID)
+	if err != nil {
+		return fmt.Errorf("failed to save season stats for player %s: %w", player.Name, err)
+	}
+
+	var playerID, oldYellow, oldRed int
+	var pooled bool
+	err = db.QueryRow(`
+		SELECT k.id, COALESCE(k.yellow_cards, 0), COALESCE(k.red_cards, 0),
+			EXISTS (SELECT 1 FROM players c WHERE c.merged_into_id = k.id)
+		FROM players p JOIN players k ON k.id = COALESCE(p.merged_into_id, p.id)
+		WHERE p.player_ref_id = ?`, player.PlayerRefID).Scan(&playerID, &oldYellow, &oldRed, &pooled)
+	if err != nil {
+		return fmt.Errorf("failed to load player %s: %w", player.Name, err)
+	}
+	if err := saveSeasonTotals(db, playerID); err != nil {
+		return err
+	}
+	if !pooled {
+		return nil
+	}
+	if err := foldRefCounters(db, playerID); err != nil {
+		return err
+	}
+	// InsertOrUpdatePlayer ไม่ได้ตรวจโทษแบนของผู้เล่นที่มีหลาย ref จึงตรวจจากผลรวมที่นี่
+	var newYellow, newRed int
+	if err := db.QueryRow("SELECT COALESCE(yellow_cards, 0), COALESCE(red_cards, 0) FROM players WHERE id = ?",
+		playerID).Scan(&newYellow, &newRed); err != nil {
+		return fmt.Errorf("failed to load player %d: %w", playerID, err)
+	}
+	return RecordDisciplineChanges(db, playerID, player.LeagueID, player.TeamID, oldYellow, newYellow, oldRed, newRed)
+}
+
+// saveSeasonTotals คำนวณ player_season_stats ของ playerID ใหม่เป็นผลรวมของทุก player_ref_id
+// ที่เป็นผู้เล่นคนนี้ (ทีมคือทีมที่ลงเล่นมากที่สุด) ลีก/ฤดูกาลที่ไม่มีค่าราย ref จะไม่ถูกแตะ
+func saveSeasonTotals(db models.Execer, playerID int) error {
+	_, err := db.Exec(`
+		INSERT INTO player_season_stats (
+			player_id, league_id, season_id, team_id,
+			matches_played, minutes_played, goals, assists, yellow_cards, red_cards
+		)
+		SELECT ?, r.league_id, r.season_id,
+			SUBSTRING_INDEX(GROUP_CONCAT(r.team_id ORDER BY r.matches_played DESC), ',', 1),
+			SUM(r.matches_played), SUM(r.minutes_played), SUM(r.goals), SUM(r.assists),
+			SUM(r.yellow_cards), SUM(r.red_cards)
+		FROM player_ref_season_stats r
+		JOIN players p ON p.player_ref_id = r.player_ref_id
+		WHERE COALESCE(p.merged_into_id, p.id) = ?
+		GROUP BY r.league_id, r.season_id
+		ON DUPLICATE KEY UPDATE
+			team_id = VALUES(team_id), matches_played = VALUES(matches_played),
+			minutes_played = COALESCE(VALUES(minutes_played), minutes_played),
+			goals = VALUES(goals), assists = COALESCE(VALUES(assists), assists),
+			yellow_cards = VALUES(yellow_cards), red_cards = VALUES(red_cards)`, playerID, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to save season totals for player %d: %w", playerID, err)
+	}
+	return nil
+}
+
+// foldRefCounters ตั้งสถิติสะสมในตาราง players ของ playerID เป็นผลรวมของฤดูกาลล่าสุด
+// ของแต่ละ ref ในแต่ละลีก ถ้ายังไม่มีค่าราย ref เลยจะไม่แตะค่าเดิม
+func foldRefCounters(db models.Execer, playerID int) error {
+	_, err := db.Exec(`
+		UPDATE players k JOIN (
+			SELECT SUM(r.matches_played) AS matches_played, SUM(r.goals) AS goals,
+				SUM(r.yellow_cards) AS yellow_cards, SUM(r.red_cards) AS red_cards,
+				SUM(r.assists) AS assists, SUM(r.minutes_played) AS minutes_played
+			FROM player_ref_season_stats r
+			JOIN players p ON p.player_ref_id = r.player_ref_id
+			JOIN seasons se ON se.id = r.season_id
+			WHERE COALESCE(p.merged_into_id, p.id) = ?
+				AND se.season_start_date = (
+					SELECT MAX(se2.season_start_date)
+					FROM player_ref_season_stats r2 JOIN seasons se2 ON se2.id = r2.season_id
+					WHERE r2.player_ref_id = r.player_ref_id AND r2.league_id = r.league_id)
+			HAVING COUNT(*) > 0
+		) t
+		SET k.matches_played = t.matches_played, k.goals = t.goals,
+			k.yellow_cards = t.yellow_cards, k.red_cards = t.red_cards,
+			k.assists = COALESCE(t.assists, k.assists), k.minutes_played = COALESCE(t.minutes_played, k.minutes_played)
+		WHERE k.id = ?`, playerID, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to fold ref counters into player %d: %w", playerID, err)
+	}
+	return nil
+}
+
+// GetPlayerLeaders คืนผู้เล่นเรียงตาม stat ที่เลือกจากมากไปน้อย
+// ถ้าระบุ season จะใช้ player_season_stats ไม่เช่นนั้นใช้ค่าสะสมปัจจุบันในตาราง players
+// ชื่อฤดูกาลซ้ำกันได้ในหลายลีก จึงรวมสถิติของฤดูกาลเป็นแถวเดียวต่อผู้เล่น (ทีมคือทีมที่ลงเล่นมากที่สุด)
+func GetPlayerLeaders(db *sql.DB, f models.PlayerLeaderFilter) ([]models.PlayerLeaderDB, error) {
+	column, ok := leaderStatColumns[f.Stat]
+	if !ok {
+		return nil, fmt.Errorf("unknown stat %q", f.Stat)
+	}
+	var query string
+	var args []interface{}
+	if f.Season != "" {
+		inner := `
+				SELECT ps.player_id,
+					SUBSTRING_INDEX(GROUP_CONCAT(ps.team_id ORDER BY ps.matches_played DESC), ',', 1) AS team_id,
+					SUM(ps.matches_played) AS matches_played, SUM(ps.minutes_played) AS minutes_played,
+					SUM(ps.goals) AS goals, SUM(ps.assists) AS assists,
+					SUM(ps.yellow_cards) AS yellow_cards, SUM(ps.red_cards) AS red_cards
+				FROM player_season_stats ps
+				JOIN seasons se ON ps.season_id = se.id
+				WHERE se.name = ?`
+		args = append(args, f.Season)
+		if f.LeagueID > 0 {
+			inner += " AND ps.league_id = ?"
+			args = append(args, f.LeagueID)
+		}
+		if f.TeamID > 0 {
+			inner += " AND ps.team_id = ?"
+			args = append(args, f.TeamID)
+		}
+		inner += " GROUP BY ps.player_id"
+		query = `
+			SELECT p.id, p.name, p.full_name_en, p.photo_url, s.team_id, t.name_th, t.name_en,
+				s.matches_played, s.minutes_played, s.goals, s.assists, s.yellow_cards, s.red_cards
+			FROM (` + inner + `) s
+			JOIN players p ON s.player_id = p.id
+			LEFT JOIN teams t ON s.team_id = t.id
+			WHERE p.merged_into_id IS NULL AND s.` + column + ` > 0
+			ORDER BY s.` + column + ` DESC, p.name ASC`
+	} else {
+		query = `
+			SELECT p.id, p.name, p.full_name_en, p.photo_url, p.team_id, t.name_th, t.name_en,
+				COALESCE(p.matches_played, 0), p.minutes_played, COALESCE(p.goals, 0), p.assists,
+				COALESCE(p.yellow_cards, 0), COALESCE(p.red_cards, 0)
+			FROM players p
+			LEFT JOIN teams t ON p.team_id = t.id
+			WHERE p.merged_into_id IS NULL AND p.` + column + ` > 0`
+		if f.LeagueID > 0 {
+			query += " AND p.league_id = ?"
+			args = append(args, f.LeagueID)
+		}
+		if f.TeamID > 0 {
+			query += " AND p.team_id = ?"
+			args = append(args, f.TeamID)
+		}
+		query += " ORDER BY p." + column + " DESC, p.name ASC"
+	}
+	if f.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, f.Limit)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s leaders: %w", f.Stat, err)
+	}
+	defer rows.Close()
+	var leaders []models.PlayerLeaderDB
+	for rows.Next() {
+		var l models.PlayerLeaderDB
+		if err := rows.Scan(&l.PlayerID, &l.Name, &l.NameEN, &l.PhotoURL, &l.TeamID, &l.TeamName, &l.TeamNameEN,
+			&l.MatchesPlayed, &l.MinutesPlayed, &l.Goals, &l.Assists, &l.YellowCards, &l.RedCards); err != nil {
+			return nil, err
+		}
+		leaders = append(leaders, l)
+	}
+	return leaders, rows.Err()
+}
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-ballthai-scraper/models"
+)
+
+// aseanCodes คือรหัสประเทศสมาชิกอาเซียน (ทั้ง ISO alpha-2, alpha-3 และรหัส FIFA) ไม่รวมไทย
+var aseanCodes = codeSet("BN BRN KH KHM CAM ID IDN LA LAO MY MYS MAS MM MMR MYA PH PHL PHI SG SGP SIN VN VNM VIE TL TLS")
+
+// afcCodes คือรหัสประเทศสมาชิก AFC ที่ไม่ใช่อาเซียน
+var afcCodes = codeSet(`JP JPN KR KOR KP PRK CN CHN HK HKG MO MAC TW TWN TPE MN MNG AU AUS IR IRN IQ IRQ
+	SA SAU KSA AE ARE UAE QA QAT KW KWT KUW BH BHR OM OMN OMA YE YEM JO JOR SY SYR LB LBN PS PSE PLE
+	UZ UZB TJ TJK KG KGZ TM TKM AF AFG IN IND PK PAK BD BGD BAN LK LKA SRI NP NPL NEP BT BTN BHU MV MDV GU GUM`)
+
+func codeSet(codes string) map[string]bool {
+	set := map[string]bool{}
+	for _, c := range strings.Fields(codes) {
+		set[c] = true
+	}
+	return set
+}
+
+// ClassifyNationality จัดกลุ่มสัญชาติสำหรับโควตา override คือค่า quota_group ที่แก้ไขเองในตาราง nationalities
+// ถ้าไม่มีสัญชาติเลยจะคืน unknown เพื่อให้ตรวจเอง (ไม่เดาว่าเป็นคนไทย)
+func ClassifyNationality(code, name string, override sql.NullString) string {
+	switch g := strings.ToLower(strings.TrimSpace(override.String)); g {
+	case models.QuotaGroupThai, models.QuotaGroupASEAN, models.QuotaGroupAFC, models.QuotaGroupOther:
+		return g
+	}
+	c := strings.ToUpper(strings.TrimSpace(code))
+	n := strings.TrimSpace(name)
+	switch {
+	case c == "" && n == "":
+		return models.QuotaGroupUnknown
+	case c == "TH", c == "THA", n == "ไทย", strings.EqualFold(n, "Thailand"), strings.EqualFold(n, "Thai"):
+		return models.QuotaGroupThai
+	case aseanCodes[c]:
+		return models.QuotaGroupASEAN
+	case afcCodes[c]:
+		return models.QuotaGroupAFC
+	}
+	return models.QuotaGroupOther
+}
+
+// GetQuotaRules คืนโควตาของลีก ใช้แถวของฤดูกาล (ชื่อ season) ก่อน แล้วจึงใช้ค่าเริ่มต้นของลีก
+// ถ้าไม่มีทั้งสองแบบจะคืนโควตาที่ไม่จำกัด
+func GetQuotaRules(db *sql.DB, leagueID int, season string) (models.QuotaRules, error) {
+	rules := models.QuotaRules{LeagueID: leagueID}
+	var seasonID, maxASEAN, maxAFC, maxOther, maxTotal sql.NullInt64
+	err := db.QueryRow(`
+		SELECT q.season_id, q.max_asean, q.max_afc, q.max_other, q.max_foreign_total
+		FROM quota_rules q
+		LEFT JOIN seasons se ON q.season_id = se.id
+		WHERE q.league_id = ? AND (q.season_id IS NULL OR se.name = ?)
+		ORDER BY q.season_id IS NULL ASC
+		LIMIT 1`, leagueID, season).Scan(&seasonID, &maxASEAN, &maxAFC, &maxOther, &maxTotal)
+	if err == sql.ErrNoRows {
+		return rules, nil
+	} else if err != nil {
+		return rules, fmt.Errorf("failed to query quota rules for league %d: %w", leagueID, err)
+	}
+	rules.SeasonID = nullIntPtr(seasonID)
+	rules.MaxASEAN, rules.MaxAFC, rules.MaxOther, rules.MaxForeignTotal =
+		nullIntPtr(maxASEAN), nullIntPtr(maxAFC), nullIntPtr(maxOther), nullIntPtr(maxTotal)
+	return rules, nil
+}
+
+// GetTeamQuotas จัดกลุ่มสัญชาติของผู้เล่นทุกทีมในลีกและเทียบกับโควตา
+// ถ้าระบุ season จะใช้รายชื่อจาก player_season_stats ของฤดูกาลนั้น ไม่เช่นนั้นใช้ทีมปัจจุบันในตาราง players
+// teamID > 0 จะคืนเฉพาะทีมนั้นพร้อมรายชื่อผู้เล่น
+func GetTeamQuotas(db *sql.DB, rules models.QuotaRules, season string, teamID int) ([]models.TeamQuota, error) {
+	var query string
+	args := []interface{}{rules.LeagueID}
+	if season != "" {
+		query = `
+			SELECT t.id, t.name_th, p.id, p.name, n.code, n.name, n.quota_group, COALESCE(s.matches_played, 0)
+			FROM player_season_stats s
+			JOIN players p ON s.player_id = p.id
+			JOIN seasons se ON s.season_id = se.id
+			JOIN teams t ON s.team_id = t.id
+			LEFT JOIN nationalities n ON p.nationality_id = n.id
+			WHERE s.league_id = ? AND se.name = ?`
+		args = append(args, season)
+	} else {
+		query = `
+			SELECT t.id, t.name_th, p.id, p.name, n.code, n.name, n.quota_group, COALESCE(p.matches_played, 0)
+			FROM players p
+			JOIN teams t ON p.team_id = t.id
+			LEFT JOIN nationalities n ON p.nationality_id = n.id
+			WHERE p.league_id = ? AND p.merged_into_id IS NULL`
+	}
+	if teamID > 0 {
+		query += " AND t.id = ?"
+		args = append(args, teamID)
+	}
+	query += " ORDER BY t.name_th ASC, p.name ASC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query squads for league %d: %w", rules.LeagueID, err)
+	}
+	defer rows.Close()
+
+	teams := map[int]*models.TeamQuota{}
+	var order []int
+	for rows.Next() {
+		var tID, pID, played int
+		var teamName, playerName string
+		var code, natName, override sql.NullString
+		if err := rows.Scan(&tID, &teamName, &pID, &playerName, &code, &natName, &override, &played); err != nil {
+			return nil, err
+		}
+		tq := teams[tID]
+		if tq == nil {
+			tq = &models.TeamQuota{TeamID: tID, TeamName: teamName, Counts: emptyQuotaCounts(), Fielded: emptyQuotaCounts()}
+			teams[tID] = tq
+			order = append(order, tID)
+		}
+		group := ClassifyNationality(code.String, natName.String, override)
+		tq.Counts[group]++
+		if played > 0 {
+			tq.Fielded[group]++
+		}
+		if teamID > 0 {
+			tq.Players = append(tq.Players, models.QuotaPlayer{PlayerID: pID, Name: playerName,
+				Nationality: nullStringPtr(natName), Group: group, MatchesPlayed: played})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.TeamQuota, 0, len(order))
+	for _, id := range order {
+		tq := teams[id]
+		checkQuota(tq, rules)
+		result = append(result, *tq)
+	}
+	// ทีมที่ผิดโควตาขึ้นก่อน
+	sort.SliceStable(result, func(i, j int) bool { return !result[i].Compliant && result[j].Compliant })
+	return result, nil
+}
+
+func emptyQuotaCounts() map[string]int {
+	return map[string]int{
+		models.QuotaGroupThai: 0, models.QuotaGroupASEAN: 0, models.QuotaGroupAFC: 0,
+		models.QuotaGroupOther: 0, models.QuotaGroupUnknown: 0,
+	}
+}
+
+func foreignTotal(counts map[string]int) int {
+	return counts[models.QuotaGroupASEAN] + counts[models.QuotaGroupAFC] + counts[models.QuotaGroupOther]
+}
+
+// checkQuota นับผู้เล่นต่างชาติรวมและบันทึกรายการที่เกินโควตา ทั้งที่ลงทะเบียนและที่ลงสนาม (นำหน้าด้วย fielded_)
+// ผู้เล่นที่ไม่ทราบสัญชาติไม่นับเป็นต่างชาติ แต่ทำให้ทีมไม่ผ่านจนกว่าจะระบุสัญชาติ
+func checkQuota(tq *models.TeamQuota, rules models.QuotaRules) {
+	tq.ForeignTotal = foreignTotal(tq.Counts)
+	tq.FieldedForeign = foreignTotal(tq.Fielded)
+	tq.Violations = []string{}
+	check := func(prefix string, counts map[string]int, total int) {
+		limit := func(label string, count int, max *int) {
+			if max != nil && count > *max {
+				tq.Violations = append(tq.Violations, fmt.Sprintf("%s%s: %d/%d", prefix, label, count, *max))
+			}
+		}
+		limit(models.QuotaGroupASEAN, counts[models.QuotaGroupASEAN], rules.MaxASEAN)
+		limit(models.QuotaGroupAFC, counts[models.QuotaGroupAFC], rules.MaxAFC)
+		limit(models.QuotaGroupOther, counts[models.QuotaGroupOther], rules.MaxOther)
+		limit("foreign_total", total, rules.MaxForeignTotal)
+	}
+	check("", tq.Counts, tq.ForeignTotal)
+	check("fielded_", tq.Fielded, tq.FieldedForeign)
+	if n := tq.Counts[models.QuotaGroupUnknown]; n > 0 {
+		tq.Violations = append(tq.Violations, fmt.Sprintf("%s: %d", models.QuotaGroupUnknown, n))
+	}
+	tq.Compliant = len(tq.Violations) == 0
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/kickoff"
+	"go-ballthai-scraper/models"
+)
+
+// GetSanctions คืนบทลงโทษ/คะแนนปรับของลีก เรียงตามวันที่
+// seasonID ไม่ Valid = ทุกฤดูกาล, ถ้าระบุจะรวมแถวที่ไม่ผูกฤดูกาลด้วย
+// asOf = YYYY-MM-DD คืนเฉพาะที่มีผลแล้ว ณ วันนั้น, asOf ว่าง = ที่มีผลแล้ว ณ วันนี้ (เวลาไทย), asOf = "*" = ทั้งหมด
+func GetSanctions(db *sql.DB, leagueID int, seasonID sql.NullInt64, asOf string) ([]models.SanctionDB, error) {
+	query := `
+		SELECT s.id, s.league_id, s.season_id, s.stage_id, s.team_id, t.name_th, s.points, s.reason, s.reason_en,
+			DATE_FORMAT(s.sanction_date, '%Y-%m-%d')
+		FROM sanctions s
+		LEFT JOIN teams t ON t.id = s.team_id
+		WHERE s.league_id = ?`
+	args := []interface{}{leagueID}
+	if seasonID.Valid {
+		query += " AND (s.season_id = ? OR s.season_id IS NULL)"
+		args = append(args, seasonID.Int64)
+	}
+	if asOf == "" {
+		// ไม่ใช้ CURDATE() เพราะ timezone ของ MySQL อาจเป็น UTC ซึ่งช้ากว่าวันที่ไทยอยู่ 7 ชั่วโมง
+		asOf = kickoff.Today(kickoff.ZoneOrDefault(""))
+	}
+	if asOf != "*" {
+		query += " AND s.sanction_date <= ?"
+		args = append(args, asOf)
+	}
+	query += " ORDER BY s.sanction_date, s.id"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sanctions for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	sanctions := []models.SanctionDB{}
+	for rows.Next() {
+		var s models.SanctionDB
+		var season, stage sql.NullInt64
+		var teamName, reasonEN sql.NullString
+		if err := rows.Scan(&s.ID, &s.LeagueID, &season, &stage, &s.TeamID, &teamName, &s.Points, &s.Reason, &reasonEN,
+			&s.SanctionDate); err != nil {
+			return nil, err
+		}
+		s.SeasonID, s.StageID = nullIntPtr(season), nullIntPtr(stage)
+		s.TeamName, s.ReasonEN = nullStringPtr(teamName), nullStringPtr(reasonEN)
+		sanctions = append(sanctions, s)
+	}
+	return sanctions, rows.Err()
+}
+
+// CreateSanction บันทึกบทลงโทษใหม่ (ตรวจค่าด้วย SanctionDB.Validate ก่อน) คืน id ที่สร้าง
+func CreateSanction(db *sql.DB, s models.SanctionDB) (int, error) {
+	result, err := db.Exec(`
+		INSERT INTO sanctions (league_id, season_id, stage_id, team_id, points, reason, reason_en, sanction_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		s.LeagueID, s.SeasonID, s.StageID, s.TeamID, s.Points, s.Reason, s.ReasonEN, s.SanctionDate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert sanction for team %d: %w", s.TeamID, err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID for sanction: %w", err)
+	}
+	return int(id), nil
+}
+
+// DeleteSanction ลบบทลงโทษ คืน sql.ErrNoRows ถ้าไม่พบ
+func DeleteSanction(db *sql.DB, id int) error {
+	result, err := db.Exec("DELETE FROM sanctions WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete sanction %d: %w", id, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -174,3 +174,533 @@ CREATE TABLE IF NOT EXISTS seasons (
 );
 -- schema.sql
 -- ไฟล์นี้รวบรวมคำสั่ง SQL สำหรับสร้างตารางฐานข้อมูลทั้งหมด
+
+-- 12. สร้างตาราง standing_snapshots (ประวัติตารางคะแนนรายวัน/รายนัด)
+-- ทุกครั้งที่ scraper ดึงตารางคะแนน จะบันทึก snapshot แยกตามวันที่ เพื่อให้ดูอันดับย้อนหลังได้
+CREATE TABLE IF NOT EXISTS `standing_snapshots` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `league_id` INT NOT NULL,
+    `team_id` INT NOT NULL,
+    `stage_id` INT,
+    `snapshot_date` DATE NOT NULL,       -- วันที่ดึงข้อมูล
+    `matchday` INT NOT NULL DEFAULT 0,   -- นัดที่ (ใช้ matches_played ของทีม ณ วันนั้น)
+    `matches_played` INT DEFAULT 0,
+    `wins` INT DEFAULT 0,
+    `draws` INT DEFAULT 0,
+    `losses` INT DEFAULT 0,
+    `goals_for` INT DEFAULT 0,
+    `goals_against` INT DEFAULT 0,
+    `goal_difference` INT DEFAULT 0,
+    `points` INT DEFAULT 0,
+    `current_rank` INT,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    -- UNIQUE ไม่ถือว่า NULL ซ้ำกัน จึงใช้ stage_key (0 = ไม่มี stage) แทน stage_id ในคีย์
+    `stage_key` INT AS (COALESCE(`stage_id`, 0)) STORED,
+    UNIQUE KEY `uq_snapshot_team_stage_date` (`league_id`, `team_id`, `stage_key`, `snapshot_date`),
+    INDEX `idx_snapshot_league_date` (`league_id`, `snapshot_date`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`),
+    FOREIGN KEY (`stage_id`) REFERENCES `stage`(`id`)
+);
+
+-- 13. สร้างตาราง standing_rules (กติกาการจัดอันดับของแต่ละลีก)
+-- tiebreakers: ลำดับเกณฑ์เมื่อคะแนนเท่ากัน คั่นด้วย comma
+--   h2h_points, h2h_goal_difference, h2h_goals_for, goal_difference, goals_for, wins
+-- ลีกที่ไม่มีแถวในตารางนี้จะใช้ 3/1/0 และ goal_difference,goals_for
+CREATE TABLE IF NOT EXISTS `standing_rules` (
+    `league_id` INT PRIMARY KEY,
+    `points_win` INT NOT NULL DEFAULT 3,
+    `points_draw` INT NOT NULL DEFAULT 1,
+    `points_loss` INT NOT NULL DEFAULT 0,
+    `tiebreakers` VARCHAR(255) NOT NULL DEFAULT 'goal_difference,goals_for',
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`)
+);
+
+-- ไทยลีกใช้ผลการพบกัน (head-to-head) ก่อนผลต่างประตูรวม
+INSERT INTO standing_rules (league_id, tiebreakers) VALUES
+(1, 'h2h_points,h2h_goal_difference,h2h_goals_for,goal_difference,goals_for'),
+(2, 'h2h_points,h2h_goal_difference,h2h_goals_for,goal_difference,goals_for'),
+(3, 'h2h_points,h2h_goal_difference,h2h_goals_for,goal_difference,goals_for')
+ON DUPLICATE KEY UPDATE `tiebreakers` = VALUES(`tiebreakers`);
+
+-- 14. สร้างตาราง discipline_rules (เกณฑ์โทษแบนจากใบเหลือง/ใบแดงของแต่ละรายการ)
+-- yellow_threshold: ครบกี่ใบเหลืองถูกแบน (นับซ้ำทุกครั้งที่ครบจำนวน เช่น 4, 8, 12)
+-- fair_play_*_points: คะแนนที่ใช้ในตาราง fair play (น้อยกว่าดีกว่า)
+-- ลีกที่ไม่มีแถวในตารางนี้จะใช้ค่า default ด้านล่าง
+CREATE TABLE IF NOT EXISTS `discipline_rules` (
+    `league_id` INT PRIMARY KEY,
+    `yellow_threshold` INT NOT NULL DEFAULT 4,
+    `yellow_ban_matches` INT NOT NULL DEFAULT 1,
+    `red_ban_matches` INT NOT NULL DEFAULT 1,
+    `fair_play_yellow_points` INT NOT NULL DEFAULT 1,
+    `fair_play_red_points` INT NOT NULL DEFAULT 3,
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`)
+);
+
+-- 15. สร้างตาราง suspensions (โทษแบนที่ตรวจพบจากการดึงข้อมูลผู้เล่น)
+-- scraper จะเพิ่มแถวเมื่อใบเหลืองสะสมครบเกณฑ์หรือได้ใบแดงเพิ่ม
+-- incurred_date: วันที่นัดล่าสุดของทีมก่อนตรวจพบ ใช้นับจำนวนนัดที่รับโทษไปแล้ว
+CREATE TABLE IF NOT EXISTS `suspensions` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `player_id` INT NOT NULL,
+    `league_id` INT NOT NULL,
+    `season_id` INT NULL,               -- ฤดูกาลของ incurred_date (ใบสะสมนับใหม่ทุกฤดูกาล)
+    `team_id` INT,
+    `reason` VARCHAR(20) NOT NULL,      -- yellow_cards หรือ red_card
+    `card_count` INT NOT NULL,          -- จำนวนใบสะสมที่ทำให้ถูกแบน
+    `matches_banned` INT NOT NULL DEFAULT 1,
+    `incurred_date` DATE NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `season_key` INT AS (COALESCE(`season_id`, 0)) STORED, -- UNIQUE ไม่ถือว่า NULL ซ้ำกัน
+    UNIQUE KEY `uq_suspension_card` (`player_id`, `league_id`, `season_key`, `reason`, `card_count`),
+    FOREIGN KEY (`player_id`) REFERENCES `players`(`id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`)
+);
+
+-- 16. เพิ่มคอลัมน์ assists และ minutes_played ให้ตาราง players (NULL = ยังไม่มีข้อมูล)
+-- API ผู้เล่นของ ballthai ไม่มีสองค่านี้ และยังไม่มีข้อมูล event ของแมตช์ให้คำนวณ จึงกรอกผ่าน PUT /api/players/{id} เท่านั้น
+ALTER TABLE `players`
+    ADD COLUMN `assists` INT NULL AFTER `goals`,
+    ADD COLUMN `minutes_played` INT NULL AFTER `matches_played`;
+
+-- 17. สร้างตาราง player_season_stats (สถิติผู้เล่นแยกตามลีกและฤดูกาล)
+-- ScrapePlayers บันทึกค่าสะสมของฤดูกาลปัจจุบันทุกครั้งที่ดึงข้อมูล ใช้ทำ leaderboard ย้อนหลังรายฤดูกาล
+CREATE TABLE IF NOT EXISTS `player_season_stats` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `player_id` INT NOT NULL,
+    `league_id` INT NOT NULL,
+    `season_id` INT NOT NULL,
+    `team_id` INT,
+    `matches_played` INT DEFAULT 0,
+    `minutes_played` INT NULL,
+    `goals` INT DEFAULT 0,
+    `assists` INT NULL,
+    `yellow_cards` INT DEFAULT 0,
+    `red_cards` INT DEFAULT 0,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE (`player_id`, `league_id`, `season_id`),
+    FOREIGN KEY (`player_id`) REFERENCES `players`(`id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`)
+);
+
+-- 18. เพิ่มคอลัมน์ quota_group ให้ตาราง nationalities (thai, asean, afc, other)
+-- NULL = ให้ระบบจัดกลุ่มจากรหัสประเทศอัตโนมัติ, กำหนดเองได้เมื่อรหัสจาก API ไม่ตรงมาตรฐาน
+ALTER TABLE `nationalities` ADD COLUMN `quota_group` VARCHAR(10) NULL;
+
+-- 19. สร้างตาราง quota_rules (โควตานักเตะต่างชาติของแต่ละลีก/ฤดูกาล)
+-- season_id NULL = ค่าเริ่มต้นของลีก, ถ้ามีแถวของฤดูกาลนั้นจะใช้แถวนั้นก่อน
+-- max_* NULL = ไม่จำกัด; max_foreign_total นับผู้เล่นที่ไม่ใช่คนไทยทั้งหมด (asean + afc + other)
+CREATE TABLE IF NOT EXISTS `quota_rules` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `league_id` INT NOT NULL,
+    `season_id` INT NULL,
+    `max_asean` INT NULL,
+    `max_afc` INT NULL,
+    `max_other` INT NULL,
+    `max_foreign_total` INT NULL,
+    `season_key` INT AS (COALESCE(`season_id`, 0)) STORED, -- UNIQUE ไม่กัน NULL ซ้ำ จึงใช้ 0 แทนค่าเริ่มต้นของลีก
+    UNIQUE KEY `uq_quota_league_season` (`league_id`, `season_key`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`)
+);
+
+-- 20. เพิ่มข้อมูลโปรไฟล์ผู้เล่น (กรอกผ่าน UpdatePlayer: ยังไม่ได้ยืนยันชื่อฟิลด์ของ player-public API จึงไม่ดึงอัตโนมัติ)
+-- อายุไม่เก็บในตาราง คำนวณจาก birth_date ตอนเรียก API
+ALTER TABLE `players`
+    ADD COLUMN `birth_date` DATE NULL,
+    ADD COLUMN `height_cm` INT NULL,
+    ADD COLUMN `weight_kg` INT NULL,
+    ADD COLUMN `preferred_foot` VARCHAR(10) NULL,  -- left, right, both
+    ADD COLUMN `birthplace` VARCHAR(255) NULL,
+    ADD COLUMN `career_start` INT NULL;            -- ปีที่เริ่มเล่นอาชีพ
+
+-- 21. รูปผู้เล่น: photo_pinned = 1 คือรูปที่อัปโหลดเอง ScrapePlayers จะไม่เขียนทับ
+-- และแปลง photo_url เดิมที่เป็น path ในเครื่อง (img/player/x.png, ./img/player/x.png) เป็น web path (/img/player/x.png)
+ALTER TABLE `players` ADD COLUMN `photo_pinned` TINYINT(1) NOT NULL DEFAULT 0;
+UPDATE `players` SET `photo_url` = REPLACE(`photo_url`, '\\', '/') WHERE `photo_url` LIKE '%\\\\%';
+UPDATE `players` SET `photo_url` = SUBSTRING(`photo_url`, 2) WHERE `photo_url` LIKE './img/%';
+UPDATE `players` SET `photo_url` = CONCAT('/', `photo_url`) WHERE `photo_url` LIKE 'img/%';
+
+-- 22. การรวมผู้เล่นซ้ำ (คนเดียวกันแต่มีหลาย player_ref_id เช่น ในถ้วย หรือหลังย้ายลีก)
+-- merged_into_id: ผู้เล่นที่ถูกรวมแล้วจะชี้ไปยัง record หลัก และไม่แสดงใน API รายชื่อผู้เล่น
+-- player_duplicate_ignores: คู่ที่ตรวจแล้วว่าไม่ใช่คนเดียวกัน (player_a_id < player_b_id)
+ALTER TABLE `players` ADD COLUMN `merged_into_id` INT NULL,
+    ADD CONSTRAINT `fk_players_merged_into` FOREIGN KEY (`merged_into_id`) REFERENCES `players`(`id`);
+
+CREATE TABLE IF NOT EXISTS `player_duplicate_ignores` (
+    `player_a_id` INT NOT NULL,
+    `player_b_id` INT NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`player_a_id`, `player_b_id`),
+    FOREIGN KEY (`player_a_id`) REFERENCES `players`(`id`),
+    FOREIGN KEY (`player_b_id`) REFERENCES `players`(`id`)
+);
+
+-- player_ref_season_stats: ค่าสะสมล่าสุดของแต่ละ player_ref_id ตามที่ API ส่งมา
+-- player_season_stats และสถิติสะสมของผู้เล่นที่มีหลาย ref คือผลรวมของทุก ref (ดู SavePlayerSeasonStats)
+CREATE TABLE IF NOT EXISTS `player_ref_season_stats` (
+    `player_ref_id` INT NOT NULL,
+    `league_id` INT NOT NULL,
+    `season_id` INT NOT NULL,
+    `team_id` INT,
+    `matches_played` INT DEFAULT 0,
+    `minutes_played` INT NULL,
+    `goals` INT DEFAULT 0,
+    `assists` INT NULL,
+    `yellow_cards` INT DEFAULT 0,
+    `red_cards` INT DEFAULT 0,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`player_ref_id`, `league_id`, `season_id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`)
+);
+
+-- ตั้งต้นจากสถิติรายฤดูกาลเดิมของผู้เล่นที่มี ref เดียว (record ที่รวมแล้วแยกค่าราย ref ย้อนหลังไม่ได้)
+INSERT IGNORE INTO `player_ref_season_stats` (`player_ref_id`, `league_id`, `season_id`, `team_id`,
+    `matches_played`, `minutes_played`, `goals`, `assists`, `yellow_cards`, `red_cards`)
+SELECT p.`player_ref_id`, s.`league_id`, s.`season_id`, s.`team_id`,
+    s.`matches_played`, s.`minutes_played`, s.`goals`, s.`assists`, s.`yellow_cards`, s.`red_cards`
+FROM `player_season_stats` s
+JOIN `players` p ON p.`id` = s.`player_id`
+WHERE p.`player_ref_id` IS NOT NULL AND p.`merged_into_id` IS NULL
+    AND NOT EXISTS (SELECT 1 FROM `players` c WHERE c.`merged_into_id` = p.`id`);
+
+-- 23. โครงสร้างสายการแข่งขันแบบน็อกเอาต์ (ถ้วย: League Cup, FA Cup, BGC Cup)
+-- ใช้กับลีกที่ leagues.competition_type เป็น cup หรือ super_cup (ดู §30)
+-- knockout_rounds: หนึ่งรอบต่อ ลีก/ฤดูกาล/stage, round_order NULL = เรียงตามวันที่นัดแรกของรอบ
+--   legs กำหนดเองต่อรอบ (PUT /api/bracket/rounds/{id}) ต้องตั้งเป็น 2 ก่อน เลกสองถึงจะถูกผูกเข้ากับคู่
+-- knockout_ties: คู่การแข่งขัน team_a คือทีมเหย้าในเลกแรก
+--   penalties_*/extra_time ใช้เมื่อแมตช์ไม่มีข้อมูลต่อเวลา/ยิงจุดโทษ, winner_team_id กำหนดเองได้ (เช่น ชนะบาย)
+--   next_tie_id/next_slot: ผู้ชนะจะถูกส่งเข้าคู่ถัดไปในช่อง a หรือ b
+CREATE TABLE IF NOT EXISTS `knockout_rounds` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `league_id` INT NOT NULL,
+    `season_id` INT NULL,
+    `stage_id` INT NOT NULL,
+    `round_order` INT NULL,
+    `legs` TINYINT NOT NULL DEFAULT 1,
+    `away_goals` TINYINT(1) NOT NULL DEFAULT 0,   -- ใช้กฎประตูทีมเยือนในรอบสองเลก
+    UNIQUE (`league_id`, `season_id`, `stage_id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    FOREIGN KEY (`stage_id`) REFERENCES `stage`(`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `knockout_ties` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `round_id` INT NOT NULL,
+    `bracket_position` INT NULL,
+    `team_a_id` INT NULL,
+    `team_b_id` INT NULL,
+    `leg1_match_id` INT NULL,
+    `leg2_match_id` INT NULL,
+    `extra_time` TINYINT(1) NOT NULL DEFAULT 0,
+    `penalties_a` INT NULL,
+    `penalties_b` INT NULL,
+    `winner_team_id` INT NULL,
+    `next_tie_id` INT NULL,
+    `next_slot` CHAR(1) NULL,                     -- a หรือ b
+    UNIQUE (`leg1_match_id`),
+    UNIQUE (`leg2_match_id`),
+    FOREIGN KEY (`round_id`) REFERENCES `knockout_rounds`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`team_a_id`) REFERENCES `teams`(`id`),
+    FOREIGN KEY (`team_b_id`) REFERENCES `teams`(`id`),
+    FOREIGN KEY (`leg1_match_id`) REFERENCES `matches`(`id`),
+    FOREIGN KEY (`leg2_match_id`) REFERENCES `matches`(`id`),
+    FOREIGN KEY (`winner_team_id`) REFERENCES `teams`(`id`),
+    FOREIGN KEY (`next_tie_id`) REFERENCES `knockout_ties`(`id`)
+);
+
+-- 24. เพิ่มสกอร์ครึ่งแรก ต่อเวลา และยิงจุดโทษให้ตาราง matches
+-- home_score/away_score คือสกอร์เมื่อจบเวลาปกติ, *_score_et คือสกอร์รวมหลังต่อเวลา (นับรวมเวลาปกติ)
+-- decided_by: regular_time | extra_time | penalties (NULL = ยังไม่มีผล)
+ALTER TABLE `matches`
+    ADD COLUMN `home_score_ht` INT NULL,
+    ADD COLUMN `away_score_ht` INT NULL,
+    ADD COLUMN `home_score_et` INT NULL,
+    ADD COLUMN `away_score_et` INT NULL,
+    ADD COLUMN `home_penalties` INT NULL,
+    ADD COLUMN `away_penalties` INT NULL,
+    ADD COLUMN `decided_by` VARCHAR(20) NULL;
+
+-- 25. match_status เป็น enum และแยกการล็อกไม่ให้ scraper ทับข้อมูลออกเป็น scrape_locked
+-- ค่าเก่า: OFF = ล็อก, SLIP = เลื่อน + ล็อก, ADD/ว่าง/รหัสตัวเลข = scheduled หรือ finished ถ้ามีผลและเลยเวลาเตะแล้ว
+ALTER TABLE `matches` ADD COLUMN `scrape_locked` TINYINT(1) NOT NULL DEFAULT 0;
+UPDATE `matches` SET `scrape_locked` = 1 WHERE UPPER(`match_status`) IN ('OFF', 'SLIP');
+UPDATE `matches` SET `match_status` = CASE
+        WHEN UPPER(`match_status`) = 'SLIP' THEN 'postponed'
+        WHEN UPPER(`match_status`) IN ('FINISHED', 'FT') THEN 'finished'
+        WHEN `home_score` IS NOT NULL AND `away_score` IS NOT NULL
+            AND TIMESTAMP(`start_date`, `start_time`) <= NOW() THEN 'finished'
+        ELSE 'scheduled'
+    END
+WHERE `match_status` IS NULL OR `match_status` NOT IN
+    ('scheduled', 'live', 'half_time', 'finished', 'postponed', 'cancelled', 'abandoned', 'suspended');
+ALTER TABLE `matches` MODIFY `match_status`
+    ENUM('scheduled', 'live', 'half_time', 'finished', 'postponed', 'cancelled', 'abandoned', 'suspended')
+    NOT NULL DEFAULT 'scheduled';
+CREATE INDEX `idx_matches_status` ON `matches` (`match_status`);
+
+-- 26. เวลาเตะแบบมี timezone
+-- start_date/start_time คือเวลาท้องถิ่นของสนาม, kickoff_at คือเวลาเดียวกันเป็น UTC, timezone คือ IANA zone ของสนาม
+-- timezone ของแมตช์ = สนาม > ลีก > Asia/Bangkok (NULL ในตาราง stadiums/leagues = ใช้ค่าถัดไป)
+ALTER TABLE `leagues` ADD COLUMN `timezone` VARCHAR(64) NULL;
+ALTER TABLE `stadiums` ADD COLUMN `timezone` VARCHAR(64) NULL;
+ALTER TABLE `matches`
+    ADD COLUMN `timezone` VARCHAR(64) NULL,
+    ADD COLUMN `kickoff_at` DATETIME NULL;
+UPDATE `leagues` SET `timezone` = 'Asia/Tokyo' WHERE `name` LIKE 'J-League%';
+-- ข้อมูลเดิม: Asia/Bangkok (+07:00) และ Asia/Tokyo (+09:00) ไม่มี DST จึงแปลงด้วย offset คงที่ได้
+UPDATE `matches` m
+    LEFT JOIN `leagues` l ON l.id = m.league_id
+SET m.`timezone` = COALESCE(l.`timezone`, 'Asia/Bangkok'),
+    m.`kickoff_at` = TIMESTAMP(m.`start_date`, m.`start_time`)
+        - INTERVAL (CASE COALESCE(l.`timezone`, 'Asia/Bangkok') WHEN 'Asia/Tokyo' THEN 9 ELSE 7 END) HOUR;
+CREATE INDEX `idx_matches_kickoff_at` ON `matches` (`kickoff_at`);
+
+-- 27. ชื่อภาษาอังกฤษของลีกและ stage (teams/stadiums/players มีคอลัมน์ภาษาอังกฤษอยู่แล้ว)
+-- scraper เติมค่าจาก API เฉพาะเมื่อยังว่าง เพื่อไม่ทับชื่อที่แก้ไขเองใน dashboard
+ALTER TABLE `leagues` ADD COLUMN `name_en` VARCHAR(255) NULL;
+ALTER TABLE `stage` ADD COLUMN `stage_name_en` VARCHAR(255) NULL;
+UPDATE `leagues` SET `name_en` = CASE `id`
+        WHEN 1 THEN 'Thai League 1'
+        WHEN 2 THEN 'Thai League 2'
+        WHEN 3 THEN 'Thai League 3'
+        ELSE `name`
+    END
+WHERE `id` IN (1, 2, 3, 4, 5, 6, 59);
+
+-- 28. ชื่อภาษาอังกฤษ/slug ที่ถอดจากชื่อไทยอัตโนมัติ (romanize แบบ RTGS) รอผู้ดูแลตรวจใน dashboard
+-- สร้างเฉพาะแถวที่ยังไม่มีชื่อภาษาอังกฤษ; accepted จะเขียนลงคอลัมน์ภาษาอังกฤษและ slug ของตารางนั้น
+ALTER TABLE `teams` ADD COLUMN `slug` VARCHAR(255) NULL;
+ALTER TABLE `players` ADD COLUMN `slug` VARCHAR(255) NULL;
+ALTER TABLE `stadiums` ADD COLUMN `slug` VARCHAR(255) NULL;
+CREATE INDEX `idx_teams_slug` ON `teams` (`slug`);
+CREATE INDEX `idx_players_slug` ON `players` (`slug`);
+CREATE INDEX `idx_stadiums_slug` ON `stadiums` (`slug`);
+CREATE TABLE IF NOT EXISTS `name_suggestions` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `entity_type` ENUM('team', 'player', 'stadium') NOT NULL,
+    `entity_id` INT NOT NULL,
+    `source_name` VARCHAR(255) NOT NULL,
+    `suggested_name` VARCHAR(255) NOT NULL,
+    `suggested_slug` VARCHAR(255) NOT NULL,
+    `status` ENUM('pending', 'accepted', 'rejected') NOT NULL DEFAULT 'pending',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `reviewed_at` DATETIME NULL,
+    UNIQUE KEY `uniq_name_suggestion` (`entity_type`, `entity_id`),
+    KEY `idx_name_suggestions_status` (`status`)
+);
+
+-- 29. slug ของลีกและทะเบียน alias (t1, fa, league_cup, ...) แทน map ที่เขียนไว้ในโค้ด
+-- พารามิเตอร์ league/league_id/team ทุก endpoint แปลงผ่าน ResolveLeagueID/ResolveTeamID
+-- slug ของทีม/ผู้เล่นไม่ซ้ำกัน ตั้งครั้งเดียวตอนสร้าง (แถวเก่าตั้งให้ตอน start server) และไม่เปลี่ยนตามชื่อ
+ALTER TABLE `leagues` ADD COLUMN `slug` VARCHAR(64) NULL;
+CREATE UNIQUE INDEX `uniq_leagues_slug` ON `leagues` (`slug`);
+CREATE TABLE IF NOT EXISTS `league_aliases` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `league_id` INT NOT NULL,
+    `alias` VARCHAR(64) NOT NULL,
+    UNIQUE KEY `uniq_league_alias` (`alias`),
+    KEY `idx_league_aliases_league` (`league_id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`) ON DELETE CASCADE
+);
+UPDATE `leagues` SET `slug` = CASE `id`
+        WHEN 1 THEN 't1'
+        WHEN 2 THEN 't2'
+        WHEN 3 THEN 't3'
+        WHEN 4 THEN 'league-cup'
+        WHEN 5 THEN 'fa'
+        WHEN 6 THEN 'bgc'
+        WHEN 59 THEN 'samipro'
+        WHEN 60 THEN 't1-jpy'
+        WHEN 61 THEN 'pea-u21'
+    END
+WHERE `id` IN (1, 2, 3, 4, 5, 6, 59, 60, 61);
+INSERT IGNORE INTO `league_aliases` (`league_id`, `alias`)
+    SELECT `id`, 'league_cup' FROM `leagues` WHERE `id` = 4;
+DROP INDEX `idx_teams_slug` ON `teams`;
+DROP INDEX `idx_players_slug` ON `players`;
+CREATE UNIQUE INDEX `uniq_teams_slug` ON `teams` (`slug`);
+CREATE UNIQUE INDEX `uniq_players_slug` ON `players` (`slug`);
+
+-- 30. ข้อมูลประกอบของลีก: ประเภทรายการ, ระดับ, ประเทศ, เพศ, รุ่นอายุ, โลโก้, สปอนเซอร์, สี และลำดับการแสดงผล
+-- age_group NULL = ชุดใหญ่, tier NULL = ไม่มีระดับ (เช่นถ้วย), sort_order น้อยแสดงก่อน
+ALTER TABLE `leagues`
+    ADD COLUMN `competition_type` ENUM('league', 'cup', 'super_cup') NOT NULL DEFAULT 'league',
+    ADD COLUMN `tier` TINYINT NULL,
+    ADD COLUMN `country` CHAR(2) NULL,
+    ADD COLUMN `gender` ENUM('men', 'women') NOT NULL DEFAULT 'men',
+    ADD COLUMN `age_group` VARCHAR(8) NULL,
+    ADD COLUMN `logo_url` VARCHAR(255) NULL,
+    ADD COLUMN `sponsor_name` VARCHAR(255) NULL,
+    ADD COLUMN `primary_color` CHAR(7) NULL,
+    ADD COLUMN `secondary_color` CHAR(7) NULL,
+    ADD COLUMN `sort_order` INT NOT NULL DEFAULT 0;
+UPDATE `leagues` SET `country` = 'TH' WHERE `id` IN (1, 2, 3, 4, 5, 6, 59, 61);
+UPDATE `leagues` SET `country` = 'JP', `tier` = 1, `sort_order` = 90 WHERE `id` = 60;
+UPDATE `leagues` SET `tier` = 1, `sort_order` = 1 WHERE `id` = 1;
+UPDATE `leagues` SET `tier` = 2, `sort_order` = 2 WHERE `id` = 2;
+UPDATE `leagues` SET `tier` = 3, `sort_order` = 3 WHERE `id` = 3;
+UPDATE `leagues` SET `tier` = 4, `sort_order` = 4 WHERE `id` = 59;
+UPDATE `leagues` SET `competition_type` = 'cup', `sort_order` = 10 WHERE `id` = 5;
+UPDATE `leagues` SET `competition_type` = 'cup', `sort_order` = 11 WHERE `id` = 4;
+UPDATE `leagues` SET `competition_type` = 'cup', `sort_order` = 12 WHERE `id` = 6;
+UPDATE `leagues` SET `age_group` = 'U21', `sort_order` = 20 WHERE `id` = 61;
+CREATE INDEX `idx_leagues_sort_order` ON `leagues` (`sort_order`, `tier`);
+
+-- 31. stage ผูกกับลีกและฤดูกาล พร้อมประเภท ลำดับ และ stage ถัดไป
+-- stage_type: regular_season, group, knockout, playoff; stage_order น้อยมาก่อนภายในลีก/ฤดูกาลเดียวกัน
+-- next_stage_id ใช้บอกว่าผ่านจาก stage นี้ไป stage ไหน (เช่น รอบแบ่งกลุ่ม -> รอบ 16 ทีม)
+-- ชื่อ stage ซ้ำกันได้ในต่างลีก/ฤดูกาล; stage เดิมที่หลายลีกใช้ร่วมกันจะถูกแยกเป็นแถวละลีก
+ALTER TABLE `stage`
+    ADD COLUMN `league_id` INT NULL,
+    ADD COLUMN `season_id` INT NULL,
+    ADD COLUMN `stage_type` ENUM('regular_season', 'group', 'knockout', 'playoff') NOT NULL DEFAULT 'regular_season',
+    ADD COLUMN `stage_order` INT NOT NULL DEFAULT 0,
+    ADD COLUMN `next_stage_id` INT NULL,
+    ADD CONSTRAINT `fk_stage_league` FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    ADD CONSTRAINT `fk_stage_season` FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    ADD CONSTRAINT `fk_stage_next` FOREIGN KEY (`next_stage_id`) REFERENCES `stage`(`id`) ON DELETE SET NULL;
+ALTER TABLE `stage` DROP INDEX `stage_name`;
+CREATE TEMPORARY TABLE `stage_usage` AS
+    SELECT DISTINCT `stage_id`, `league_id` FROM (
+        SELECT `stage_id`, `league_id` FROM `matches`
+        UNION ALL SELECT `stage_id`, `league_id` FROM `standings`
+        UNION ALL SELECT `stage_id`, `league_id` FROM `standing_snapshots`
+        UNION ALL SELECT `stage_id`, `league_id` FROM `knockout_rounds`
+    ) u WHERE `stage_id` IS NOT NULL AND `league_id` IS NOT NULL;
+UPDATE `stage` s
+    JOIN (SELECT `stage_id`, MIN(`league_id`) AS `league_id` FROM `stage_usage` GROUP BY `stage_id`) u ON u.`stage_id` = s.`id`
+    SET s.`league_id` = u.`league_id`;
+INSERT INTO `stage` (`stage_name`, `stage_name_en`, `league_id`)
+    SELECT s.`stage_name`, s.`stage_name_en`, u.`league_id`
+    FROM `stage` s JOIN `stage_usage` u ON u.`stage_id` = s.`id`
+    WHERE u.`league_id` != s.`league_id`;
+UPDATE `matches` t JOIN `stage` o ON o.`id` = t.`stage_id`
+    JOIN `stage` n ON n.`stage_name` = o.`stage_name` AND n.`league_id` = t.`league_id`
+    SET t.`stage_id` = n.`id` WHERE o.`league_id` != t.`league_id`;
+UPDATE `standings` t JOIN `stage` o ON o.`id` = t.`stage_id`
+    JOIN `stage` n ON n.`stage_name` = o.`stage_name` AND n.`league_id` = t.`league_id`
+    SET t.`stage_id` = n.`id` WHERE o.`league_id` != t.`league_id`;
+UPDATE `standing_snapshots` t JOIN `stage` o ON o.`id` = t.`stage_id`
+    JOIN `stage` n ON n.`stage_name` = o.`stage_name` AND n.`league_id` = t.`league_id`
+    SET t.`stage_id` = n.`id` WHERE o.`league_id` != t.`league_id`;
+UPDATE `knockout_rounds` t JOIN `stage` o ON o.`id` = t.`stage_id`
+    JOIN `stage` n ON n.`stage_name` = o.`stage_name` AND n.`league_id` = t.`league_id`
+    SET t.`stage_id` = n.`id` WHERE o.`league_id` != t.`league_id`;
+DROP TEMPORARY TABLE `stage_usage`;
+-- ฤดูกาลตั้งให้เฉพาะ stage ที่ทุกแมตช์อยู่ในฤดูกาลเดียวกัน
+UPDATE `stage` s JOIN (
+        SELECT m.`stage_id`, MIN(se.`id`) AS `season_id`
+        FROM `matches` m
+        JOIN `seasons` se ON se.`league_id` = m.`league_id` AND m.`start_date` BETWEEN se.`season_start_date` AND se.`season_end_date`
+        WHERE m.`stage_id` IS NOT NULL
+        GROUP BY m.`stage_id` HAVING COUNT(DISTINCT se.`id`) = 1
+    ) ms ON ms.`stage_id` = s.`id`
+    SET s.`season_id` = ms.`season_id`;
+UPDATE `stage` SET `stage_type` = 'playoff' WHERE `stage_name` LIKE '%เพลย์ออฟ%' OR LOWER(`stage_name`) LIKE '%play%off%';
+UPDATE `stage` SET `stage_type` = 'group' WHERE `stage_type` = 'regular_season' AND (`stage_name` LIKE '%กลุ่ม%' OR LOWER(`stage_name`) LIKE '%group%');
+UPDATE `stage` SET `stage_type` = 'knockout' WHERE `id` IN (SELECT `stage_id` FROM `knockout_rounds`);
+UPDATE `stage` s JOIN (
+        SELECT st.`id`, ROW_NUMBER() OVER (PARTITION BY st.`league_id`, st.`season_id` ORDER BY MIN(m.`start_date`), st.`id`) AS `n`
+        FROM `stage` st LEFT JOIN `matches` m ON m.`stage_id` = st.`id`
+        GROUP BY st.`id`, st.`league_id`, st.`season_id`
+    ) o ON o.`id` = s.`id`
+    SET s.`stage_order` = o.`n`;
+-- UNIQUE ไม่ถือว่า NULL ซ้ำกัน จึงใช้ season_key (0 = ยังไม่ผูกฤดูกาล) แทน season_id ในคีย์
+-- stage ที่ยังไม่มีฤดูกาลจะถูกผูกหรือแยกตามฤดูกาลเมื่อ scraper พบแมตช์ของมัน (GetOrCreateStage)
+ALTER TABLE `stage`
+    ADD COLUMN `season_key` INT AS (COALESCE(`season_id`, 0)) STORED,
+    ADD UNIQUE KEY `uniq_stage_league_season_name` (`league_id`, `season_key`, `stage_name`);
+
+-- 32. โซนของตารางคะแนน (แชมป์, โควตา AFC, เลื่อนชั้น, เพลย์ออฟ, ตกชั้น) แทนสีที่เขียนไว้ใน widget
+-- position_from/position_to ติดลบคือนับจากท้ายตาราง (-1 = อันดับสุดท้าย) จึงไม่ต้องแก้เมื่อจำนวนทีมเปลี่ยน
+-- season_id/stage_id NULL = ใช้ทุกฤดูกาล/ทุก stage; ถ้ามีแถวที่เจาะจงกว่าจะใช้ชุดนั้นแทนทั้งชุด
+-- zone: champion, afc_champions_league, afc_champions_league_two, promotion, promotion_playoff, relegation_playoff, relegation
+CREATE TABLE IF NOT EXISTS `standing_zones` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `league_id` INT NOT NULL,
+    `season_id` INT NULL,
+    `stage_id` INT NULL,
+    `zone` VARCHAR(32) NOT NULL,
+    `position_from` INT NOT NULL,
+    `position_to` INT NOT NULL,
+    `color` CHAR(7) NOT NULL,
+    `label` VARCHAR(255) NOT NULL,
+    `label_en` VARCHAR(255) NULL,
+    KEY `idx_standing_zones_league` (`league_id`, `season_id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    FOREIGN KEY (`stage_id`) REFERENCES `stage`(`id`) ON DELETE CASCADE
+);
+INSERT INTO `standing_zones` (`league_id`, `zone`, `position_from`, `position_to`, `color`, `label`, `label_en`) VALUES
+(1, 'champion', 1, 1, '#1E8E3E', 'แชมป์ / AFC Champions League Elite', 'Champion / AFC Champions League Elite'),
+(1, 'afc_champions_league_two', 2, 2, '#4285F4', 'AFC Champions League Two', 'AFC Champions League Two'),
+(1, 'relegation', -3, -1, '#D93025', 'ตกชั้น', 'Relegation'),
+(2, 'promotion', 1, 2, '#1E8E3E', 'เลื่อนชั้น', 'Promotion'),
+(2, 'promotion_playoff', 3, 6, '#4285F4', 'เพลย์ออฟเลื่อนชั้น', 'Promotion play-off'),
+(2, 'relegation', -3, -1, '#D93025', 'ตกชั้น', 'Relegation');
+-- J1 League (id 60) อาจยังไม่มีในฐานข้อมูล จึงใส่ผ่าน SELECT จาก leagues แทน VALUES
+INSERT INTO `standing_zones` (`league_id`, `zone`, `position_from`, `position_to`, `color`, `label`, `label_en`)
+SELECT l.`id`, z.`zone`, z.`position_from`, z.`position_to`, z.`color`, z.`label`, z.`label_en`
+FROM `leagues` l JOIN (
+    SELECT 'champion' AS `zone`, 1 AS `position_from`, 1 AS `position_to`, '#1E8E3E' AS `color`,
+        'แชมป์ / AFC Champions League Elite' AS `label`, 'Champion / AFC Champions League Elite' AS `label_en`
+    UNION ALL SELECT 'afc_champions_league', 2, 3, '#4285F4', 'AFC Champions League', 'AFC Champions League'
+    UNION ALL SELECT 'relegation', -3, -1, '#D93025', 'ตกชั้น', 'Relegation'
+) z
+WHERE l.`id` = 60;
+
+-- 33. การหัก/เพิ่มคะแนน (sanctions) ที่ปรับบนตารางคะแนนที่ดึงมาหรือคำนวณเอง
+-- points ติดลบ = หักคะแนน, บวก = ได้คะแนน (เช่น ชนะบาย) แทนการแก้ standings.points แล้วตั้ง status = 1
+-- มีผลตั้งแต่ sanction_date (ตาราง as_of ก่อนวันนั้นไม่ถูกปรับ), stage_id NULL = ทุกตารางของทีมในลีก/ฤดูกาล
+CREATE TABLE IF NOT EXISTS `sanctions` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `league_id` INT NOT NULL,
+    `season_id` INT NULL,
+    `stage_id` INT NULL,
+    `team_id` INT NOT NULL,
+    `points` INT NOT NULL,
+    `reason` VARCHAR(255) NOT NULL,
+    `reason_en` VARCHAR(255) NULL,
+    `sanction_date` DATE NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY `idx_sanctions_league_season` (`league_id`, `season_id`),
+    FOREIGN KEY (`league_id`) REFERENCES `leagues`(`id`),
+    FOREIGN KEY (`season_id`) REFERENCES `seasons`(`id`),
+    FOREIGN KEY (`stage_id`) REFERENCES `stage`(`id`),
+    FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`)
+);
+
+-- 34. ตรวจโปรแกรมชนกัน (ทีมพักไม่ครบ, สนามเดียวกันวันเดียวกัน, ช่องถ่ายทอดชนเวลา, แข่งนอกช่วงฤดูกาล)
+-- min_rest_hours คือเวลาพักขั้นต่ำของทีมระหว่างสองนัด (นับจากเวลาเตะถึงเวลาเตะ) NULL = ค่าเริ่มต้น 48 ชั่วโมง
+ALTER TABLE `leagues` ADD COLUMN `min_rest_hours` INT NULL;
+
+-- ผลการตรวจล่าสุด (ตรวจหลัง scrape แมตช์ทุกครั้ง) other_match_id/subject_id = 0 คือไม่มี
+-- subject_id คือทีม (rest), สนาม (stadium) หรือช่อง (channel) ที่ชนกัน
+CREATE TABLE IF NOT EXISTS `match_conflicts` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `conflict_type` VARCHAR(20) NOT NULL,
+    `match_id` INT NOT NULL,
+    `other_match_id` INT NOT NULL DEFAULT 0,
+    `subject_id` INT NOT NULL DEFAULT 0,
+    `detail` VARCHAR(255) NOT NULL DEFAULT '',
+    `detected_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, -- ตรวจพบครั้งแรก (คงเดิมเมื่อยังชนในการตรวจรอบถัดไป)
+    UNIQUE KEY `uq_match_conflicts` (`conflict_type`, `match_id`, `other_match_id`, `subject_id`),
+    FOREIGN KEY (`match_id`) REFERENCES `matches`(`id`) ON DELETE CASCADE
+);
+
+-- 35. ตัวนับ match_ref_id ติดลบของแมตช์ที่สร้างเอง (CreateMatch, InsertMatch, CommitFixtures)
+-- issued คือจำนวนเลขที่จ่ายไปแล้ว เลขถัดไปคือ -(issued + 1); เริ่มจากเลขติดลบที่ใช้อยู่แล้วในตาราง matches
+CREATE TABLE IF NOT EXISTS `match_ref_sequence` (
+    `id` TINYINT PRIMARY KEY,
+    `issued` INT NOT NULL
+);
+INSERT IGNORE INTO `match_ref_sequence` (`id`, `issued`)
+    SELECT 1, COALESCE(-MIN(`match_ref_id`), 0) FROM `matches` WHERE `match_ref_id` < 0;
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-ballthai-scraper/romanize"
+)
+
+// ErrNotFound คือ league/team ที่ระบุด้วย id, slug, alias หรือชื่อแล้วไม่พบ
+var ErrNotFound = errors.New("not found")
+
+// slugSources คือ expression ของชื่อที่ใช้สร้าง slug (ภาษาอังกฤษก่อน ถ้าไม่มีจึงถอดจากชื่อไทย)
+var slugSources = map[string]string{
+	"teams":   "COALESCE(NULLIF(name_en, ''), name_th)",
+	"players": "COALESCE(NULLIF(full_name_en, ''), name)",
+}
+
+// uniqueSlug คืน slug จาก name ที่ยังไม่ซ้ำกับแถวอื่นในตาราง (ซ้ำจะเติม -2, -3, ...)
+func uniqueSlug(q interface {
+	QueryRow(string, ...interface{}) *sql.Row
+}, table, name string, id int) (string, error) {
+	base := romanize.Slug(name)
+	if base == "" {
+		base = strings.TrimSuffix(table, "s") + "-" + strconv.Itoa(id)
+	}
+	slug := base
+	for n := 2; ; n++ {
+		var taken bool
+		if err := q.QueryRow("SELECT EXISTS(SELECT 1 FROM `"+table+"` WHERE slug = ? AND id != ?)", slug, id).Scan(&taken); err != nil {
+			return "", fmt.Errorf("failed to check %s slug %q: %w", table, slug, err)
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(n)
+	}
+}
+
+// AssignSlug ตั้ง slug ให้แถวที่ยังไม่มี slug (slug ที่มีแล้วจะไม่เปลี่ยนตามชื่อ เพื่อให้ URL คงที่)
+func AssignSlug(db *sql.DB, table string, id int) error {
+	source, ok := slugSources[table]
+	if !ok {
+		return fmt.Errorf("table %s has no slug", table)
+	}
+	var name string
+	var slug sql.NullString
+	if err := db.QueryRow("SELECT "+source+", slug FROM `"+table+"` WHERE id = ?", id).Scan(&name, &slug); err != nil {
+		return fmt.Errorf("failed to load %s %d for slug: %w", table, id, err)
+	}
+	if slug.String != "" {
+		return nil
+	}
+	newSlug, err := uniqueSlug(db, table, name, id)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec("UPDATE `"+table+"` SET slug = ? WHERE id = ? AND (slug IS NULL OR slug = '')", newSlug, id); err != nil {
+		return fmt.Errorf("failed to set %s slug for id %d: %w", table, id, err)
+	}
+	return nil
+}
+
+// AssignMissingSlugs ตั้ง slug ให้ทีมและผู้เล่นทุกแถวที่ยังไม่มี คืนจำนวนแถวที่ตั้งให้
+func AssignMissingSlugs(db *sql.DB) (int, error) {
+	count := 0
+	for _, table := range []string{"teams", "players"} {
+		rows, err := db.Query("SELECT id FROM `" + table + "` WHERE slug IS NULL OR slug = '' ORDER BY id")
+		if err != nil {
+			return count, fmt.Errorf("failed to query %s without slug: %w", table, err)
+		}
+		var ids []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return count, err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return count, err
+		}
+		for _, id := range ids {
+			if err := AssignSlug(db, table, id); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ResolveLeagueID แปลงค่าพารามิเตอร์ league/league_id เป็น league id
+// รับได้ทั้งตัวเลข, slug, alias ใน league_aliases หรือชื่อลีกไทย/อังกฤษ (ไม่สนตัวพิมพ์)
+func ResolveLeagueID(db *sql.DB, value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+	var id int
+	err := db.QueryRow(`
+		SELECT id FROM (
+			SELECT id, 1 AS rank_order FROM leagues WHERE LOWER(slug) = LOWER(?)
+			UNION ALL
+			SELECT league_id, 2 FROM league_aliases WHERE LOWER(alias) = LOWER(?)
+			UNION ALL
+			SELECT id, 3 FROM leagues WHERE name = ? OR name_en = ?
+		) matched ORDER BY rank_order LIMIT 1`, value, value, value, value).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("league %q: %w", value, ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve league %q: %w", value, err)
+	}
+	return id, nil
+}
+
+// ResolveTeamID แปลงค่าพารามิเตอร์ team/team_id เป็น team id
+// รับได้ทั้งตัวเลข, slug หรือชื่อทีมไทย/อังกฤษ
+func ResolveTeamID(db *sql.DB, value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+	var id int
+	err := db.QueryRow(`
+		SELECT id FROM teams
+		WHERE LOWER(slug) = LOWER(?) OR name_th = ? OR name_en = ?
+		ORDER BY LOWER(slug) = LOWER(?) DESC, id LIMIT 1`, value, value, value, value).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("team %q: %w", value, ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve team %q: %w", value, err)
+	}
+	return id, nil
+}
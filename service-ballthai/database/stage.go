@@ -2,6 +2,11 @@ package database
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"go-ballthai-scraper/models"
 )
 
 // GetStageNameByID คืน stage_name จาก stage_id
@@ -13,3 +18,200 @@ func GetStageNameByID(db *sql.DB, stageID int64) (string, error) {
 	}
 	return name, nil
 }
+
+// GetOrCreateStage คืน stage ตามชื่อในลีกและฤดูกาลที่ระบุ (ไม่สนช่องว่าง) ถ้าไม่มีจะสร้างใหม่
+// stage ใหม่ได้ประเภทตามชื่อ (models.GuessStageType) และลำดับต่อท้าย stage อื่นของลีก/ฤดูกาลเดียวกัน
+// ถ้ามี stage ชื่อเดียวกันที่ยังไม่มีฤดูกาล (เช่นจาก migration ใน schema.sql §31) จะใช้แถวนั้นแทนการสร้างซ้ำ ดู adoptSeasonlessStage
+func GetOrCreateStage(db *sql.DB, leagueID int, seasonID sql.NullInt64, stageName string) (int, error) {
+	var stageID int
+	err := db.QueryRow(`
+		SELECT id FROM stage
+		WHERE league_id = ? AND season_id <=> ? AND REPLACE(stage_name, ' ', '') = REPLACE(?, ' ', '')
+		LIMIT 1`, leagueID, seasonID, stageName).Scan(&stageID)
+	if err == nil {
+		return stageID, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to query stage %s for league %d: %w", stageName, leagueID, err)
+	}
+	if seasonID.Valid {
+		if id, err := adoptSeasonlessStage(db, leagueID, seasonID.Int64, stageName); err != nil || id != 0 {
+			return id, err
+		}
+	}
+
+	newID, err := insertStage(db, leagueID, seasonID, stageName)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Inserted new stage: %s (ID: %d, league %d)", stageName, newID, leagueID)
+	return newID, nil
+}
+
+// insertStage สร้าง stage ใหม่ต่อท้าย stage อื่นของลีก/ฤดูกาลเดียวกัน
+func insertStage(db models.Execer, leagueID int, seasonID sql.NullInt64, stageName string) (int, error) {
+	result, err := db.Exec(`
+		INSERT INTO stage (stage_name, league_id, season_id, stage_type, stage_order)
+		SELECT ?, ?, ?, ?, COALESCE(MAX(stage_order), 0) + 1 FROM stage WHERE league_id = ? AND season_id <=> ?`,
+		stageName, leagueID, seasonID, models.GuessStageType(stageName), leagueID, seasonID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert new stage %s: %w", stageName, err)
+	}
+	newID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID for stage %s: %w", stageName, err)
+	}
+	return int(newID), nil
+}
+
+// adoptSeasonlessStage หา stage ชื่อเดียวกันของลีกที่ season_id เป็น NULL แล้วผูกกับฤดูกาล seasonID
+//   - ถ้าทุกแมตช์ของ stage นั้นอยู่ในช่วงวันที่ของฤดูกาล (หรือยังไม่มีแมตช์) จะตั้ง season_id ให้แถวเดิม
+//   - ถ้ามีแมตช์ของฤดูกาลอื่นปนอยู่ จะสร้าง stage ของฤดูกาลนี้แล้วย้ายแมตช์และรอบน็อกเอาต์ของฤดูกาลนี้ไป
+//
+// คืน 0 เมื่อไม่มี stage ให้ใช้
+func adoptSeasonlessStage(db *sql.DB, leagueID int, seasonID int64, stageName string) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var oldID, outside int
+	err = tx.QueryRow(`
+		SELECT st.id, (
+			SELECT COUNT(*) FROM matches m JOIN seasons se ON se.id = ?
+			WHERE m.stage_id = st.id AND (m.start_date < se.season_start_date OR m.start_date > se.season_end_date)
+		)
+		FROM stage st
+		WHERE st.league_id = ? AND st.season_id IS NULL AND REPLACE(st.stage_name, ' ', '') = REPLACE(?, ' ', '')
+		ORDER BY st.id LIMIT 1 FOR UPDATE`, seasonID, leagueID, stageName).Scan(&oldID, &outside)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to query stage %s without season for league %d: %w", stageName, leagueID, err)
+	}
+
+	if outside == 0 {
+		if _, err := tx.Exec("UPDATE stage SET season_id = ? WHERE id = ?", seasonID, oldID); err != nil {
+			return 0, fmt.Errorf("failed to set season of stage %d: %w", oldID, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+		log.Printf("Assigned season %d to stage %s (ID: %d, league %d)", seasonID, stageName, oldID, leagueID)
+		return oldID, nil
+	}
+
+	newID, err := insertStage(tx, leagueID, sql.NullInt64{Int64: seasonID, Valid: true}, stageName)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE matches m JOIN seasons se ON se.id = ?
+		SET m.stage_id = ?
+		WHERE m.stage_id = ? AND m.start_date BETWEEN se.season_start_date AND COALESCE(se.season_end_date, m.start_date)`,
+		seasonID, newID, oldID); err != nil {
+		return 0, fmt.Errorf("failed to move matches from stage %d to %d: %w", oldID, newID, err)
+	}
+	if _, err := tx.Exec("UPDATE knockout_rounds SET stage_id = ? WHERE stage_id = ? AND season_id = ?", newID, oldID, seasonID); err != nil {
+		return 0, fmt.Errorf("failed to move knockout rounds from stage %d to %d: %w", oldID, newID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	log.Printf("Split stage %s (ID: %d) into season %d stage %d for league %d", stageName, oldID, seasonID, newID, leagueID)
+	return newID, nil
+}
+
+// GetStages คืน stage เรียงตามลำดับ กรองด้วยลีก (0 = ทุกลีก) และฤดูกาล (ไม่ระบุ = ทุกฤดูกาล)
+// nameCol/leagueCol คือ expression ของชื่อ stage/ลีกตามภาษา
+func GetStages(db *sql.DB, leagueID int, seasonID sql.NullInt64, nameCol, leagueCol string) ([]models.StageDB, error) {
+	query := `
+		SELECT st.id, st.league_id, ` + leagueCol + `, st.season_id, se.name, ` + nameCol + `, st.stage_name_en,
+			st.stage_type, st.stage_order, st.next_stage_id
+		FROM stage st
+		LEFT JOIN leagues l ON l.id = st.league_id
+		LEFT JOIN seasons se ON se.id = st.season_id
+		WHERE st.stage_name IS NOT NULL AND st.stage_name != ''`
+	var args []interface{}
+	if leagueID != 0 {
+		query += " AND st.league_id = ?"
+		args = append(args, leagueID)
+	}
+	if seasonID.Valid {
+		query += " AND st.season_id = ?"
+		args = append(args, seasonID.Int64)
+	}
+	query += " ORDER BY l.sort_order, st.league_id, se.season_start_date DESC, st.stage_order, st.id"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stages: %w", err)
+	}
+	defer rows.Close()
+
+	stages := []models.StageDB{}
+	for rows.Next() {
+		var s models.StageDB
+		var league, season sql.NullInt64
+		var leagueName, seasonName, nameEN sql.NullString
+		var next sql.NullInt64
+		if err := rows.Scan(&s.ID, &league, &leagueName, &season, &seasonName, &s.StageName, &nameEN,
+			&s.StageType, &s.StageOrder, &next); err != nil {
+			return nil, err
+		}
+		s.LeagueID, s.SeasonID, s.NextStageID = nullIntPtr(league), nullIntPtr(season), nullIntPtr(next)
+		s.LeagueName, s.SeasonName, s.StageNameEN = nullStringPtr(leagueName), nullStringPtr(seasonName), nullStringPtr(nameEN)
+		stages = append(stages, s)
+	}
+	return stages, rows.Err()
+}
+
+// StageUpdate คือค่าที่แก้ไขได้ของ stage (nil = ไม่เปลี่ยน, next_stage_id = 0 คือล้างค่า)
+type StageUpdate struct {
+	StageNameEN *string `json:"stage_name_en"`
+	StageType   *string `json:"stage_type"`
+	StageOrder  *int    `json:"stage_order"`
+	NextStageID *int    `json:"next_stage_id"`
+}
+
+// ErrInvalidNextStage คือ next_stage_id ที่ชี้ไปยังตัวเอง, stage ที่ไม่มีอยู่ หรือ stage ของลีกอื่น
+var ErrInvalidNextStage = errors.New("next_stage_id must be another stage of the same league")
+
+// UpdateStage แก้ไขชื่อภาษาอังกฤษ ประเภท ลำดับ และ stage ถัดไป (stage_type ต้องตรวจด้วย models.IsStageType ก่อน)
+func UpdateStage(db *sql.DB, stageID int, u StageUpdate) error {
+	var next sql.NullInt64
+	if u.NextStageID != nil && *u.NextStageID != 0 {
+		if *u.NextStageID == stageID {
+			return ErrInvalidNextStage
+		}
+		var sameLeague bool
+		err := db.QueryRow(`
+			SELECT a.league_id <=> b.league_id FROM stage a, stage b WHERE a.id = ? AND b.id = ?`,
+			stageID, *u.NextStageID).Scan(&sameLeague)
+		if err == sql.ErrNoRows || (err == nil && !sameLeague) {
+			return ErrInvalidNextStage
+		} else if err != nil {
+			return fmt.Errorf("failed to check next stage: %w", err)
+		}
+		next = sql.NullInt64{Int64: int64(*u.NextStageID), Valid: true}
+	}
+	result, err := db.Exec(`
+		UPDATE stage SET
+			stage_name_en = IF(?, NULLIF(?, ''), stage_name_en),
+			stage_type = COALESCE(?, stage_type),
+			stage_order = COALESCE(?, stage_order),
+			next_stage_id = IF(?, ?, next_stage_id)
+		WHERE id = ?`,
+		u.StageNameEN != nil, u.StageNameEN, u.StageType, u.StageOrder, u.NextStageID != nil, next, stageID)
+	if err != nil {
+		return fmt.Errorf("failed to update stage %d: %w", stageID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		var exists bool
+		if err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM stage WHERE id = ?)", stageID).Scan(&exists); err == nil && !exists {
+			return sql.ErrNoRows
+		}
+	}
+	return nil
+}
@@ -5,9 +5,15 @@ import (
 )
 
 // GetStageIDByName returns stage id from stage_name (case-insensitive)
-func GetStageIDByName(db *sql.DB, stageName string) (int, error) {
+// leagueID 0 = any league; when several seasons share the name the latest season wins
+func GetStageIDByName(db *sql.DB, stageName string, leagueID int) (int, error) {
 	var id int
-	err := db.QueryRow("SELECT id FROM stage WHERE LOWER(stage_name) = LOWER(?)", stageName).Scan(&id)
+	err := db.QueryRow(`
+		SELECT st.id FROM stage st
+		LEFT JOIN seasons se ON se.id = st.season_id
+		WHERE LOWER(st.stage_name) = LOWER(?) AND (? = 0 OR st.league_id = ?)
+		ORDER BY se.season_start_date DESC, st.id DESC
+		LIMIT 1`, stageName, leagueID, leagueID).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
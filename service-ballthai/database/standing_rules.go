@@ -0,0 +1,100 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go-ballthai-scraper/models"
+)
+
+// defaultTieBreakers ใช้เมื่อไม่มีกติกาของลีกใน standing_rules (ผลต่างประตูรวมแบบ J-League)
+var defaultTieBreakers = []string{"goal_difference", "goals_for"}
+
+// GetStandingRules คืนกติกาการจัดอันดับของลีก ถ้าไม่มีแถวใน standing_rules จะคืนค่า default (3/1/0)
+func GetStandingRules(db *sql.DB, leagueID int) (models.StandingRules, error) {
+	rules := models.StandingRules{LeagueID: leagueID, PointsWin: 3, PointsDraw: 1, PointsLoss: 0, TieBreakers: defaultTieBreakers}
+	var tiebreakers string
+	err := db.QueryRow("SELECT points_win, points_draw, points_loss, tiebreakers FROM standing_rules WHERE league_id = ?", leagueID).
+		Scan(&rules.PointsWin, &rules.PointsDraw, &rules.PointsLoss, &tiebreakers)
+	if err == sql.ErrNoRows {
+		return rules, nil
+	} else if err != nil {
+		return rules, fmt.Errorf("failed to query standing rules for league %d: %w", leagueID, err)
+	}
+	rules.TieBreakers = nil
+	for _, tb := range strings.Split(tiebreakers, ",") {
+		if tb = strings.TrimSpace(tb); tb != "" {
+			rules.TieBreakers = append(rules.TieBreakers, tb)
+		}
+	}
+	return rules, nil
+}
+
+// GetFinishedMatchResults คืนผลการแข่งขันที่จบแล้วของลีก (มีสกอร์ เตะไปแล้ว และไม่ได้กำลังแข่งอยู่)
+// stageID ไม่ Valid = ทุก stage, asOf ว่าง = ถึงปัจจุบัน, asOf = YYYY-MM-DD นับถึงสิ้นวันนั้น
+func GetFinishedMatchResults(db *sql.DB, leagueID int, stageID sql.NullInt64, asOf string) ([]models.MatchResult, error) {
+	query := `
+		SELECT id, DATE_FORMAT(start_date, '%Y-%m-%d'), home_team_id, away_team_id, home_score, away_score
+		FROM matches
+		WHERE league_id = ?
+			AND home_team_id IS NOT NULL AND away_team_id IS NOT NULL
+			AND home_score IS NOT NULL AND away_score IS NOT NULL
+			AND match_status NOT IN ` + noResultStatuses + `
+			AND match_status NOT IN ` + inPlayStatuses
+	args := []interface{}{leagueID}
+	if stageID.Valid {
+		query += " AND stage_id = ?"
+		args = append(args, stageID.Int64)
+	}
+	if asOf != "" {
+		query += " AND start_date <= ? AND kickoff_at <= UTC_TIMESTAMP()"
+		args = append(args, asOf)
+	} else {
+		query += " AND kickoff_at <= UTC_TIMESTAMP()"
+	}
+	query += " ORDER BY start_date ASC, start_time ASC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finished matches for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+	var results []models.MatchResult
+	for rows.Next() {
+		var m models.MatchResult
+		if err := rows.Scan(&m.MatchID, &m.StartDate, &m.HomeTeamID, &m.AwayTeamID, &m.HomeScore, &m.AwayScore); err != nil {
+			return nil, err
+		}
+		results = append(results, m)
+	}
+	return results, rows.Err()
+}
+
+// GetTeamNames คืน map team_id -> name_th สำหรับ team id ที่ระบุ
+func GetTeamNames(db *sql.DB, teamIDs []int) (map[int]string, error) {
+	names := map[int]string{}
+	if len(teamIDs) == 0 {
+		return names, nil
+	}
+	placeholders := make([]string, len(teamIDs))
+	args := make([]interface{}, len(teamIDs))
+	for i, id := range teamIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := db.Query("SELECT id, name_th FROM teams WHERE id IN ("+strings.Join(placeholders, ",")+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team names: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
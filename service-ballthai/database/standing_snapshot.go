@@ -0,0 +1,108 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"go-ballthai-scraper/models"
+)
+
+// SaveStandingSnapshot บันทึก snapshot ของ standing ณ วันที่ระบุ (หนึ่งแถวต่อทีม/stage/วัน)
+// ถ้าวันเดียวกันถูกดึงซ้ำ จะเขียนทับค่าเดิมของวันนั้น
+func SaveStandingSnapshot(db *sql.DB, standing models.StandingDB, at time.Time) error {
+	snapshotDate := at.Format("2006-01-02")
+	query := `
+		INSERT INTO standing_snapshots (
+			league_id, team_id, stage_id, snapshot_date, matchday,
+			matches_played, wins, draws, losses,
+			goals_for, goals_against, goal_difference, points, current_rank
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			matchday = VALUES(matchday), matches_played = VALUES(matches_played),
+			wins = VALUES(wins), draws = VALUES(draws), losses = VALUES(losses),
+			goals_for = VALUES(goals_for), goals_against = VALUES(goals_against),
+			goal_difference = VALUES(goal_difference), points = VALUES(points),
+			current_rank = VALUES(current_rank)
+	`
+	_, err := db.Exec(query,
+		standing.LeagueID, standing.TeamID, standing.StageID, snapshotDate, standing.MatchesPlayed,
+		standing.MatchesPlayed, standing.Wins, standing.Draws, standing.Losses,
+		standing.GoalsFor, standing.GoalsAgainst, standing.GoalDifference, standing.Points, standing.CurrentRank,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save standing snapshot for team %d in league %d on %s: %w", standing.TeamID, standing.LeagueID, snapshotDate, err)
+	}
+	log.Printf("Saved standing snapshot for team %d in league %d on %s (matchday %d)", standing.TeamID, standing.LeagueID, snapshotDate, standing.MatchesPlayed)
+	return nil
+}
+
+// GetStandingHistory คืนค่าอันดับและคะแนนของทีมในลีกเรียงตามวันที่ (ใช้ทำกราฟอันดับตามเวลา)
+func GetStandingHistory(db *sql.DB, leagueID, teamID int) ([]models.StandingSnapshotDB, error) {
+	rows, err := db.Query(`
+		SELECT id, league_id, team_id, stage_id, DATE_FORMAT(snapshot_date, '%Y-%m-%d'), matchday,
+			matches_played, wins, draws, losses, goals_for, goals_against, goal_difference, points, current_rank
+		FROM standing_snapshots
+		WHERE league_id = ? AND team_id = ?
+		ORDER BY snapshot_date ASC, id ASC`, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query standing history for team %d in league %d: %w", teamID, leagueID, err)
+	}
+	defer rows.Close()
+
+	history := []models.StandingSnapshotDB{}
+	for rows.Next() {
+		var s models.StandingSnapshotDB
+		if err := rows.Scan(&s.ID, &s.LeagueID, &s.TeamID, &s.StageID, &s.SnapshotDate, &s.Matchday,
+			&s.MatchesPlayed, &s.Wins, &s.Draws, &s.Losses, &s.GoalsFor, &s.GoalsAgainst, &s.GoalDifference, &s.Points, &s.CurrentRank); err != nil {
+			return nil, err
+		}
+		history = append(history, s)
+	}
+	return history, rows.Err()
+}
+
+// GetStandingsAsOf คืนตารางคะแนนของลีก ณ วันที่ระบุ โดยใช้ snapshot ล่าสุดของแต่ละทีมที่ไม่เกินวันนั้น
+// ผลลัพธ์อยู่ในรูป models.StandingDB เพื่อให้ handler ใช้โค้ดแปลงผลร่วมกับตารางปัจจุบันได้
+func GetStandingsAsOf(db *sql.DB, leagueID int, stageID sql.NullInt64, asOf string) ([]models.StandingDB, error) {
+	query := `
+		SELECT ss.id, ss.league_id, ss.team_id, t.name_th as team_name, t.team_post_ballthai as team_post, t.logo_url as team_logo,
+			ss.stage_id, ss.matches_played, ss.wins, ss.draws, ss.losses,
+			ss.goals_for, ss.goals_against, ss.goal_difference, ss.points, ss.current_rank
+		FROM standing_snapshots ss
+		JOIN (
+			SELECT team_id, stage_id, MAX(snapshot_date) AS snapshot_date
+			FROM standing_snapshots
+			WHERE league_id = ? AND snapshot_date <= ?
+			GROUP BY team_id, stage_id
+		) latest ON latest.team_id = ss.team_id
+			AND latest.snapshot_date = ss.snapshot_date
+			AND (latest.stage_id = ss.stage_id OR (latest.stage_id IS NULL AND ss.stage_id IS NULL))
+		LEFT JOIN teams t ON ss.team_id = t.id
+		WHERE ss.league_id = ?`
+	args := []interface{}{leagueID, asOf, leagueID}
+	if stageID.Valid {
+		query += " AND ss.stage_id = ?"
+		args = append(args, stageID.Int64)
+	}
+	query += " ORDER BY ss.stage_id ASC, ss.current_rank ASC, ss.points DESC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query standings for league %d as of %s: %w", leagueID, asOf, err)
+	}
+	defer rows.Close()
+
+	var standings []models.StandingDB
+	for rows.Next() {
+		var s models.StandingDB
+		if err := rows.Scan(&s.ID, &s.LeagueID, &s.TeamID, &s.TeamName, &s.TeamPost, &s.TeamLogo,
+			&s.StageID, &s.MatchesPlayed, &s.Wins, &s.Draws, &s.Losses,
+			&s.GoalsFor, &s.GoalsAgainst, &s.GoalDifference, &s.Points, &s.CurrentRank); err != nil {
+			return nil, err
+		}
+		standings = append(standings, s)
+	}
+	return standings, rows.Err()
+}
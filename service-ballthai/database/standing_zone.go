@@ -0,0 +1,86 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/models"
+)
+
+// GetStandingZones คืนโซนทั้งหมดของลีกที่ใช้กับฤดูกาลที่ระบุ (รวมค่าเริ่มต้นที่ season_id เป็น NULL)
+// ใช้ standings.SelectZones เลือกชุดที่ตรงกับ season/stage ของตาราง
+func GetStandingZones(db *sql.DB, leagueID int, seasonID sql.NullInt64) ([]models.StandingZone, error) {
+	rows, err := db.Query(`
+		SELECT id, league_id, season_id, stage_id, zone, position_from, position_to, color, label, label_en
+		FROM standing_zones
+		WHERE league_id = ? AND (season_id IS NULL OR season_id <=> ?)
+		ORDER BY ABS(position_to - position_from), id`, leagueID, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query standing zones for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	zones := []models.StandingZone{}
+	for rows.Next() {
+		var z models.StandingZone
+		var season, stage sql.NullInt64
+		var labelEN sql.NullString
+		if err := rows.Scan(&z.ID, &z.LeagueID, &season, &stage, &z.Zone, &z.PositionFrom, &z.PositionTo,
+			&z.Color, &z.Label, &labelEN); err != nil {
+			return nil, err
+		}
+		z.SeasonID, z.StageID, z.LabelEN = nullIntPtr(season), nullIntPtr(stage), nullStringPtr(labelEN)
+		zones = append(zones, z)
+	}
+	return zones, rows.Err()
+}
+
+// SeasonEnded บอกว่าฤดูกาลจบแล้วหรือยัง (season_end_date ก่อนวันที่ today, YYYY-MM-DD)
+// ฤดูกาลที่ไม่ระบุหรือไม่มีวันสิ้นสุดถือว่ายังไม่จบ
+func SeasonEnded(db *sql.DB, seasonID sql.NullInt64, today string) (bool, error) {
+	if !seasonID.Valid {
+		return false, nil
+	}
+	var ended bool
+	err := db.QueryRow("SELECT COALESCE(season_end_date < ?, FALSE) FROM seasons WHERE id = ?", today, seasonID.Int64).Scan(&ended)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read end date of season %d: %w", seasonID.Int64, err)
+	}
+	return ended, nil
+}
+
+// GetRemainingFixtures คืนจำนวนนัดที่ยังไม่มีผลของแต่ละทีม แยกตาม stage_id (0 = ไม่มี stage)
+// นับนัดที่เลื่อนออกไปด้วย (ยังต้องเตะ) แต่ไม่นับนัดที่ยกเลิก ถ้าระบุฤดูกาลจะนับเฉพาะนัดในช่วงฤดูกาลนั้น
+func GetRemainingFixtures(db *sql.DB, leagueID int, seasonID sql.NullInt64) (map[int64]map[int]int, error) {
+	rows, err := db.Query(`
+		SELECT COALESCE(m.stage_id, 0), t.team_id, COUNT(*)
+		FROM matches m
+		JOIN (SELECT id, home_team_id AS team_id FROM matches WHERE league_id = ?
+			UNION ALL SELECT id, away_team_id FROM matches WHERE league_id = ?) t ON t.id = m.id
+		LEFT JOIN seasons se ON se.id = ?
+		WHERE m.league_id = ? AND t.team_id IS NOT NULL
+			AND m.match_status NOT IN ('cancelled', 'abandoned')
+			AND (m.home_score IS NULL OR m.away_score IS NULL OR m.kickoff_at > UTC_TIMESTAMP() OR m.match_status IN ('postponed', 'suspended'))
+			AND (se.id IS NULL OR m.start_date BETWEEN se.season_start_date AND COALESCE(se.season_end_date, m.start_date))
+		GROUP BY COALESCE(m.stage_id, 0), t.team_id`, leagueID, leagueID, seasonID, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remaining fixtures for league %d: %w", leagueID, err)
+	}
+	defer rows.Close()
+
+	remaining := map[int64]map[int]int{}
+	for rows.Next() {
+		var stageID int64
+		var teamID, count int
+		if err := rows.Scan(&stageID, &teamID, &count); err != nil {
+			return nil, err
+		}
+		if remaining[stageID] == nil {
+			remaining[stageID] = map[int]int{}
+		}
+		remaining[stageID][teamID] = count
+	}
+	return remaining, rows.Err()
+}
@@ -43,6 +43,9 @@ func GetTeamIDByThaiName(db *sql.DB, teamNameThai, teamLogoURL string) (int, err
 			return 0, fmt.Errorf("failed to get last insert ID for team: %w", err)
 		}
 		log.Printf("Inserted new team: %s (ID: %d)", teamNameThai, newID)
+		if err := AssignSlug(db, "teams", int(newID)); err != nil {
+			log.Printf("Warning: %v", err)
+		}
 		return int(newID), nil
 	} else if err != nil {
 		return 0, fmt.Errorf("failed to query team by name: %w", err)
@@ -77,7 +80,7 @@ func InsertOrUpdateTeam(db *sql.DB, team models.TeamDB) error {
 			       team_post_ballthai, website, shop, stadium_id
 		       ) VALUES (?, ?, ?, ?, ?, ?, ?)
 	       `
-	       _, err := db.Exec(insertQuery,
+	       result, err := db.Exec(insertQuery,
 		       team.NameTH, team.NameEN, sql.NullString{String: logoDBPath, Valid: logoDBPath != ""},
 		       team.TeamPostBallthai, team.Website, team.Shop, team.StadiumID,
 	       )
@@ -85,6 +88,11 @@ func InsertOrUpdateTeam(db *sql.DB, team models.TeamDB) error {
 		       return fmt.Errorf("failed to insert team %s: %w", team.NameTH, err)
 	       }
 	       log.Printf("Inserted new team: %s", team.NameTH)
+	       if newID, err := result.LastInsertId(); err == nil {
+		       if err := AssignSlug(db, "teams", int(newID)); err != nil {
+			       log.Printf("Warning: %v", err)
+		       }
+	       }
        } else if err != nil {
 	       return fmt.Errorf("failed to query existing team %s: %w", team.NameTH, err)
 	} else {
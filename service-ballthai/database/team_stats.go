@@ -0,0 +1,131 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-ballthai-scraper/models"
+)
+
+// GetSeasonDateRange คืนช่วงวันที่ของฤดูกาลจากตาราง seasons
+// leagueID = 0 คือไม่กรองลีก: ชื่อฤดูกาลเดียวกัน (เช่น "2024/25") มีได้หลายลีก จึงคืนช่วงที่ครอบทุกลีก
+// (วันเริ่มแรกสุดถึงวันจบหลังสุด) แทนการหยิบลีกใดลีกหนึ่ง
+func GetSeasonDateRange(db *sql.DB, name string, leagueID int) (start, end string, err error) {
+	query := `
+		SELECT COUNT(*), DATE_FORMAT(MIN(season_start_date), '%Y-%m-%d'), DATE_FORMAT(MAX(season_end_date), '%Y-%m-%d')
+		FROM seasons WHERE name = ?`
+	args := []interface{}{name}
+	if leagueID > 0 {
+		query += " AND league_id = ?"
+		args = append(args, leagueID)
+	}
+	var n int
+	var s, e sql.NullString
+	if err := db.QueryRow(query, args...).Scan(&n, &s, &e); err != nil {
+		return "", "", fmt.Errorf("failed to get date range for season %s: %w", name, err)
+	}
+	if n == 0 {
+		return "", "", fmt.Errorf("failed to get date range for season %s: %w", name, sql.ErrNoRows)
+	}
+	return s.String, e.String, nil
+}
+
+// GetTeamFinishedResults คืนผลการแข่งขันที่จบแล้วของทีม เรียงจากเก่าไปใหม่
+// leagueID = 0 คือทุกรายการ, from/to ว่างคือไม่จำกัดช่วงวันที่
+func GetTeamFinishedResults(db *sql.DB, teamID, leagueID int, from, to string) ([]models.MatchResult, error) {
+	query := `
+		SELECT id, DATE_FORMAT(start_date, '%Y-%m-%d'), home_team_id, away_team_id, home_score, away_score,
+			home_score_ht, away_score_ht
+		FROM matches
+		WHERE (home_team_id = ? OR away_team_id = ?)
+			AND home_team_id IS NOT NULL AND away_team_id IS NOT NULL
+			AND home_score IS NOT NULL AND away_score IS NOT NULL
+			AND kickoff_at <= UTC_TIMESTAMP()
+			AND match_status NOT IN ` + noResultStatuses + `
+			AND match_status NOT IN ` + inPlayStatuses
+	args := []interface{}{teamID, teamID}
+	if leagueID > 0 {
+		query += " AND league_id = ?"
+		args = append(args, leagueID)
+	}
+	if from != "" {
+		query += " AND start_date >= ?"
+		args = append(args, from)
+	}
+	if to != "" {
+		query += " AND start_date <= ?"
+		args = append(args, to)
+	}
+	query += " ORDER BY start_date ASC, start_time ASC, id ASC"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query results for team %d: %w", teamID, err)
+	}
+	defer rows.Close()
+	var results []models.MatchResult
+	for rows.Next() {
+		var m models.MatchResult
+		var homeHT, awayHT sql.NullInt64
+		if err := rows.Scan(&m.MatchID, &m.StartDate, &m.HomeTeamID, &m.AwayTeamID, &m.HomeScore, &m.AwayScore,
+			&homeHT, &awayHT); err != nil {
+			return nil, err
+		}
+		if homeHT.Valid && awayHT.Valid {
+			m.HomeScoreHT, m.AwayScoreHT = nullIntPtr(homeHT), nullIntPtr(awayHT)
+		}
+		results = append(results, m)
+	}
+	return results, rows.Err()
+}
+
+// teamSeasonScope สร้างเงื่อนไขของ player_season_stats (alias pss) ตามทีม ลีก (0 = ทุกลีก) และชื่อฤดูกาล ("" = ทุกฤดูกาล)
+func teamSeasonScope(teamID, leagueID int, season string) (string, []interface{}) {
+	where := "pss.team_id = ?"
+	args := []interface{}{teamID}
+	if leagueID > 0 {
+		where += " AND pss.league_id = ?"
+		args = append(args, leagueID)
+	}
+	if season != "" {
+		where += " AND se.name = ?"
+		args = append(args, season)
+	}
+	return where, args
+}
+
+// GetTeamTopScorer คืนผู้เล่นที่ยิงประตูมากที่สุดของทีมในฤดูกาล จาก player_season_stats (nil ถ้ายังไม่มีใครยิงได้)
+func GetTeamTopScorer(db *sql.DB, teamID, leagueID int, season string) (*models.TeamScorerDB, error) {
+	where, args := teamSeasonScope(teamID, leagueID, season)
+	var s models.TeamScorerDB
+	err := db.QueryRow(`
+		SELECT p.id, p.name, SUM(pss.goals) AS goals
+		FROM player_season_stats pss
+		JOIN players p ON p.id = pss.player_id
+		JOIN seasons se ON se.id = pss.season_id
+		WHERE `+where+`
+		GROUP BY p.id, p.name
+		HAVING goals > 0
+		ORDER BY goals DESC, SUM(pss.matches_played) ASC, p.id ASC LIMIT 1`, args...).Scan(&s.PlayerID, &s.Name, &s.Goals)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get top scorer for team %d: %w", teamID, err)
+	}
+	return &s, nil
+}
+
+// GetTeamDisciplineTotals รวมใบเหลือง/ใบแดงของผู้เล่นในทีมในฤดูกาล จาก player_season_stats
+func GetTeamDisciplineTotals(db *sql.DB, teamID, leagueID int, season string) (models.TeamDisciplineDB, error) {
+	where, args := teamSeasonScope(teamID, leagueID, season)
+	var d models.TeamDisciplineDB
+	err := db.QueryRow(`
+		SELECT COALESCE(SUM(pss.yellow_cards), 0), COALESCE(SUM(pss.red_cards), 0)
+		FROM player_season_stats pss
+		JOIN seasons se ON se.id = pss.season_id
+		WHERE `+where, args...).Scan(&d.YellowCards, &d.RedCards)
+	if err != nil {
+		return d, fmt.Errorf("failed to get discipline totals for team %d: %w", teamID, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,123 @@
+// Package fixtures สร้างโปรแกรมการแข่งขันแบบพบกันหมด (round-robin) สำหรับรายการที่จัดเอง
+// เช่น ทัวร์นาเมนต์กระชับมิตรหรือถ้วยเยาวชน โดยไม่แตะฐานข้อมูล
+package fixtures
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Options คือค่าที่ใช้สร้างโปรแกรม
+type Options struct {
+	TeamIDs     []int
+	StartDate   string   // YYYY-MM-DD วันแข่งของนัดแรก
+	DaysBetween int      // ระยะห่างระหว่างนัด (วัน), 0 = 7
+	Blackouts   []string // YYYY-MM-DD วันที่ห้ามแข่ง นัดที่ตรงจะเลื่อนไปวันถัดไปที่ว่าง
+	Double      bool     // true = เหย้า-เยือน (ครึ่งหลังสลับเจ้าบ้านของครึ่งแรก)
+}
+
+// Fixture คือหนึ่งนัดในโปรแกรมที่สร้าง
+type Fixture struct {
+	Matchday   int    `json:"matchday"`
+	StartDate  string `json:"start_date"`
+	HomeTeamID int    `json:"home_team_id"`
+	AwayTeamID int    `json:"away_team_id"`
+}
+
+// pair คือคู่แข่งขันในหนึ่งนัด (a = เจ้าบ้าน)
+type pair struct{ a, b int }
+
+// rounds จับคู่ด้วย circle method: ทีมแรกอยู่กับที่ ทีมที่เหลือหมุนทีละตำแหน่ง
+// เจ้าบ้านสลับกันตามแบบ Berger (ทีมที่อยู่กับที่สลับทุกนัด, คู่อื่นสลับตามตำแหน่ง)
+// ทำให้จำนวนนัดเหย้าต่างกันไม่เกิน 1 และมีการเล่นเหย้า/เยือนติดกันน้อยที่สุด
+// จำนวนทีมคี่จะเติมบาย (0) ไว้ในตำแหน่งที่อยู่กับที่ ทีมที่เจอบายได้พักในนัดนั้น
+// (ถ้าบายอยู่ในวงที่หมุน จำนวนนัดเหย้าจะต่างกันได้ถึง 2)
+func rounds(teams []int) [][]pair {
+	ring := append([]int(nil), teams...)
+	if len(ring)%2 == 1 {
+		ring = append([]int{0}, ring...)
+	}
+	n := len(ring)
+	out := make([][]pair, 0, n-1)
+	for r := 0; r < n-1; r++ {
+		round := make([]pair, 0, n/2)
+		for i := 0; i < n/2; i++ {
+			home, away := ring[i], ring[n-1-i]
+			if (i == 0 && r%2 == 1) || (i > 0 && i%2 == 1) {
+				home, away = away, home
+			}
+			if home != 0 && away != 0 {
+				round = append(round, pair{home, away})
+			}
+		}
+		out = append(out, round)
+		// หมุนทุกตำแหน่งยกเว้นตำแหน่งแรก
+		last := ring[n-1]
+		copy(ring[2:], ring[1:n-1])
+		ring[1] = last
+	}
+	return out
+}
+
+// Generate สร้างโปรแกรมแบบพบกันหมดตาม opts เรียงตามนัด
+func Generate(opts Options) ([]Fixture, error) {
+	if len(opts.TeamIDs) < 2 {
+		return nil, fmt.Errorf("at least 2 teams are required")
+	}
+	seen := map[int]bool{}
+	for _, id := range opts.TeamIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("invalid team id %d", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("team %d is listed twice", id)
+		}
+		seen[id] = true
+	}
+	date, err := time.Parse(dateLayout, opts.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("start_date must be YYYY-MM-DD")
+	}
+	spacing := opts.DaysBetween
+	if spacing == 0 {
+		spacing = 7
+	}
+	if spacing < 0 {
+		return nil, fmt.Errorf("days_between must be positive")
+	}
+	blackout := map[string]bool{}
+	for _, d := range opts.Blackouts {
+		if _, err := time.Parse(dateLayout, d); err != nil {
+			return nil, fmt.Errorf("blackout date %s must be YYYY-MM-DD", d)
+		}
+		blackout[d] = true
+	}
+
+	schedule := rounds(opts.TeamIDs)
+	if opts.Double {
+		first := len(schedule)
+		for _, round := range schedule[:first] {
+			mirrored := make([]pair, len(round))
+			for i, p := range round {
+				mirrored[i] = pair{p.b, p.a}
+			}
+			schedule = append(schedule, mirrored)
+		}
+	}
+
+	var out []Fixture
+	for md, round := range schedule {
+		if md > 0 {
+			date = date.AddDate(0, 0, spacing)
+		}
+		for blackout[date.Format(dateLayout)] {
+			date = date.AddDate(0, 0, 1)
+		}
+		for _, p := range round {
+			out = append(out, Fixture{Matchday: md + 1, StartDate: date.Format(dateLayout), HomeTeamID: p.a, AwayTeamID: p.b})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package fixtures
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name         string
+		teams        int
+		double       bool
+		wantMatchday int
+	}{
+		{"even number of teams", 6, false, 5},
+		{"odd number of teams gets a bye each matchday", 5, false, 5},
+		{"two teams", 2, false, 1},
+		{"double round-robin mirrors the first half", 4, true, 6},
+		{"double round-robin with a bye", 7, true, 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]int, tt.teams)
+			for i := range ids {
+				ids[i] = 10 + i
+			}
+			got, err := Generate(Options{TeamIDs: ids, StartDate: "2025-08-01", Double: tt.double})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			meetings := map[[2]int]int{} // [เจ้าบ้าน, ทีมเยือน] -> จำนวนนัด
+			home := map[int]int{}
+			playing := map[int]map[int]bool{} // matchday -> ทีมที่ลงเตะ
+			lastMatchday := 0
+			for _, f := range got {
+				if f.Matchday < lastMatchday {
+					t.Fatalf("fixtures are not ordered by matchday: %d after %d", f.Matchday, lastMatchday)
+				}
+				lastMatchday = f.Matchday
+				if playing[f.Matchday] == nil {
+					playing[f.Matchday] = map[int]bool{}
+				}
+				for _, id := range []int{f.HomeTeamID, f.AwayTeamID} {
+					if playing[f.Matchday][id] {
+						t.Errorf("team %d plays twice on matchday %d", id, f.Matchday)
+					}
+					playing[f.Matchday][id] = true
+				}
+				meetings[[2]int{f.HomeTeamID, f.AwayTeamID}]++
+				home[f.HomeTeamID]++
+			}
+			if lastMatchday != tt.wantMatchday {
+				t.Errorf("matchdays = %d, want %d", lastMatchday, tt.wantMatchday)
+			}
+
+			for _, a := range ids {
+				for _, b := range ids {
+					if a >= b {
+						continue
+					}
+					ab, ba := meetings[[2]int{a, b}], meetings[[2]int{b, a}]
+					if tt.double && (ab != 1 || ba != 1) {
+						t.Errorf("teams %d and %d meet %d+%d times, want once at each ground", a, b, ab, ba)
+					}
+					if !tt.double && ab+ba != 1 {
+						t.Errorf("teams %d and %d meet %d times, want once", a, b, ab+ba)
+					}
+				}
+			}
+
+			if !tt.double {
+				lo, hi := len(got), 0
+				for _, id := range ids {
+					if home[id] < lo {
+						lo = home[id]
+					}
+					if home[id] > hi {
+						hi = home[id]
+					}
+				}
+				if hi-lo > 1 {
+					t.Errorf("home games range from %d to %d, want a difference of at most 1", lo, hi)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateDates(t *testing.T) {
+	got, err := Generate(Options{
+		TeamIDs:     []int{1, 2, 3, 4},
+		StartDate:   "2025-08-01",
+		DaysBetween: 3,
+		Blackouts:   []string{"2025-08-04", "2025-08-05"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dates := map[int]string{}
+	for _, f := range got {
+		dates[f.Matchday] = f.StartDate
+	}
+	// นัดที่ 2 ตรงวันห้ามแข่งสองวันจึงเลื่อนเป็นวันที่ 6 และนัดถัดไปนับต่อจากวันที่เลื่อนแล้ว
+	want := map[int]string{1: "2025-08-01", 2: "2025-08-06", 3: "2025-08-09"}
+	if !reflect.DeepEqual(dates, want) {
+		t.Errorf("dates = %v, want %v", dates, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"single team", Options{TeamIDs: []int{1}, StartDate: "2025-08-01"}},
+		{"duplicate team", Options{TeamIDs: []int{1, 2, 1}, StartDate: "2025-08-01"}},
+		{"invalid team id", Options{TeamIDs: []int{1, 0}, StartDate: "2025-08-01"}},
+		{"invalid start date", Options{TeamIDs: []int{1, 2}, StartDate: "01/08/2025"}},
+		{"negative spacing", Options{TeamIDs: []int{1, 2}, StartDate: "2025-08-01", DaysBetween: -1}},
+		{"invalid blackout date", Options{TeamIDs: []int{1, 2}, StartDate: "2025-08-01", Blackouts: []string{"tomorrow"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.opts); err == nil {
+				t.Error("Generate returned no error")
+			}
+		})
+	}
+}
@@ -13,14 +13,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"strconv"
-	"time"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/kickoff"
+	"go-ballthai-scraper/models"
 )
 
 // Data structures
@@ -31,8 +31,9 @@ type League struct {
 
 type Team struct {
 	ID              int     `json:"id"`
-	Name            string  `json:"name"` // เพิ่ม field name (map จาก name_th)
+	Name            string  `json:"name"` // ชื่อตามภาษาที่ขอ (fallback เป็น name_th)
 	NameTh          string  `json:"name_th"`
+	NameEn          *string `json:"name_en"`
 	StadiumID       *int    `json:"stadium_id,omitempty"`
 	StadiumName     *string `json:"stadium_name,omitempty"`
 	Logo            *string `json:"logo"` // ส่ง logo_url เป็น logo
@@ -74,6 +75,14 @@ type Match struct {
 	AwayLogo      *string `json:"logo_away,omitempty"`
 	StageID       *int    `json:"stage_id,omitempty"`
 	StageName     *string `json:"stage_name,omitempty"`
+	HomeScoreHT   *int    `json:"home_score_ht,omitempty"`
+	AwayScoreHT   *int    `json:"away_score_ht,omitempty"`
+	HomeScoreET   *int    `json:"home_score_et,omitempty"`
+	AwayScoreET   *int    `json:"away_score_et,omitempty"`
+	HomePenalties *int    `json:"home_penalties,omitempty"`
+	AwayPenalties *int    `json:"away_penalties,omitempty"`
+	DecidedBy     *string `json:"decided_by,omitempty"`
+	*kickoff.View
 }
 
 type Player struct {
@@ -146,11 +155,45 @@ func UpdateMatch(w http.ResponseWriter, r *http.Request) {
 		MatchStatus   string `json:"match_status"`
 		ChannelID     *int   `json:"channel_id"`
 		LiveChannelID *int   `json:"live_channel_id"`
+		ScrapeLocked  *bool  `json:"scrape_locked"`
+		ForceStatus   bool   `json:"force_status"`
+		models.MatchScoreDetail
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
 		return
 	}
+	if msg := req.Validate(&req.HomeScore, &req.AwayScore); msg != "" {
+		http.Error(w, fmt.Sprintf(`{"success": false, "error": "%s"}`, msg), http.StatusBadRequest)
+		return
+	}
+	var currentStatus string
+	if err := DB.QueryRow("SELECT match_status FROM matches WHERE id = ?", id).Scan(&currentStatus); err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "Match not found"}`, http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+		return
+	}
+	status, msg := models.ResolveMatchStatus(req.MatchStatus, models.MatchStatus(currentStatus), req.ForceStatus)
+	if msg != "" {
+		http.Error(w, fmt.Sprintf(`{"success": false, "error": "%s"}`, msg), http.StatusBadRequest)
+		return
+	}
+	errs, err := models.ValidateMatch(DB, models.MatchInput{
+		ID: id, LeagueID: req.LeagueID, StageID: req.StageID,
+		StartDate: req.StartDate, StartTime: req.StartTime,
+		HomeTeamID: req.HomeTeamID, AwayTeamID: req.AwayTeamID,
+		HomeScore: &req.HomeScore, AwayScore: &req.AwayScore,
+	})
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+		return
+	}
+	if errs != nil {
+		models.WriteValidationErrors(w, errs)
+		return
+	}
 	query := `UPDATE matches SET
 		league_id = ?,
 		stage_id = ?,
@@ -162,8 +205,19 @@ func UpdateMatch(w http.ResponseWriter, r *http.Request) {
 		away_score = ?,
 		match_status = ?,
 		channel_id = ?,
-		live_channel_id = ?
+		live_channel_id = ?,
+		home_score_ht = ?,
+		away_score_ht = ?,
+		home_score_et = ?,
+		away_score_et = ?,
+		home_penalties = ?,
+		away_penalties = ?,
+		decided_by = ?,
+		scrape_locked = COALESCE(?, scrape_locked),
+		timezone = ?,
+		kickoff_at = ?
 		WHERE id = ?`
+	zone, kickoffAt := models.MatchKickoff(DB, sqlNullInt64(int64(req.LeagueID)), sqlNullInt64(int64(req.HomeTeamID)), req.StartDate, req.StartTime)
 	// Normalize stage_id: treat 0 or missing as NULL to avoid FK violation (no stage.id == 0)
 	var stageID interface{} = nil
 	if req.StageID != nil && *req.StageID > 0 {
@@ -172,7 +226,10 @@ func UpdateMatch(w http.ResponseWriter, r *http.Request) {
 	_, err = DB.Exec(query,
 		req.LeagueID, stageID, req.StartDate, req.StartTime,
 		req.HomeTeamID, req.AwayTeamID, req.HomeScore, req.AwayScore,
-		req.MatchStatus, req.ChannelID, req.LiveChannelID, id,
+		string(status), req.ChannelID, req.LiveChannelID,
+		req.HomeScoreHT, req.AwayScoreHT, req.HomeScoreET, req.AwayScoreET,
+		req.HomePenalties, req.AwayPenalties, req.DecidedByArg(), req.ScrapeLocked,
+		zone, kickoffAt, id,
 	)
 	if err != nil {
 		http.Error(w, `{"success": false, "error": "Failed to update match"}`, http.StatusInternalServerError)
@@ -191,12 +248,21 @@ func GetMatchByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, `{"success": false, "error": "Invalid match id"}`, http.StatusBadRequest)
 		return
 	}
+	loc, err := requestZone(r)
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Invalid tz parameter"}`, http.StatusBadRequest)
+		return
+	}
+	lang := requestLang(w, r)
 	query := `
 		SELECT m.id, m.league_id, m.stage_id, m.start_date, m.start_time,
 			   m.home_team_id, m.away_team_id, m.home_score, m.away_score,
 			   m.match_status, m.channel_id, m.live_channel_id,
-		       ht.name_th as home_team, at.name_th as away_team,
-		       s.id as stadium_id, s.name as stadium, l.name as league_name,
+			   m.home_score_ht, m.away_score_ht, m.home_score_et, m.away_score_et,
+			   m.home_penalties, m.away_penalties, m.decided_by, m.scrape_locked,
+			   DATE_FORMAT(m.kickoff_at, '%Y-%m-%d %H:%i:%s'), m.timezone,
+		       ` + localName(lang, "ht.name_th", "ht.name_en") + ` as home_team, ` + localName(lang, "at.name_th", "at.name_en") + ` as away_team,
+		       s.id as stadium_id, ` + localName(lang, "s.name", "s.name_en") + ` as stadium, ` + localName(lang, "l.name", "l.name_en") + ` as league_name,
 			   ht.team_post_ballthai as team_post_home, at.team_post_ballthai as team_post_away
 		FROM matches m
 		LEFT JOIN teams ht ON m.home_team_id = ht.id
@@ -219,6 +285,15 @@ func GetMatchByID(w http.ResponseWriter, r *http.Request) {
 		MatchStatus   string  `json:"match_status"`
 		ChannelID     *int    `json:"channel_id"`
 		LiveChannelID *int    `json:"live_channel_id"`
+		HomeScoreHT   *int    `json:"home_score_ht"`
+		AwayScoreHT   *int    `json:"away_score_ht"`
+		HomeScoreET   *int    `json:"home_score_et"`
+		AwayScoreET   *int    `json:"away_score_et"`
+		HomePenalties *int    `json:"home_penalties"`
+		AwayPenalties *int    `json:"away_penalties"`
+		DecidedBy     *string `json:"decided_by"`
+		ScrapeLocked  bool    `json:"scrape_locked"`
+		*kickoff.View
 		HomeTeam      string  `json:"home_team"`
 		AwayTeam      string  `json:"away_team"`
 		StadiumID     *int    `json:"stadium_id"`
@@ -227,10 +302,14 @@ func GetMatchByID(w http.ResponseWriter, r *http.Request) {
 		TeamPostHome  *string `json:"team_post_home"`
 		TeamPostAway  *string `json:"team_post_away"`
 	}
+	var kickoffAt, zone sql.NullString
 	row := DB.QueryRow(query, id)
 	err = row.Scan(&resp.ID, &resp.LeagueID, &resp.StageID, &resp.StartDate, &resp.StartTime,
 		&resp.HomeTeamID, &resp.AwayTeamID, &resp.HomeScore, &resp.AwayScore,
 		&resp.MatchStatus, &resp.ChannelID, &resp.LiveChannelID,
+		&resp.HomeScoreHT, &resp.AwayScoreHT, &resp.HomeScoreET, &resp.AwayScoreET,
+		&resp.HomePenalties, &resp.AwayPenalties, &resp.DecidedBy, &resp.ScrapeLocked,
+		&kickoffAt, &zone,
 		&resp.HomeTeam, &resp.AwayTeam, &resp.StadiumID, &resp.Stadium, &resp.LeagueName,
 		&resp.TeamPostHome, &resp.TeamPostAway)
 	if err == sql.ErrNoRows {
@@ -245,8 +324,9 @@ func GetMatchByID(w http.ResponseWriter, r *http.Request) {
 		resp.TeamPostAway = &zero
 	}
 	if resp.MatchStatus == "" {
-		resp.MatchStatus = "ADD"
+		resp.MatchStatus = string(models.StatusScheduled)
 	}
+	resp.View = kickoffView(kickoffAt, zone, loc)
 	response := APIResponse{
 		Success: true,
 		Data:    resp,
@@ -270,6 +350,7 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 	// If caller asks for a specific team via team_post_ballthai, return
 	// an object containing the team, next upcoming match, past results, and players.
 	teamPost := r.URL.Query().Get("team_post_ballthai")
+	lang := requestLang(w, r)
 	if teamPost != "" {
 		// Find team by team_post_ballthai
 		var teamID int
@@ -277,7 +358,7 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 		var stadiumID sql.NullInt64
 		var stadiumName sql.NullString
 		var logo sql.NullString
-		row := DB.QueryRow(`SELECT id, name_th, stadium_id, (SELECT name FROM stadiums s WHERE s.id = t.stadium_id) as stadium_name, logo_url FROM teams t WHERE t.team_post_ballthai = ? LIMIT 1`, teamPost)
+		row := DB.QueryRow(`SELECT id, `+localName(lang, "name_th", "name_en")+`, stadium_id, (SELECT `+localName(lang, "s.name", "s.name_en")+` FROM stadiums s WHERE s.id = t.stadium_id) as stadium_name, logo_url FROM teams t WHERE t.team_post_ballthai = ? LIMIT 1`, teamPost)
 		if err := row.Scan(&teamID, &teamName, &stadiumID, &stadiumName, &logo); err != nil {
 			http.Error(w, fmt.Sprintf("Team with team_post_ballthai=%s not found: %v", teamPost, err), http.StatusNotFound)
 			return
@@ -299,11 +380,19 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 			teamObj["logo_url"] = logo.String
 		}
 
+		// "วันนี้" ตาม ?tz= (ค่าเริ่มต้น Asia/Bangkok) เทียบกับ kickoff_at (UTC)
+		loc, err := requestZone(r)
+		if err != nil {
+			http.Error(w, "Invalid tz parameter", http.StatusBadRequest)
+			return
+		}
+		dayStart, dayEnd, _ := kickoff.DayRangeUTC(kickoff.Today(loc), loc)
+
 		// Next upcoming matches (up to 10 upcoming matches with start_date >= today)
 		var nextMatches []map[string]interface{}
 	// include team names and league name instead of numeric ids
-	nmQuery := `SELECT m.id, m.start_date, m.start_time, m.home_team_id, m.away_team_id, ht.name_th as home_team, at.name_th as away_team, m.home_score, m.away_score, m.match_status, l.name as league_name, m.stage_id, m.channel_id, m.live_channel_id FROM matches m LEFT JOIN teams ht ON m.home_team_id = ht.id LEFT JOIN teams at ON m.away_team_id = at.id LEFT JOIN leagues l ON m.league_id = l.id WHERE (m.home_team_id = ? OR m.away_team_id = ?) AND DATE(m.start_date) >= CURDATE() ORDER BY m.start_date ASC, m.start_time ASC LIMIT 10`
-		nrows, nerr := DB.Query(nmQuery, teamID, teamID)
+	nmQuery := `SELECT m.id, m.start_date, m.start_time, m.home_team_id, m.away_team_id, ` + localName(lang, "ht.name_th", "ht.name_en") + ` as home_team, ` + localName(lang, "at.name_th", "at.name_en") + ` as away_team, m.home_score, m.away_score, m.match_status, ` + localName(lang, "l.name", "l.name_en") + ` as league_name, m.stage_id, m.channel_id, m.live_channel_id, DATE_FORMAT(m.kickoff_at, '%Y-%m-%d %H:%i:%s'), m.timezone, ht.logo_url, at.logo_url FROM matches m LEFT JOIN teams ht ON m.home_team_id = ht.id LEFT JOIN teams at ON m.away_team_id = at.id LEFT JOIN leagues l ON m.league_id = l.id WHERE (m.home_team_id = ? OR m.away_team_id = ?) AND m.kickoff_at >= ? ORDER BY m.kickoff_at ASC LIMIT 10`
+		nrows, nerr := DB.Query(nmQuery, teamID, teamID, dayStart)
 		if nerr == nil {
 			defer nrows.Close()
 			for nrows.Next() {
@@ -321,27 +410,25 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 				var nmStage sql.NullInt64
 				var nmChannel sql.NullInt64
 				var nmLiveChannel sql.NullInt64
-				if err := nrows.Scan(&nmID, &nmDate, &nmTime, &nmHome, &nmAway, &nmHomeName, &nmAwayName, &nmHomeScore, &nmAwayScore, &nmStatus, &nmLeagueName, &nmStage, &nmChannel, &nmLiveChannel); err == nil {
+				var nmKickoff, nmZone sql.NullString
+				var nmHomeLogo, nmAwayLogo sql.NullString
+				if err := nrows.Scan(&nmID, &nmDate, &nmTime, &nmHome, &nmAway, &nmHomeName, &nmAwayName, &nmHomeScore, &nmAwayScore, &nmStatus, &nmLeagueName, &nmStage, &nmChannel, &nmLiveChannel, &nmKickoff, &nmZone, &nmHomeLogo, &nmAwayLogo); err == nil {
 					hm := ""
 					am := ""
 					ln := ""
 					if nmHomeName.Valid { hm = nmHomeName.String }
 					if nmAwayName.Valid { am = nmAwayName.String }
 					if nmLeagueName.Valid { ln = nmLeagueName.String }
-					// lookup logos
+					// logos
 					var homeLogoVal interface{} = nil
 					var awayLogoVal interface{} = nil
-					if hm != "" {
-						if hl, err := database.GetTeamLogoByName(DB, hm); err == nil && hl != "" {
-							homeLogoVal = hl
-						}
+					if nmHomeLogo.String != "" {
+						homeLogoVal = nmHomeLogo.String
 					}
-					if am != "" {
-						if al, err := database.GetTeamLogoByName(DB, am); err == nil && al != "" {
-							awayLogoVal = al
-						}
+					if nmAwayLogo.String != "" {
+						awayLogoVal = nmAwayLogo.String
 					}
-					nextMatches = append(nextMatches, map[string]interface{}{
+					nm := map[string]interface{}{
 						"id":           int(nmID.Int64),
 						"start_date":   nmDate.String,
 						"start_time":   nmTime.String,
@@ -356,15 +443,17 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 						"stage_id":     nilSafeInt(nmStage),
 						"channel_id":   nilSafeInt(nmChannel),
 						"live_channel_id": nilSafeInt(nmLiveChannel),
-					})
+					}
+					addKickoff(nm, kickoffView(nmKickoff, nmZone, loc))
+					nextMatches = append(nextMatches, nm)
 				}
 			}
 		}
 
 		// Past results (last 10 matches where date <= today)
 		// include team names and league name for past results
-		pastQuery := `SELECT m.id, m.start_date, m.start_time, m.home_team_id, m.away_team_id, ht.name_th as home_team, at.name_th as away_team, m.home_score, m.away_score, m.match_status, l.name as league_name FROM matches m LEFT JOIN teams ht ON m.home_team_id = ht.id LEFT JOIN teams at ON m.away_team_id = at.id LEFT JOIN leagues l ON m.league_id = l.id WHERE (m.home_team_id = ? OR m.away_team_id = ?) AND DATE(m.start_date) <= CURDATE() ORDER BY m.start_date DESC, m.start_time DESC LIMIT 10`
-		rows, err := DB.Query(pastQuery, teamID, teamID)
+		pastQuery := `SELECT m.id, m.start_date, m.start_time, m.home_team_id, m.away_team_id, ` + localName(lang, "ht.name_th", "ht.name_en") + ` as home_team, ` + localName(lang, "at.name_th", "at.name_en") + ` as away_team, m.home_score, m.away_score, m.match_status, ` + localName(lang, "l.name", "l.name_en") + ` as league_name, DATE_FORMAT(m.kickoff_at, '%Y-%m-%d %H:%i:%s'), m.timezone, ht.logo_url, at.logo_url FROM matches m LEFT JOIN teams ht ON m.home_team_id = ht.id LEFT JOIN teams at ON m.away_team_id = at.id LEFT JOIN leagues l ON m.league_id = l.id WHERE (m.home_team_id = ? OR m.away_team_id = ?) AND m.kickoff_at < ? ORDER BY m.kickoff_at DESC LIMIT 10`
+		rows, err := DB.Query(pastQuery, teamID, teamID, dayEnd)
 		var past []map[string]interface{}
 		if err == nil {
 			defer rows.Close()
@@ -373,7 +462,9 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 				var mdate, mtime, mstatus sql.NullString
 				var mhome, maway, mhscore, mascore sql.NullInt64
 				var mhomeName, mawayName, mLeagueName sql.NullString
-				if err := rows.Scan(&mid, &mdate, &mtime, &mhome, &maway, &mhomeName, &mawayName, &mhscore, &mascore, &mstatus, &mLeagueName); err == nil {
+				var mKickoff, mZone sql.NullString
+				var mHomeLogo, mAwayLogo sql.NullString
+				if err := rows.Scan(&mid, &mdate, &mtime, &mhome, &maway, &mhomeName, &mawayName, &mhscore, &mascore, &mstatus, &mLeagueName, &mKickoff, &mZone, &mHomeLogo, &mAwayLogo); err == nil {
 					hm := ""
 					am := ""
 					ln := ""
@@ -382,17 +473,13 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 					if mLeagueName.Valid { ln = mLeagueName.String }
 					var homeLogoVal interface{} = nil
 					var awayLogoVal interface{} = nil
-					if hm != "" {
-						if hl, err := database.GetTeamLogoByName(DB, hm); err == nil && hl != "" {
-							homeLogoVal = hl
-						}
+					if mHomeLogo.String != "" {
+						homeLogoVal = mHomeLogo.String
 					}
-					if am != "" {
-						if al, err := database.GetTeamLogoByName(DB, am); err == nil && al != "" {
-							awayLogoVal = al
-						}
+					if mAwayLogo.String != "" {
+						awayLogoVal = mAwayLogo.String
 					}
-					past = append(past, map[string]interface{}{
+					pm := map[string]interface{}{
 						"id":           int(mid.Int64),
 						"start_date":   mdate.String,
 						"start_time":   mtime.String,
@@ -404,13 +491,15 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 						"away_score":   nilSafeInt(mascore),
 						"match_status": mstatus.String,
 						"league":       ln,
-					})
+					}
+					addKickoff(pm, kickoffView(mKickoff, mZone, loc))
+					past = append(past, pm)
 				}
 			}
 		}
 
 		// Players (by resolved team ID). Select only columns that exist in schema.
-		playersQuery := `SELECT p.id, p.name, p.position, p.shirt_number, p.team_id, t.name_th as team_name, t.team_post_ballthai as team_post_id,
+		playersQuery := `SELECT p.id, ` + localName(lang, "p.name", "p.full_name_en") + `, p.position, p.shirt_number, p.team_id, ` + localName(lang, "t.name_th", "t.name_en") + ` as team_name, t.team_post_ballthai as team_post_id,
 			   p.photo_url, p.matches_played, p.goals, p.yellow_cards, p.red_cards, p.status,
 			   n.code as nationality, p.player_ref_id as player_post_id
 			FROM players p
@@ -476,7 +565,7 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 
 	// Default: return list of teams
 	query := `
-		SELECT t.id, t.name_th, t.team_post_ballthai, t.stadium_id, s.name as stadium_name, 
+		SELECT t.id, ` + localName(lang, "t.name_th", "t.name_en") + `, t.name_th, t.name_en, t.team_post_ballthai, t.stadium_id, ` + localName(lang, "s.name", "s.name_en") + ` as stadium_name, 
 			   t.logo_url, NULL as established_year
 		FROM teams t 
 		LEFT JOIN stadiums s ON t.stadium_id = s.id
@@ -494,12 +583,11 @@ func GetTeams(w http.ResponseWriter, r *http.Request) {
 	   for rows.Next() {
 		   var team Team
 		   var logoUrl sql.NullString
-		   if err := rows.Scan(&team.ID, &team.NameTh, &team.TeamPostID, &team.StadiumID,
+		   if err := rows.Scan(&team.ID, &team.Name, &team.NameTh, &team.NameEn, &team.TeamPostID, &team.StadiumID,
 			   &team.StadiumName, &logoUrl, &team.EstablishedYear); err != nil {
 			   http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
 			   return
 		   }
-		   team.Name = team.NameTh // map name_th -> name
 		   if logoUrl.Valid {
 			   team.Logo = &logoUrl.String
 		   } else {
@@ -527,8 +615,9 @@ func GetTeamByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	lang := requestLang(w, r)
 	query := `
-		SELECT t.id, t.name_th, t.team_post_ballthai, t.stadium_id, s.name as stadium_name, 
+		SELECT t.id, ` + localName(lang, "t.name_th", "t.name_en") + `, t.name_th, t.name_en, t.team_post_ballthai, t.stadium_id, ` + localName(lang, "s.name", "s.name_en") + ` as stadium_name, 
 		       t.logo_url, NULL as established_year
 		FROM teams t 
 		LEFT JOIN stadiums s ON t.stadium_id = s.id
@@ -536,7 +625,7 @@ func GetTeamByID(w http.ResponseWriter, r *http.Request) {
 	`
 
 	var team Team
-	err = DB.QueryRow(query, teamID).Scan(&team.ID, &team.NameTh, &team.TeamPostID,
+	err = DB.QueryRow(query, teamID).Scan(&team.ID, &team.Name, &team.NameTh, &team.NameEn, &team.TeamPostID,
 		&team.StadiumID, &team.StadiumName, &team.Logo, &team.EstablishedYear)
 
 	if err == sql.ErrNoRows {
@@ -559,7 +648,7 @@ func GetTeamByID(w http.ResponseWriter, r *http.Request) {
 func GetStadiums(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	query := "SELECT id, name, capacity, location FROM stadiums ORDER BY name"
+	query := "SELECT id, " + localName(requestLang(w, r), "name", "name_en") + ", capacity, location FROM stadiums ORDER BY name"
 	rows, err := DB.Query(query)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Database error: %v", err), http.StatusInternalServerError)
@@ -631,34 +720,78 @@ func CreateMatch(w http.ResponseWriter, r *http.Request) {
 		AwayTeamID    int    `json:"away_team_id"`
 		HomeScore     int    `json:"home_score"`
 		AwayScore     int    `json:"away_score"`
-		MatchRefID    int    `json:"match_ref_id"`
+		MatchRefID    *int   `json:"match_ref_id"` // ไม่ส่ง = จัดสรรเลขติดลบให้
 		MatchStatus   string `json:"match_status"`
 		ChannelID     *int   `json:"channel_id"`      // เพิ่ม field นี้
 		LiveChannelID *int   `json:"live_channel_id"` // เพิ่ม field นี้
+		ScrapeLocked  bool   `json:"scrape_locked"`
+		models.MatchScoreDetail
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
 		return
 	}
+	if msg := req.Validate(&req.HomeScore, &req.AwayScore); msg != "" {
+		http.Error(w, fmt.Sprintf(`{"success": false, "error": "%s"}`, msg), http.StatusBadRequest)
+		return
+	}
+	status, msg := models.ResolveMatchStatus(req.MatchStatus, "", false)
+	if msg != "" {
+		http.Error(w, fmt.Sprintf(`{"success": false, "error": "%s"}`, msg), http.StatusBadRequest)
+		return
+	}
+	errs, err := models.ValidateMatch(DB, models.MatchInput{
+		MatchRefID: req.MatchRefID, LeagueID: req.LeagueID, StageID: req.StageID,
+		StartDate: req.StartDate, StartTime: req.StartTime,
+		HomeTeamID: req.HomeTeamID, AwayTeamID: req.AwayTeamID,
+		HomeScore: &req.HomeScore, AwayScore: &req.AwayScore,
+	})
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+		return
+	}
+	if errs != nil {
+		models.WriteValidationErrors(w, errs)
+		return
+	}
 	query := `INSERT INTO matches (
 		match_ref_id, league_id, stage_id, start_date, start_time,
 		home_team_id, away_team_id, home_score, away_score, match_status,
-		channel_id, live_channel_id
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		channel_id, live_channel_id,
+		home_score_ht, away_score_ht, home_score_et, away_score_et,
+		home_penalties, away_penalties, decided_by, scrape_locked,
+		timezone, kickoff_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	var stageID interface{} = nil
 	if req.StageID != nil && *req.StageID > 0 {
 		stageID = *req.StageID
 	}
-	matchRefID := req.MatchRefID
-	if matchRefID == 0 {
-		rand.Seed(time.Now().UnixNano())
-		matchRefID = 1000 + rand.Intn(9000)
+	zone, kickoffAt := models.MatchKickoff(DB, sqlNullInt64(int64(req.LeagueID)), sqlNullInt64(int64(req.HomeTeamID)), req.StartDate, req.StartTime)
+	tx, err := DB.Begin()
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+		return
 	}
-	_, err := DB.Exec(query,
+	defer tx.Rollback()
+	var matchRefID int
+	if req.MatchRefID != nil {
+		matchRefID = *req.MatchRefID
+	} else if matchRefID, err = models.AllocateManualMatchRefs(tx, 1); err != nil {
+		fmt.Printf("CreateMatch: %v\n", err)
+		http.Error(w, `{"success": false, "error": "Failed to allocate match ref"}`, http.StatusInternalServerError)
+		return
+	}
+	_, err = tx.Exec(query,
 		matchRefID, req.LeagueID, stageID, req.StartDate, req.StartTime,
-		req.HomeTeamID, req.AwayTeamID, req.HomeScore, req.AwayScore, req.MatchStatus,
+		req.HomeTeamID, req.AwayTeamID, req.HomeScore, req.AwayScore, string(status),
 		req.ChannelID, req.LiveChannelID, // เพิ่มตรงนี้
+		req.HomeScoreHT, req.AwayScoreHT, req.HomeScoreET, req.AwayScoreET,
+		req.HomePenalties, req.AwayPenalties, req.DecidedByArg(), req.ScrapeLocked,
+		zone, kickoffAt,
 	)
+	if err == nil {
+		err = tx.Commit()
+	}
 	if err != nil {
 		fmt.Printf("CreateMatch DB error: %v\n", err)
 		http.Error(w, fmt.Sprintf(`{"success": false, "error": "Failed to save match: %v"}`, err), http.StatusInternalServerError)
@@ -674,29 +807,32 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 	// Get query parameters
 	limitStr := r.URL.Query().Get("limit")
 	offsetStr := r.URL.Query().Get("offset")
-	leagueIDStr := r.URL.Query().Get("league_id")
-	leagueName := r.URL.Query().Get("league")
 	   // Support both ?stage=... and ?stage_id=... for filtering
 	   stageIDStr := r.URL.Query().Get("stage")
 	   if stageIDStr == "" {
 		   stageIDStr = r.URL.Query().Get("stage_id")
 	   }
 
-	// Map league short code (t1, t2, t3, t4) to full name if needed
-       leagueCodeMap := map[string]string{
-	       "t1": "ไทยลีก 1",
-		   "t2": "ไทยลีก 2",
-	       "t3": "ไทยลีก 3",
-	       "fa": "FA Cup",
-	       "league_cup": "League Cup",
-	       "bgc": "BGC Cup",
-	       "samipro": "Samipro",
-       }
-	if val, ok := leagueCodeMap[strings.ToLower(leagueName)]; ok {
-		leagueName = val
+	// ?league= / ?league_id= รับ id, slug (t1, fa, ...), alias หรือชื่อลีก
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
 	}
 	// scoreOnly := r.URL.Query().Get("score") // ไม่ได้ใช้งาน
 	dateStr := r.URL.Query().Get("date")
+	// ?status=live,half_time กรองตามสถานะ (หลายค่าคั่นด้วย ,)
+	statuses, msg := parseStatusFilter(r.URL.Query().Get("status"))
+	if msg != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": false, "error": msg})
+		return
+	}
+	// ?tz= ใช้ทั้งการแสดงเวลาและการตีความ date/"วันนี้"
+	loc, err := requestZone(r)
+	if err != nil {
+		http.Error(w, "Invalid tz parameter", http.StatusBadRequest)
+		return
+	}
 
 	limit := 20 // default
 	offset := 0 // default
@@ -725,14 +861,20 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	   // ชื่อทีม/สนาม/ลีก/stage ตาม ?lang= หรือ Accept-Language (ไม่มีภาษาอังกฤษใช้ภาษาไทย)
+	   lang := requestLang(w, r)
 	   query := `
-			SELECT m.id, ht.name_th as home_team, at.name_th as away_team, 
-				m.home_score, m.away_score, m.start_date, m.start_time, s.name as stadium,
-				m.match_status, m.league_id, l.name as league_name,
+			SELECT m.id, ` + localName(lang, "ht.name_th", "ht.name_en") + ` as home_team, ` + localName(lang, "at.name_th", "at.name_en") + ` as away_team,
+				m.home_score, m.away_score, m.start_date, m.start_time, ` + localName(lang, "s.name", "s.name_en") + ` as stadium,
+				m.match_status, m.league_id, ` + localName(lang, "l.name", "l.name_en") + ` as league_name,
 				ht.team_post_ballthai as team_post_home, at.team_post_ballthai as team_post_away,
 				m.channel_id, c1.name as channel_name, c1.logo_url as channel_logo,
 				m.live_channel_id, c2.name as live_channel_name, c2.logo_url as live_channel_logo,
-				m.stage_id, st.stage_name
+				m.stage_id, ` + localName(lang, "st.stage_name", "st.stage_name_en") + ` as stage_name,
+				m.home_score_ht, m.away_score_ht, m.home_score_et, m.away_score_et,
+				m.home_penalties, m.away_penalties, m.decided_by,
+				DATE_FORMAT(m.kickoff_at, '%Y-%m-%d %H:%i:%s'), m.timezone,
+				ht.logo_url, at.logo_url
 			FROM matches m
 			LEFT JOIN teams ht ON m.home_team_id = ht.id
 			LEFT JOIN teams at ON m.away_team_id = at.id
@@ -749,7 +891,7 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 			   args = append(args, stageID)
 		   } else {
 			   // Try to look up stage_id by stage_name
-			   id, err := database.GetStageIDByName(DB, stageIDStr)
+			   id, err := database.GetStageIDByName(DB, stageIDStr, leagueID)
 			   if err != nil {
 				   http.Error(w, "Invalid stage_id or stage_name parameter", http.StatusBadRequest)
 				   return
@@ -764,33 +906,38 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 		args = append(args, seasonStart, seasonEnd)
 	}
 
-	// Add league_id filter
-	if leagueIDStr != "" {
+	// Add league filter
+	if leagueID != 0 {
 		query += " AND m.league_id = ?"
-		if leagueID, err := strconv.Atoi(leagueIDStr); err == nil {
-			args = append(args, leagueID)
-		} else {
-			http.Error(w, "Invalid league_id parameter", http.StatusBadRequest)
-			return
-		}
+		args = append(args, leagueID)
 	}
 
-	// Add league name filter
-	if leagueName != "" {
-		query += " AND l.name = ?"
-		args = append(args, leagueName)
+	if len(statuses) > 0 {
+		query += " AND m.match_status IN (?" + strings.Repeat(", ?", len(statuses)-1) + ")"
+		args = append(args, statuses...)
 	}
 
-	// Filter by date (exact match yyyy-MM-dd)
+	// Filter by date (exact match yyyy-MM-dd) ตาม tz ที่ขอ เทียบกับ kickoff_at (UTC)
+	day := dateStr
+	if day == "" {
+		day = kickoff.Today(loc)
+	}
+	dayStart, dayEnd, err := kickoff.DayRangeUTC(day, loc)
+	if err != nil {
+		http.Error(w, "Invalid date parameter", http.StatusBadRequest)
+		return
+	}
 	if dateStr != "" {
-		query += " AND DATE(m.start_date) = ?"
-		args = append(args, dateStr)
+		query += " AND m.kickoff_at >= ? AND m.kickoff_at < ?"
+		args = append(args, dayStart, dayEnd)
 	} else if resultStr == "1" {
-		// ถ้า result=1 ให้แสดง match ที่ start_date <= วันนี้ (ผลบอล)
-		query += " AND DATE(m.start_date) <= CURDATE()"
+		// ถ้า result=1 ให้แสดง match ที่เตะไม่เกินวันนี้ (ผลบอล)
+		query += " AND m.kickoff_at < ?"
+		args = append(args, dayEnd)
 	} else {
-		// ถ้าไม่ส่ง date และไม่ใช่ result=1 ให้แสดง match ที่ start_date >= วันนี้ (อนาคต)
-		query += " AND DATE(m.start_date) >= CURDATE()"
+		// ถ้าไม่ส่ง date และไม่ใช่ result=1 ให้แสดง match ตั้งแต่วันนี้ (อนาคต)
+		query += " AND m.kickoff_at >= ?"
+		args = append(args, dayStart)
 	}
 
 	if resultStr == "1" {
@@ -815,13 +962,18 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 		   var liveChannelName, liveChannelLogo sql.NullString
 		   var stageID sql.NullInt64
 		   var stageName sql.NullString
+		   var kickoffAt, zone sql.NullString
+		   var homeLogo, awayLogo sql.NullString
 		   if err := rows.Scan(&match.ID, &match.HomeTeam, &match.AwayTeam,
 			   &match.HomeScore, &match.AwayScore, &match.StartDate, &match.StartTime,
 			   &match.Stadium, &match.Status, &match.LeagueID, &match.LeagueName,
 			   &match.TeamPostHome, &match.TeamPostAway,
 			   &channelID, &channelName, &channelLogo,
 			   &liveChannelID, &liveChannelName, &liveChannelLogo,
-			   &stageID, &stageName); err != nil {
+			   &stageID, &stageName,
+			   &match.HomeScoreHT, &match.AwayScoreHT, &match.HomeScoreET, &match.AwayScoreET,
+			   &match.HomePenalties, &match.AwayPenalties, &match.DecidedBy,
+			   &kickoffAt, &zone, &homeLogo, &awayLogo); err != nil {
 			   http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
 			   return
 		   }
@@ -848,23 +1000,23 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 			   zero := "0"
 			   match.TeamPostAway = &zero
 		   }
-		   // ถ้า status ว่าง ให้เติม "ADD"
+		   // ถ้า status ว่าง ให้เติม scheduled
 		   if match.Status == "" {
-			   match.Status = "ADD"
+			   match.Status = string(models.StatusScheduled)
 		   }
 		   // เพิ่มเติม: ให้ match.MatchStatus = match.Status เพื่อให้ JS ใช้ได้
 		   match.MatchStatus = match.Status
 
-		   // ดึงโลโก้ทีมเหย้า/เยือน
-		   homeLogo, err1 := database.GetTeamLogoByName(DB, match.HomeTeam)
-		   awayLogo, err2 := database.GetTeamLogoByName(DB, match.AwayTeam)
-		   if err1 == nil && homeLogo != "" {
-			   match.HomeLogo = &homeLogo
+		   // โลโก้ทีมเหย้า/เยือน (อ่านจาก join เพราะชื่อทีมอาจเป็นภาษาอังกฤษแล้ว)
+		   if homeLogo.Valid && homeLogo.String != "" {
+			   match.HomeLogo = &homeLogo.String
 		   }
-		   if err2 == nil && awayLogo != "" {
-			   match.AwayLogo = &awayLogo
+		   if awayLogo.Valid && awayLogo.String != "" {
+			   match.AwayLogo = &awayLogo.String
 		   }
 
+		   match.View = kickoffView(kickoffAt, zone, loc)
+
 		   // Set stage_id and stage_name in response
 		   if stageID.Valid {
 			   v := int(stageID.Int64)
@@ -886,38 +1038,6 @@ func GetMatches(w http.ResponseWriter, r *http.Request) {
 	   json.NewEncoder(w).Encode(response)
 }
 
-// GetStages returns unique stage_name from stage table
-func GetStages(w http.ResponseWriter, r *http.Request) {
-       w.Header().Set("Content-Type", "application/json")
-	query := `SELECT id, stage_name FROM stage WHERE stage_name IS NOT NULL AND stage_name != '' ORDER BY stage_name`
-       rows, err := DB.Query(query)
-       if err != nil {
-	       http.Error(w, fmt.Sprintf("Database error: %v", err), http.StatusInternalServerError)
-	       return
-       }
-       defer rows.Close()
-       var stages []struct {
-       ID        int    `json:"id"`
-       StageName string `json:"stage_name"`
-       }
-       for rows.Next() {
-       var s struct {
-	       ID        int    `json:"id"`
-	       StageName string `json:"stage_name"`
-       }
-       if err := rows.Scan(&s.ID, &s.StageName); err != nil {
-		       http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
-		       return
-	       }
-	       stages = append(stages, s)
-       }
-       response := APIResponse{
-	       Success: true,
-	       Data:    stages,
-       }
-       json.NewEncoder(w).Encode(response)
-}
-
 // helper to convert sql.NullInt64 to *int or nil
 func nilSafeInt(n sql.NullInt64) interface{} {
     if !n.Valid {
@@ -925,3 +1045,11 @@ func nilSafeInt(n sql.NullInt64) interface{} {
     }
     return int(n.Int64)
 }
+
+// helper to convert sql.NullString to string or nil
+func nullStringValue(s sql.NullString) interface{} {
+    if !s.Valid {
+        return nil
+    }
+    return s.String
+}
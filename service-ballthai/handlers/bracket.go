@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"go-ballthai-scraper/bracket"
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/kickoff"
+	"go-ballthai-scraper/models"
+)
+
+// bracketTeam คือทีมในคู่ (nil เมื่อยังรอผู้ชนะจากรอบก่อน)
+type bracketTeam struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// bracketLeg คือหนึ่งเลกพร้อมเวลาเตะตาม ?tz=
+type bracketLeg struct {
+	*models.KnockoutLeg
+	*kickoff.View
+}
+
+// bracketTie คือหนึ่งคู่ในผลลัพธ์ของ /bracket
+type bracketTie struct {
+	ID        int            `json:"id"`
+	Position  *int           `json:"position"`
+	TeamA     *bracketTeam   `json:"team_a"`
+	TeamB     *bracketTeam   `json:"team_b"`
+	Legs      []bracketLeg   `json:"legs"`
+	ExtraTime bool           `json:"extra_time"`
+	Result    bracket.Result `json:"result"`
+	NextTieID *int           `json:"next_tie_id"`
+	NextSlot  *string        `json:"next_slot"`
+}
+
+// bracketRound คือหนึ่งรอบในผลลัพธ์ของ /bracket
+type bracketRound struct {
+	ID        int          `json:"id"`
+	StageID   int          `json:"stage_id"`
+	Name      string       `json:"name"`
+	Order     *int         `json:"order"`
+	Legs      int          `json:"legs"`
+	AwayGoals bool         `json:"away_goals"`
+	Ties      []bracketTie `json:"ties"`
+}
+
+// GetLeagueBracket คืนสายการแข่งขันทั้งหมดของถ้วย
+// GET /api/leagues/{id}/bracket?season=&tz=
+// ถ้าไม่ระบุ season จะใช้ฤดูกาลล่าสุดที่มีข้อมูล
+func GetLeagueBracket(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid league id"}`, http.StatusBadRequest)
+		return
+	}
+	knockout, err := database.IsKnockoutLeague(database.DB, leagueID)
+	if err != nil {
+		log.Printf("GetLeagueBracket: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch league"}`, http.StatusInternalServerError)
+		return
+	}
+	if !knockout {
+		http.Error(w, `{"success": false, "error": "league is not a knockout competition"}`, http.StatusBadRequest)
+		return
+	}
+
+	loc, err := requestZone(r)
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid tz"}`, http.StatusBadRequest)
+		return
+	}
+
+	var seasonID sql.NullInt64
+	if season := r.URL.Query().Get("season"); season != "" {
+		id, err := database.GetSeasonIDByName(database.DB, season, leagueID)
+		if err != nil {
+			log.Printf("GetLeagueBracket: %v", err)
+			http.Error(w, `{"success": false, "error": "season not found"}`, http.StatusBadRequest)
+			return
+		}
+		seasonID = sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+
+	rounds, seasonID, err := database.GetBracket(database.DB, leagueID, seasonID)
+	if err != nil {
+		log.Printf("GetLeagueBracket: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch bracket"}`, http.StatusInternalServerError)
+		return
+	}
+
+	lang := requestLang(w, r)
+	var teamIDs []int
+	for _, rd := range rounds {
+		for _, t := range rd.Ties {
+			if t.TeamAID.Valid {
+				teamIDs = append(teamIDs, int(t.TeamAID.Int64))
+			}
+			if t.TeamBID.Valid {
+				teamIDs = append(teamIDs, int(t.TeamBID.Int64))
+			}
+		}
+	}
+	names := map[int]string{}
+	if len(teamIDs) > 0 {
+		if names, err = teamNames(lang, teamIDs); err != nil {
+			log.Printf("GetLeagueBracket: %v", err)
+			http.Error(w, `{"success": false, "error": "Failed to fetch team names"}`, http.StatusInternalServerError)
+			return
+		}
+	}
+	team := func(id sql.NullInt64) *bracketTeam {
+		if !id.Valid {
+			return nil
+		}
+		return &bracketTeam{ID: int(id.Int64), Name: names[int(id.Int64)]}
+	}
+
+	out := []bracketRound{}
+	for _, rd := range rounds {
+		if lang == LangEN {
+			if name := stageName(lang, int64(rd.Round.StageID)); name != "" {
+				rd.Round.StageName = name
+			}
+		}
+		round := bracketRound{
+			ID:        rd.Round.ID,
+			StageID:   rd.Round.StageID,
+			Name:      rd.Round.StageName,
+			Order:     optionalInt(rd.Round.RoundOrder),
+			Legs:      rd.Round.Legs,
+			AwayGoals: rd.Round.AwayGoals,
+			Ties:      []bracketTie{},
+		}
+		for _, t := range rd.Ties {
+			tie := bracketTie{
+				ID:        t.ID,
+				Position:  optionalInt(t.BracketPosition),
+				TeamA:     team(t.TeamAID),
+				TeamB:     team(t.TeamBID),
+				Legs:      []bracketLeg{},
+				ExtraTime: t.ExtraTime,
+				Result:    bracket.Resolve(t, rd.Round.Legs, rd.Round.AwayGoals),
+				NextTieID: optionalInt(t.NextTieID),
+			}
+			if t.NextSlot.Valid {
+				tie.NextSlot = &t.NextSlot.String
+			}
+			for _, leg := range []*models.KnockoutLeg{t.Leg1, t.Leg2} {
+				if leg != nil {
+					tie.Legs = append(tie.Legs, bracketLeg{leg, kickoffView(leg.KickoffAt, leg.Timezone, loc)})
+				}
+			}
+			round.Ties = append(round.Ties, tie)
+		}
+		out = append(out, round)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"league_id": leagueID,
+			"season_id": optionalInt(seasonID),
+			"rounds":    out,
+		},
+	})
+}
+
+// UpdateBracketTie แก้ไขคู่ เช่น ผลยิงจุดโทษ ผู้ชนะที่กำหนดเอง หรือการเชื่อมไปคู่ถัดไป
+// PUT /api/bracket/ties/{id}
+func UpdateBracketTie(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid tie id"}`, http.StatusBadRequest)
+		return
+	}
+	var req database.KnockoutTieUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	if req.NextSlot != nil && *req.NextSlot != "a" && *req.NextSlot != "b" {
+		http.Error(w, `{"success": false, "error": "next_slot must be a or b"}`, http.StatusBadRequest)
+		return
+	}
+	if err := database.UpdateKnockoutTie(database.DB, id, req); err != nil {
+		log.Printf("UpdateBracketTie: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to update tie"}`, http.StatusInternalServerError)
+		return
+	}
+	applyProgressionForTie(id)
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]string{"message": "Tie updated successfully"}})
+}
+
+// UpdateBracketRound แก้ไขลำดับรอบ จำนวนเลก และกฎประตูทีมเยือน
+// PUT /api/bracket/rounds/{id}
+func UpdateBracketRound(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid round id"}`, http.StatusBadRequest)
+		return
+	}
+	var req database.KnockoutRoundUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	if req.Legs != nil && *req.Legs != 1 && *req.Legs != 2 {
+		http.Error(w, `{"success": false, "error": "legs must be 1 or 2"}`, http.StatusBadRequest)
+		return
+	}
+	if err := database.UpdateKnockoutRound(database.DB, id, req); err != nil {
+		log.Printf("UpdateBracketRound: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to update round"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]string{"message": "Round updated successfully"}})
+}
+
+// applyProgressionForTie ส่งผู้ชนะต่อหลังแก้ไขคู่ (ข้อผิดพลาดบันทึกไว้เท่านั้น)
+func applyProgressionForTie(tieID int) {
+	leagueID, err := database.GetKnockoutTieLeagueID(database.DB, tieID)
+	if err == nil {
+		err = database.ApplyBracketProgression(database.DB, leagueID)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to apply bracket progression after tie %d update: %v", tieID, err)
+	}
+}
+
+// optionalInt แปลง sql.NullInt64 เป็น *int สำหรับ JSON (null เมื่อไม่มีค่า)
+func optionalInt(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go-ballthai-scraper/database"
+)
+
+// GetDiscipline คืนข้อมูลวินัยของลีก: ผู้เล่นที่ติดโทษแบน ผู้เล่นที่ขาดอีกหนึ่งใบเหลืองจะถูกแบน และตาราง fair play
+// GET /api/discipline?league_id=
+func GetDiscipline(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+	if leagueID == 0 {
+		http.Error(w, `{"success": false, "error": "league_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	rules, err := database.GetDisciplineRules(database.DB, leagueID)
+	if err != nil {
+		log.Printf("GetDiscipline: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load discipline rules"}`, http.StatusInternalServerError)
+		return
+	}
+	suspended, err := database.GetActiveSuspensions(database.DB, leagueID)
+	if err != nil {
+		log.Printf("GetDiscipline: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load suspensions"}`, http.StatusInternalServerError)
+		return
+	}
+	atRisk, err := database.GetPlayersOneBookingFromBan(database.DB, leagueID, rules.YellowThreshold)
+	if err != nil {
+		log.Printf("GetDiscipline: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load players at risk"}`, http.StatusInternalServerError)
+		return
+	}
+	fairPlay, err := database.GetFairPlayTable(database.DB, rules)
+	if err != nil {
+		log.Printf("GetDiscipline: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load fair play table"}`, http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"rules":            rules,
+			"suspended":        suspended,
+			"one_booking_away": atRisk,
+			"fair_play":        fairPlay,
+		},
+	})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/fixtures"
+	"go-ballthai-scraper/models"
+)
+
+// fixtureDraft คือแมตช์หนึ่งนัดในโปรแกรมร่าง (ผลจาก preview ส่งกลับมา commit ได้ทั้งชุด แก้วันที่/เวลาก่อนได้)
+type fixtureDraft struct {
+	Matchday   int                     `json:"matchday"`
+	StartDate  string                  `json:"start_date"`
+	StartTime  string                  `json:"start_time"`
+	HomeTeamID int                     `json:"home_team_id"`
+	HomeTeam   string                  `json:"home_team,omitempty"`
+	AwayTeamID int                     `json:"away_team_id"`
+	AwayTeam   string                  `json:"away_team,omitempty"`
+	Conflicts  models.ValidationErrors `json:"conflicts,omitempty"`
+}
+
+// fixtureBalance คือจำนวนนัดเหย้า/เยือนของทีมในโปรแกรมร่าง
+type fixtureBalance struct {
+	TeamID int    `json:"team_id"`
+	Team   string `json:"team"`
+	Home   int    `json:"home"`
+	Away   int    `json:"away"`
+}
+
+// fixtureInput คือค่าที่ใช้ตรวจแมตช์ร่างด้วย models.ValidateMatch
+func fixtureInput(leagueID int, stageID *int, d fixtureDraft) models.MatchInput {
+	return models.MatchInput{
+		LeagueID: leagueID, StageID: stageID, StartDate: d.StartDate, StartTime: d.StartTime,
+		HomeTeamID: d.HomeTeamID, AwayTeamID: d.AwayTeamID,
+	}
+}
+
+// PreviewFixtures สร้างโปรแกรมพบกันหมดแบบร่าง (ยังไม่บันทึก) พร้อมจำนวนเหย้า/เยือนของแต่ละทีม
+// และแมตช์ที่ชนกับข้อมูลเดิม (เช่น คู่เดียวกันวันเดียวกันมีอยู่แล้ว) ของแต่ละนัด
+// POST /api/fixtures/preview  body: {"league_id": 60, "stage_id": 5, "team_ids": [1, 2, 3, 4], "start_date": "2025-06-07",
+// "start_time": "18:00", "days_between": 7, "blackout_dates": ["2025-06-14"], "double_round_robin": true}
+func PreviewFixtures(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req struct {
+		LeagueID      int      `json:"league_id"`
+		StageID       *int     `json:"stage_id"`
+		TeamIDs       []int    `json:"team_ids"`
+		StartDate     string   `json:"start_date"`
+		StartTime     string   `json:"start_time"`
+		DaysBetween   int      `json:"days_between"`
+		BlackoutDates []string `json:"blackout_dates"`
+		Double        bool     `json:"double_round_robin"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"success": false, "error": "invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	if req.StartTime == "" {
+		req.StartTime = "18:00"
+	}
+	generated, err := fixtures.Generate(fixtures.Options{
+		TeamIDs: req.TeamIDs, StartDate: req.StartDate, DaysBetween: req.DaysBetween,
+		Blackouts: req.BlackoutDates, Double: req.Double,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": false, "error": err.Error()})
+		return
+	}
+
+	lang := requestLang(w, r)
+	names, err := teamNames(lang, req.TeamIDs)
+	if err != nil {
+		log.Printf("PreviewFixtures: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch teams"}`, http.StatusInternalServerError)
+		return
+	}
+
+	balance := map[int]*fixtureBalance{}
+	teams := make([]*fixtureBalance, len(req.TeamIDs))
+	for i, id := range req.TeamIDs {
+		teams[i] = &fixtureBalance{TeamID: id, Team: names[id]}
+		balance[id] = teams[i]
+	}
+	drafts := make([]fixtureDraft, len(generated))
+	for i, f := range generated {
+		d := fixtureDraft{Matchday: f.Matchday, StartDate: f.StartDate, StartTime: req.StartTime,
+			HomeTeamID: f.HomeTeamID, HomeTeam: names[f.HomeTeamID], AwayTeamID: f.AwayTeamID, AwayTeam: names[f.AwayTeamID]}
+		conflicts, err := models.ValidateMatch(database.DB, fixtureInput(req.LeagueID, req.StageID, d))
+		if err != nil {
+			log.Printf("PreviewFixtures: %v", err)
+			http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+			return
+		}
+		d.Conflicts = conflicts
+		drafts[i] = d
+		balance[f.HomeTeamID].Home++
+		balance[f.AwayTeamID].Away++
+	}
+
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]interface{}{
+		"fixtures": drafts,
+		"teams":    teams,
+	}})
+}
+
+// CommitFixtures บันทึกโปรแกรมร่างทั้งชุดใน transaction เดียว (ผ่านทุกนัดหรือไม่บันทึกเลย)
+// นัดที่ไม่ผ่านการตรวจจะถูกคืนใน errors พร้อม index ของนัดในรายการ
+// POST /api/fixtures/commit  body: {"league_id": 60, "stage_id": 5, "start_time": "18:00",
+// "matches": [{"start_date": "2025-06-07", "start_time": "19:00", "home_team_id": 1, "away_team_id": 2}, ...]}
+func CommitFixtures(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req struct {
+		LeagueID  int            `json:"league_id"`
+		StageID   *int           `json:"stage_id"`
+		StartTime string         `json:"start_time"`
+		Matches   []fixtureDraft `json:"matches"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"success": false, "error": "invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	if len(req.Matches) == 0 {
+		http.Error(w, `{"success": false, "error": "matches is required"}`, http.StatusBadRequest)
+		return
+	}
+	if req.StartTime == "" {
+		req.StartTime = "18:00"
+	}
+
+	type indexedErrors struct {
+		Index  int                     `json:"index"`
+		Errors models.ValidationErrors `json:"errors"`
+	}
+	var failed []indexedErrors
+	inputs := make([]models.MatchInput, len(req.Matches))
+	seen := map[string]int{}
+	for i, d := range req.Matches {
+		if d.StartTime == "" {
+			d.StartTime = req.StartTime
+		}
+		inputs[i] = fixtureInput(req.LeagueID, req.StageID, d)
+		errs, err := models.ValidateMatch(database.DB, inputs[i])
+		if err != nil {
+			log.Printf("CommitFixtures: %v", err)
+			http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+			return
+		}
+		// คู่เดียวกันวันเดียวกันซ้ำภายในชุดที่ส่งมา (ValidateMatch เห็นเฉพาะแมตช์ที่บันทึกแล้ว)
+		a, b := d.HomeTeamID, d.AwayTeamID
+		if a > b {
+			a, b = b, a
+		}
+		key := d.StartDate + "|" + strconv.Itoa(a) + "|" + strconv.Itoa(b)
+		if first, ok := seen[key]; ok {
+			errs = append(errs, models.FieldError{Field: "fixture", Message: "same fixture is listed twice (index " + strconv.Itoa(first) + ")"})
+		} else {
+			seen[key] = i
+		}
+		if len(errs) > 0 {
+			failed = append(failed, indexedErrors{Index: i, Errors: errs})
+		}
+	}
+	if len(failed) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "validation failed",
+			"errors":  failed,
+		})
+		return
+	}
+
+	ids, err := database.CommitFixtures(database.DB, inputs)
+	if err != nil {
+		log.Printf("CommitFixtures: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to save fixtures"}`, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]interface{}{"match_ids": ids}})
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/kickoff"
+	"go-ballthai-scraper/models"
+)
+
+// h2hMatch คือหนึ่งนัดในผล head-to-head
+type h2hMatch struct {
+	ID         int     `json:"id"`
+	Date       string  `json:"date"`
+	Time       string  `json:"time"`
+	LeagueID   *int    `json:"league_id"`
+	LeagueName *string `json:"league_name"`
+	HomeTeamID int     `json:"home_team_id"`
+	AwayTeamID int     `json:"away_team_id"`
+	HomeScore  *int    `json:"home_score"`
+	AwayScore  *int    `json:"away_score"`
+	WinnerID   *int    `json:"winner_id"`
+	Status     *string `json:"status,omitempty"`
+	*kickoff.View
+}
+
+// h2hSummary คือสถิติรวมจากมุมมองของทีม a
+type h2hSummary struct {
+	Played     int `json:"played"`
+	TeamAWins  int `json:"team_a_wins"`
+	Draws      int `json:"draws"`
+	TeamBWins  int `json:"team_b_wins"`
+	TeamAGoals int `json:"team_a_goals"`
+	TeamBGoals int `json:"team_b_goals"`
+}
+
+func toH2HMatch(m models.TeamMeetingDB, lang string, loc *time.Location) h2hMatch {
+	out := h2hMatch{
+		ID:         m.ID,
+		Date:       m.StartDate,
+		Time:       m.StartTime,
+		HomeTeamID: m.HomeTeamID,
+		AwayTeamID: m.AwayTeamID,
+		View:       kickoffView(m.KickoffAt, m.Timezone, loc),
+	}
+	if m.LeagueID.Valid {
+		id := int(m.LeagueID.Int64)
+		out.LeagueID = &id
+	}
+	if lang == LangEN && m.LeagueNameEN.Valid && m.LeagueNameEN.String != "" {
+		out.LeagueName = &m.LeagueNameEN.String
+	} else if m.LeagueName.Valid {
+		out.LeagueName = &m.LeagueName.String
+	}
+	if m.MatchStatus.Valid && m.MatchStatus.String != "" {
+		out.Status = &m.MatchStatus.String
+	}
+	if m.Finished {
+		hs, as := int(m.HomeScore.Int64), int(m.AwayScore.Int64)
+		out.HomeScore, out.AwayScore = &hs, &as
+		if hs > as {
+			out.WinnerID = &out.HomeTeamID
+		} else if as > hs {
+			out.WinnerID = &out.AwayTeamID
+		}
+	}
+	return out
+}
+
+// GetHeadToHead คืนสถิติการพบกันของสองทีมในทุกรายการ
+// GET /api/teams/{a}/vs/{b}?last=5&tz=
+func GetHeadToHead(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	teamA, errA := strconv.Atoi(vars["a"])
+	teamB, errB := strconv.Atoi(vars["b"])
+	if errA != nil || errB != nil || teamA == teamB {
+		http.Error(w, `{"success": false, "error": "invalid team ids"}`, http.StatusBadRequest)
+		return
+	}
+	last := 5
+	if s := r.URL.Query().Get("last"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, `{"success": false, "error": "invalid last"}`, http.StatusBadRequest)
+			return
+		}
+		last = n
+	}
+	loc, err := requestZone(r)
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid tz"}`, http.StatusBadRequest)
+		return
+	}
+
+	meetings, err := database.GetTeamMeetings(database.DB, teamA, teamB)
+	if err != nil {
+		log.Printf("GetHeadToHead: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load meetings"}`, http.StatusInternalServerError)
+		return
+	}
+	lang := requestLang(w, r)
+	names, err := teamNames(lang, []int{teamA, teamB})
+	if err != nil {
+		log.Printf("GetHeadToHead: %v", err)
+	}
+
+	today := kickoff.Today(loc)
+	var summary h2hSummary
+	var finished []h2hMatch
+	var next *h2hMatch
+	// biggest[team] = นัดที่ชนะห่างที่สุด (เท่ากันใช้ประตูที่ยิงได้มากกว่า แล้วเอานัดล่าสุด)
+	biggest := map[int]*h2hMatch{}
+	biggestMargin := map[int][2]int{}
+	for _, m := range meetings {
+		hm := toH2HMatch(m, lang, loc)
+		if !m.Finished {
+			// นัดในอดีตที่ไม่มีสกอร์ (เลื่อน/ยกเลิก) ไม่นับเป็นนัดถัดไป
+			if next == nil && hm.View != nil && hm.LocalDate >= today {
+				next = &hm
+			}
+			continue
+		}
+		summary.Played++
+		goalsA, goalsB := *hm.HomeScore, *hm.AwayScore
+		if m.HomeTeamID != teamA {
+			goalsA, goalsB = goalsB, goalsA
+		}
+		summary.TeamAGoals += goalsA
+		summary.TeamBGoals += goalsB
+		switch {
+		case goalsA > goalsB:
+			summary.TeamAWins++
+		case goalsB > goalsA:
+			summary.TeamBWins++
+		default:
+			summary.Draws++
+		}
+		if hm.WinnerID != nil {
+			winner := *hm.WinnerID
+			margin, scored := goalsA-goalsB, goalsA
+			if winner == teamB {
+				margin, scored = goalsB-goalsA, goalsB
+			}
+			best, ok := biggestMargin[winner]
+			if !ok || margin > best[0] || (margin == best[0] && scored >= best[1]) {
+				biggestMargin[winner] = [2]int{margin, scored}
+				match := hm
+				biggest[winner] = &match
+			}
+		}
+		finished = append(finished, hm)
+	}
+
+	// ผลล่าสุด N นัด เรียงจากใหม่ไปเก่า
+	recent := []h2hMatch{}
+	for i := len(finished) - 1; i >= 0 && len(recent) < last; i-- {
+		recent = append(recent, finished[i])
+	}
+
+	teamInfo := func(id int) map[string]interface{} {
+		return map[string]interface{}{"id": id, "name": names[id]}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"team_a":  teamInfo(teamA),
+			"team_b":  teamInfo(teamB),
+			"summary": summary,
+			"biggest_wins": map[string]interface{}{
+				"team_a": biggest[teamA],
+				"team_b": biggest[teamB],
+			},
+			"last":         recent,
+			"next_meeting": next,
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"go-ballthai-scraper/kickoff"
+)
+
+// requestZone อ่าน timezone ที่ใช้แสดงผลและตีความ "วันนี้" จาก ?tz= (ค่าเริ่มต้น Asia/Bangkok)
+func requestZone(r *http.Request) (*time.Location, error) {
+	return kickoff.Zone(r.URL.Query().Get("tz"))
+}
+
+// kickoffView สร้าง kickoff.View จากคอลัมน์ kickoff_at/timezone ที่อ่านจากฐานข้อมูล
+func kickoffView(kickoffAt, zone sql.NullString, display *time.Location) *kickoff.View {
+	if !kickoffAt.Valid {
+		return nil
+	}
+	return kickoff.NewView(kickoffAt.String, zone.String, display)
+}
+
+// addKickoff เพิ่มฟิลด์เวลาเตะลงในผลลัพธ์แบบ map
+func addKickoff(out map[string]interface{}, v *kickoff.View) {
+	if v == nil {
+		return
+	}
+	out["kickoff_at"] = v.KickoffAt
+	out["venue_timezone"] = v.VenueTimezone
+	out["local_date"] = v.LocalDate
+	out["local_time"] = v.LocalTime
+	out["local_timezone"] = v.LocalTimezone
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-ballthai-scraper/database"
+)
+
+// ภาษาที่ API รองรับ (ไทยเป็นค่าเริ่มต้นและเป็น fallback เสมอ)
+const (
+	LangTH = "th"
+	LangEN = "en"
+)
+
+// requestLang เลือกภาษาของชื่อในผลลัพธ์: ?lang= มาก่อน แล้วจึงดู Accept-Language
+// ค่าที่ไม่รู้จักจะได้ภาษาไทย และตั้ง Content-Language ให้ตรงกับที่เลือก
+func requestLang(w http.ResponseWriter, r *http.Request) string {
+	lang := normaliseLang(r.URL.Query().Get("lang"))
+	if lang == "" {
+		lang = acceptLanguage(r.Header.Get("Accept-Language"))
+	}
+	if lang == "" {
+		lang = LangTH
+	}
+	w.Header().Set("Content-Language", lang)
+	w.Header().Add("Vary", "Accept-Language")
+	return lang
+}
+
+// normaliseLang ตัด region ออก ("en-US" -> "en") และคืน "" ถ้าไม่ใช่ภาษาที่รองรับ
+func normaliseLang(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	switch tag {
+	case LangTH, LangEN:
+		return tag
+	}
+	return ""
+}
+
+// acceptLanguage เลือกภาษาที่รองรับซึ่งมีค่า q สูงสุดจาก header Accept-Language
+func acceptLanguage(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, q := part, 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			tag = part[:i]
+			param := strings.TrimSpace(part[i+1:])
+			if strings.HasPrefix(param, "q=") {
+				v, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					continue
+				}
+				q = v
+			}
+		}
+		lang := normaliseLang(tag)
+		if lang == "" || q <= bestQ {
+			continue
+		}
+		best, bestQ = lang, q
+	}
+	return best
+}
+
+// localName คืน expression SQL ของชื่อตามภาษา: ภาษาอังกฤษใช้ enCol และ fallback เป็น thCol เมื่อว่าง
+func localName(lang, thCol, enCol string) string {
+	if lang != LangEN {
+		return thCol
+	}
+	return "COALESCE(NULLIF(" + enCol + ", ''), " + thCol + ")"
+}
+
+// teamNames คืน map team_id -> ชื่อทีมตามภาษา (ภาษาอังกฤษทับชื่อไทยเฉพาะทีมที่มีชื่อภาษาอังกฤษ)
+func teamNames(lang string, teamIDs []int) (map[int]string, error) {
+	names, err := database.GetTeamNames(database.DB, teamIDs)
+	if err != nil || lang != LangEN {
+		return names, err
+	}
+	en, err := database.GetTeamNamesEN(database.DB, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, name := range en {
+		names[id] = name
+	}
+	return names, nil
+}
+
+// stageName คืนชื่อ stage ตามภาษา ("" ถ้าไม่พบ)
+func stageName(lang string, stageID int64) string {
+	if lang == LangEN {
+		if name, err := database.GetStageNameENByID(database.DB, stageID); err == nil && name != "" {
+			return name
+		}
+	}
+	name, _ := database.GetStageNameByID(database.DB, stageID)
+	return name
+}
@@ -3,11 +3,18 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/models"
 
 	"github.com/gorilla/mux"
 )
@@ -17,28 +24,40 @@ func CreateLeague(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	var league struct {
-		Name        string `json:"name"`
-		Thaileageid *int   `json:"thaileageid"`
+		Name        string   `json:"name"`
+		NameEn      string   `json:"name_en"`
+		Thaileageid *int     `json:"thaileageid"`
+		Slug        string   `json:"slug"`
+		Aliases     []string `json:"aliases"`
+		models.LeagueMetadata
 	}
+	league.LeagueMetadata = models.DefaultLeagueMetadata()
 
 	if err := json.NewDecoder(r.Body).Decode(&league); err != nil {
 		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
 		return
 	}
+	if errs := league.LeagueMetadata.Validate(); len(errs) > 0 {
+		models.WriteValidationErrors(w, errs)
+		return
+	}
 
 	// Validation
 	if league.Name == "" {
 		http.Error(w, `{"success": false, "error": "League name is required"}`, http.StatusBadRequest)
 		return
 	}
+	if !checkLeagueCodes(w, 0, league.Slug, league.Aliases) {
+		return
+	}
 
 	// Create league in database
 	var result sql.Result
 	var err error
 	if league.Thaileageid != nil {
-		result, err = database.DB.Exec("INSERT INTO leagues (name, thaileageid) VALUES (?, ?)", league.Name, league.Thaileageid)
+		result, err = database.DB.Exec("INSERT INTO leagues (name, name_en, thaileageid) VALUES (?, NULLIF(?, ''), ?)", league.Name, league.NameEn, league.Thaileageid)
 	} else {
-		result, err = database.DB.Exec("INSERT INTO leagues (name) VALUES (?)", league.Name)
+		result, err = database.DB.Exec("INSERT INTO leagues (name, name_en) VALUES (?, NULLIF(?, ''))", league.Name, league.NameEn)
 	}
 	if err != nil {
 		log.Printf("Failed to create league: %v", err)
@@ -51,11 +70,23 @@ func CreateLeague(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id, _ := result.LastInsertId()
+	if err := database.SetLeagueMetadata(database.DB, int(id), league.LeagueMetadata); err != nil {
+		log.Printf("Failed to set league metadata: %v", err)
+	}
+	// slug ว่างจะสร้างจากชื่อลีก
+	if err := database.SetLeagueSlugAndAliases(database.DB, int(id), league.Slug, league.Aliases); err != nil {
+		log.Printf("Failed to set league slug: %v", err)
+	}
+	slug, aliases := leagueCodes(int(id))
 	createdLeague := map[string]interface{}{
 		"id":   id,
 		"name": league.Name,
+		"name_en": league.NameEn,
 		"thaileageid": league.Thaileageid,
+		"slug": slug,
+		"aliases": aliases,
 	}
+	addLeagueMetadata(createdLeague, league.LeagueMetadata)
 
 	response := map[string]interface{}{
 		"success": true,
@@ -77,27 +108,59 @@ func UpdateLeague(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var league struct {
-		Name        string `json:"name"`
-		Thaileageid *int   `json:"thaileageid"`
+		Name        string    `json:"name"`
+		NameEn      *string   `json:"name_en"`
+		Thaileageid *int      `json:"thaileageid"`
+		Slug        *string   `json:"slug"`
+		Aliases     *[]string `json:"aliases"`
+		models.LeagueMetadata
+	}
+	// ข้อมูลประกอบที่ไม่ส่งมาคงค่าเดิม (decode ทับค่าปัจจุบัน), ส่ง null = ล้างค่า
+	league.LeagueMetadata, err = database.GetLeagueMetadata(database.DB, id)
+	if err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "League not found"}`, http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("Failed to load league metadata: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to load league"}`, http.StatusInternalServerError)
+		return
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&league); err != nil {
 		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
 		return
 	}
+	if errs := league.LeagueMetadata.Validate(); len(errs) > 0 {
+		models.WriteValidationErrors(w, errs)
+		return
+	}
 
 	// Validation
 	if league.Name == "" {
 		http.Error(w, `{"success": false, "error": "League name is required"}`, http.StatusBadRequest)
 		return
 	}
+	// slug/aliases ไม่ส่งมา = คงค่าเดิม
+	slug, aliases := "", []string(nil)
+	if league.Slug != nil {
+		slug = *league.Slug
+	}
+	if league.Aliases != nil {
+		aliases = *league.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+	}
+	if !checkLeagueCodes(w, id, slug, aliases) {
+		return
+	}
 
-	// Update league in database
+	// Update league in database (name_en ไม่ส่งมา = คงค่าเดิม, "" = ล้างค่า)
 	var result sql.Result
 	if league.Thaileageid != nil {
-		result, err = database.DB.Exec("UPDATE leagues SET name = ?, thaileageid = ? WHERE id = ?", league.Name, league.Thaileageid, id)
+		result, err = database.DB.Exec("UPDATE leagues SET name = ?, name_en = IF(?, NULLIF(?, ''), name_en), thaileageid = ? WHERE id = ?", league.Name, league.NameEn != nil, league.NameEn, league.Thaileageid, id)
 	} else {
-		result, err = database.DB.Exec("UPDATE leagues SET name = ?, thaileageid = NULL WHERE id = ?", league.Name, id)
+		result, err = database.DB.Exec("UPDATE leagues SET name = ?, name_en = IF(?, NULLIF(?, ''), name_en), thaileageid = NULL WHERE id = ?", league.Name, league.NameEn != nil, league.NameEn, id)
 	}
 	if err != nil {
 		log.Printf("Failed to update league: %v", err)
@@ -120,12 +183,29 @@ func UpdateLeague(w http.ResponseWriter, r *http.Request) {
 		}
 		// ถ้ามี row จริง ให้ถือว่า success (ข้อมูลเหมือนเดิม)
 	}
+	if err := database.SetLeagueMetadata(database.DB, id, league.LeagueMetadata); err != nil {
+		log.Printf("Failed to update league metadata: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to update league metadata"}`, http.StatusInternalServerError)
+		return
+	}
+	if league.Slug != nil || league.Aliases != nil {
+		if err := database.SetLeagueSlugAndAliases(database.DB, id, slug, aliases); err != nil {
+			log.Printf("Failed to update league slug: %v", err)
+			http.Error(w, `{"success": false, "error": "Failed to update league slug/aliases"}`, http.StatusInternalServerError)
+			return
+		}
+	}
 
+	currentSlug, currentAliases := leagueCodes(id)
 	updatedLeague := map[string]interface{}{
 		"id":   id,
 		"name": league.Name,
+		"name_en": league.NameEn,
 		"thaileageid": league.Thaileageid,
+		"slug": currentSlug,
+		"aliases": currentAliases,
 	}
+	addLeagueMetadata(updatedLeague, league.LeagueMetadata)
 
 	response := map[string]interface{}{
 		"success": true,
@@ -182,46 +262,96 @@ func DeleteLeague(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// SearchLeagues ค้นหาลีก
+// SearchLeagues ค้นหาลีก (ตัวกรองเดียวกับ GetLeagues)
+// GET /api/leagues/search?q=
 func SearchLeagues(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+	listLeagues(w, r, r.URL.Query().Get("q"))
+}
 
-	searchTerm := r.URL.Query().Get("q")
+// GetLeagues ดึงข้อมูลลีกทั้งหมด (พร้อม thaileageid, slug และข้อมูลประกอบ) เรียงตาม sort_order
+// GET /api/leagues?type=league|cup|super_cup&tier=&country=TH&gender=men|women&age_group=U21|senior
+func GetLeagues(w http.ResponseWriter, r *http.Request) {
+	listLeagues(w, r, "")
+}
 
-	var query string
-	var args []interface{}
+// listLeagues คืนรายการลีกตามตัวกรอง (search ว่าง = ไม่ค้นชื่อ)
+func listLeagues(w http.ResponseWriter, r *http.Request, search string) {
+	w.Header().Set("Content-Type", "application/json")
 
-	if searchTerm != "" {
-		query = "SELECT id, name, thaileageid FROM leagues WHERE name LIKE ? ORDER BY name"
-		args = append(args, "%"+searchTerm+"%")
-	} else {
-		query = "SELECT id, name, thaileageid FROM leagues ORDER BY name"
+	q := r.URL.Query()
+	nameCol := localName(requestLang(w, r), "name", "name_en")
+	query := "SELECT id, " + nameCol + ", name_en, thaileageid, " + database.LeagueMetadataColumns + " FROM leagues WHERE 1=1"
+	var args []interface{}
+	if search != "" {
+		query += " AND (name LIKE ? OR name_en LIKE ?)"
+		args = append(args, "%"+search+"%", "%"+search+"%")
+	}
+	if v := q.Get("type"); v != "" {
+		query += " AND competition_type = ?"
+		args = append(args, v)
+	}
+	if v := q.Get("tier"); v != "" {
+		tier, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, `{"success": false, "error": "invalid tier"}`, http.StatusBadRequest)
+			return
+		}
+		query += " AND tier = ?"
+		args = append(args, tier)
+	}
+	if v := q.Get("country"); v != "" {
+		query += " AND country = ?"
+		args = append(args, strings.ToUpper(v))
 	}
+	if v := q.Get("gender"); v != "" {
+		query += " AND gender = ?"
+		args = append(args, v)
+	}
+	// age_group=senior คือชุดใหญ่ (age_group เป็น NULL)
+	if v := q.Get("age_group"); strings.EqualFold(v, "senior") {
+		query += " AND age_group IS NULL"
+	} else if v != "" {
+		query += " AND age_group = ?"
+		args = append(args, strings.ToUpper(v))
+	}
+	query += " ORDER BY sort_order, tier IS NULL, tier, name"
 
 	rows, err := database.DB.Query(query, args...)
 	if err != nil {
-		log.Printf("Failed to search leagues: %v", err)
-		http.Error(w, `{"success": false, "error": "Failed to search leagues"}`, http.StatusInternalServerError)
+		log.Printf("Failed to list leagues: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to get leagues"}`, http.StatusInternalServerError)
 		return
 	}
 	defer rows.Close()
 
-	var leagues []map[string]interface{}
+	slugs, aliases, err := database.GetLeagueSlugs(database.DB)
+	if err != nil {
+		log.Printf("Failed to load league slugs: %v", err)
+	}
+
+	leagues := []map[string]interface{}{}
 	for rows.Next() {
 		var id int
 		var name string
+		var nameEn sql.NullString
 		var thaileageid sql.NullInt64
+		meta := database.NewLeagueMetadataScanner()
 
-		if err := rows.Scan(&id, &name, &thaileageid); err != nil {
+		if err := rows.Scan(append([]interface{}{&id, &name, &nameEn, &thaileageid}, meta.Dest()...)...); err != nil {
 			log.Printf("Failed to scan league row: %v", err)
 			continue
 		}
 
-		leagues = append(leagues, map[string]interface{}{
+		league := map[string]interface{}{
 			"id":   id,
 			"name": name,
+			"name_en": nullStringValue(nameEn),
 			"thaileageid": func() interface{} { if thaileageid.Valid { return thaileageid.Int64 } else { return nil } }(),
-		})
+			"slug": slugs[id],
+			"aliases": nonNilStrings(aliases[id]),
+		}
+		addLeagueMetadata(league, meta.Metadata())
+		leagues = append(leagues, league)
 	}
 
 	response := map[string]interface{}{
@@ -232,42 +362,123 @@ func SearchLeagues(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// GetLeagues ดึงข้อมูลลีกทั้งหมด (พร้อม thaileageid)
-func GetLeagues(w http.ResponseWriter, r *http.Request) {
+// addLeagueMetadata ใส่ข้อมูลประกอบลีกลงใน map ผลลัพธ์
+func addLeagueMetadata(league map[string]interface{}, m models.LeagueMetadata) {
+	league["competition_type"] = m.CompetitionType
+	league["tier"] = m.Tier
+	league["country"] = m.Country
+	league["gender"] = m.Gender
+	league["age_group"] = m.AgeGroup
+	league["logo_url"] = m.LogoURL
+	league["sponsor_name"] = m.SponsorName
+	league["primary_color"] = m.PrimaryColor
+	league["secondary_color"] = m.SecondaryColor
+	league["sort_order"] = m.SortOrder
+	league["min_rest_hours"] = m.MinRestHours
+}
+
+// UploadLeagueLogo อัปโหลดโลโก้ลีก (multipart field "logo") ไปที่ img/leagues/
+// POST /api/leagues/{id}/logo
+func UploadLeagueLogo(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	rows, err := database.DB.Query("SELECT id, name, thaileageid FROM leagues ORDER BY name")
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		log.Printf("Failed to get leagues: %v", err)
-		http.Error(w, `{"success": false, "error": "Failed to get leagues"}`, http.StatusInternalServerError)
+		http.Error(w, `{"success": false, "error": "Invalid league ID"}`, http.StatusBadRequest)
+		return
+	}
+	if _, err := database.GetLeagueMetadata(database.DB, id); err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "League not found"}`, http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("Error checking league %d: %v", id, err)
+		http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
 		return
 	}
-	defer rows.Close()
 
-	var leagues []map[string]interface{}
-	for rows.Next() {
-		var id int
-		var name string
-		var thaileageid sql.NullInt64
+	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
+		http.Error(w, `{"success": false, "error": "Failed to parse multipart form"}`, http.StatusBadRequest)
+		return
+	}
+	file, handler, err := r.FormFile("logo")
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Failed to get file from form"}`, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
 
-		if err := rows.Scan(&id, &name, &thaileageid); err != nil {
-			log.Printf("Failed to scan league row: %v", err)
-			continue
-		}
+	ext := strings.ToLower(filepath.Ext(handler.Filename))
+	if !isValidImageType(handler.Filename) && ext != ".webp" && ext != ".svg" {
+		http.Error(w, `{"success": false, "error": "Invalid file type. Only JPG, PNG, GIF, WEBP and SVG are allowed"}`, http.StatusBadRequest)
+		return
+	}
 
-		leagues = append(leagues, map[string]interface{}{
-			"id":   id,
-			"name": name,
-			"thaileageid": func() interface{} { if thaileageid.Valid { return thaileageid.Int64 } else { return nil } }(),
-		})
+	uploadDir := "./img/leagues"
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		log.Printf("Error creating upload directory: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to create upload directory"}`, http.StatusInternalServerError)
+		return
+	}
+	filename := fmt.Sprintf("%s-league-%d%s", time.Now().Format("20060102-150405"), id, ext)
+	dst, err := os.Create(filepath.Join(uploadDir, filename))
+	if err != nil {
+		log.Printf("Error creating file: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to create file"}`, http.StatusInternalServerError)
+		return
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, file); err != nil {
+		log.Printf("Error copying file: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to save file"}`, http.StatusInternalServerError)
+		return
 	}
 
-	response := map[string]interface{}{
-		"success": true,
-		"data":    leagues,
+	logoURL := "/img/leagues/" + filename
+	if err := database.SetLeagueLogo(database.DB, id, logoURL); err != nil {
+		log.Printf("Error updating league logo: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to update league logo"}`, http.StatusInternalServerError)
+		return
 	}
 
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(APIResponse{
+		Success: true,
+		Data: map[string]string{
+			"message":  "Logo uploaded successfully",
+			"logo_url": logoURL,
+		},
+	})
+}
+
+// checkLeagueCodes ตรวจว่า slug/aliases ไม่ชนกับลีกอื่นและไม่ใช่ตัวเลขล้วน (เขียน error ลง w ถ้าไม่ผ่าน)
+func checkLeagueCodes(w http.ResponseWriter, leagueID int, slug string, aliases []string) bool {
+	conflict, err := database.FindLeagueCodeConflict(database.DB, leagueID, append([]string{slug}, aliases...))
+	if err != nil {
+		log.Printf("Failed to check league codes: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to check league slug/aliases"}`, http.StatusInternalServerError)
+		return false
+	}
+	if conflict != "" {
+		http.Error(w, `{"success": false, "error": "slug/alias is numeric or already used by another league"}`, http.StatusConflict)
+		return false
+	}
+	return true
+}
+
+// leagueCodes คืน slug และ aliases ปัจจุบันของลีก
+func leagueCodes(leagueID int) (string, []string) {
+	slugs, aliases, err := database.GetLeagueSlugs(database.DB)
+	if err != nil {
+		log.Printf("Failed to load league slugs: %v", err)
+	}
+	return slugs[leagueID], nonNilStrings(aliases[leagueID])
+}
+
+// nonNilStrings ให้ JSON เป็น [] แทน null
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
 }
 
 // Helper function to check for duplicate entry errors
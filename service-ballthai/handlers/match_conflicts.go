@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"go-ballthai-scraper/conflicts"
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/models"
+)
+
+// conflictLookbackDays คือจำนวนวันย้อนหลังที่ตรวจด้วย (นัดที่เพิ่งเตะไปมีผลกับเวลาพักของนัดถัดไป)
+const conflictLookbackDays = 7
+
+// runConflictCheck ตรวจโปรแกรมชนกันของแมตช์ตั้งแต่ conflictLookbackDays วันก่อนแล้วบันทึกผลแทนผลเดิม
+// เรียกหลัง scrape แมตช์ทุกครั้ง และจาก GET /api/matches/conflicts?refresh=1 คืนผลที่พบ
+func runConflictCheck() ([]models.MatchConflict, error) {
+	from := time.Now().AddDate(0, 0, -conflictLookbackDays).Format("2006-01-02")
+	matches, seasons, err := database.GetConflictCandidates(database.DB, from)
+	if err != nil {
+		return nil, err
+	}
+	found := conflicts.Detect(matches, seasons)
+	if err := database.SaveMatchConflicts(database.DB, from, found); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// GetMatchConflicts คืนโปรแกรมที่ชนกัน (ผลการตรวจล่าสุด) ตั้งแต่วันที่ from
+// GET /api/matches/conflicts?league=&type=rest|stadium|channel|season&from=YYYY-MM-DD&refresh=1
+// from ว่าง = วันนี้, refresh=1 ตรวจใหม่ก่อนคืนผล
+func GetMatchConflicts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	conflictType := q.Get("type")
+	switch conflictType {
+	case "", models.ConflictRest, models.ConflictStadium, models.ConflictChannel, models.ConflictSeason:
+	default:
+		http.Error(w, `{"success": false, "error": "type must be rest, stadium, channel or season"}`, http.StatusBadRequest)
+		return
+	}
+	from := q.Get("from")
+	if from == "" {
+		from = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", from); err != nil {
+		http.Error(w, `{"success": false, "error": "from must be YYYY-MM-DD"}`, http.StatusBadRequest)
+		return
+	}
+
+	if q.Get("refresh") == "1" {
+		if _, err := runConflictCheck(); err != nil {
+			log.Printf("GetMatchConflicts: %v", err)
+			http.Error(w, `{"success": false, "error": "Failed to check conflicts"}`, http.StatusInternalServerError)
+			return
+		}
+	}
+	found, err := database.GetMatchConflicts(database.DB, leagueID, conflictType, from)
+	if err != nil {
+		log.Printf("GetMatchConflicts: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch conflicts"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: found})
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"strings"
+
+	"go-ballthai-scraper/models"
+)
+
+// parseStatusFilter แปลง ?status=live,half_time เป็นรายการสถานะ คืนข้อความผิดพลาดเมื่อมีค่าที่ไม่รู้จัก
+func parseStatusFilter(raw string) ([]interface{}, string) {
+	var out []interface{}
+	for _, part := range strings.Split(raw, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		status, ok := models.ParseMatchStatus(part)
+		if !ok {
+			return nil, "invalid status: " + strings.TrimSpace(part)
+		}
+		out = append(out, string(status))
+	}
+	return out, ""
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"go-ballthai-scraper/database"
+)
+
+// GetNameSuggestions คืนชื่อภาษาอังกฤษ/slug ที่ถอดจากชื่อไทยเพื่อให้ทีมงานตรวจ
+// GET /api/name-suggestions?entity=team|player|stadium&status=pending|accepted|rejected
+func GetNameSuggestions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	entity := r.URL.Query().Get("entity")
+	if entity != "" && !database.IsNameSuggestionEntity(entity) {
+		http.Error(w, `{"success": false, "error": "entity must be team, player or stadium"}`, http.StatusBadRequest)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", "pending", "accepted", "rejected":
+	default:
+		http.Error(w, `{"success": false, "error": "status must be pending, accepted or rejected"}`, http.StatusBadRequest)
+		return
+	}
+	suggestions, err := database.GetNameSuggestions(database.DB, entity, status)
+	if err != nil {
+		log.Printf("GetNameSuggestions: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load name suggestions"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: suggestions})
+}
+
+// GenerateNameSuggestions สร้างคำแนะนำชื่อให้แถวที่ยังไม่มีชื่อภาษาอังกฤษ
+// POST /api/name-suggestions/generate?entity=team (ไม่ระบุ = ทุก entity)
+func GenerateNameSuggestions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	entities := []string{"team", "player", "stadium"}
+	if entity := r.URL.Query().Get("entity"); entity != "" {
+		if !database.IsNameSuggestionEntity(entity) {
+			http.Error(w, `{"success": false, "error": "entity must be team, player or stadium"}`, http.StatusBadRequest)
+			return
+		}
+		entities = []string{entity}
+	}
+	generated := map[string]int{}
+	for _, entity := range entities {
+		n, err := database.GenerateNameSuggestions(database.DB, entity)
+		if err != nil {
+			log.Printf("GenerateNameSuggestions: %v", err)
+			http.Error(w, `{"success": false, "error": "failed to generate name suggestions"}`, http.StatusInternalServerError)
+			return
+		}
+		generated[entity] = n
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: generated})
+}
+
+// ReviewNameSuggestion ยอมรับหรือปฏิเสธคำแนะนำชื่อ (แก้ name_en/slug ก่อนยอมรับได้)
+// PUT /api/name-suggestions/{id} {"action": "accept"|"reject", "name_en": "...", "slug": "..."}
+func ReviewNameSuggestion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid id"}`, http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		Action string `json:"action"`
+		NameEN string `json:"name_en"`
+		Slug   string `json:"slug"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || (body.Action != "accept" && body.Action != "reject") {
+		http.Error(w, `{"success": false, "error": "action must be accept or reject"}`, http.StatusBadRequest)
+		return
+	}
+	if err := database.ReviewNameSuggestion(database.DB, id, body.Action == "accept", body.NameEN, body.Slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, `{"success": false, "error": "name suggestion not found"}`, http.StatusNotFound)
+			return
+		}
+		log.Printf("ReviewNameSuggestion: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to review name suggestion"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true})
+}
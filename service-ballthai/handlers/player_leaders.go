@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/models"
+)
+
+// playerLeader คือหนึ่งแถวใน leaderboard ผู้เล่น
+type playerLeader struct {
+	Rank          int      `json:"rank"`
+	PlayerID      int      `json:"player_id"`
+	Name          string   `json:"name"`
+	PhotoURL      *string  `json:"photo_url"`
+	TeamID        *int     `json:"team_id"`
+	TeamName      *string  `json:"team_name"`
+	Value         int      `json:"value"`
+	Per90         *float64 `json:"per_90,omitempty"`
+	MatchesPlayed int      `json:"matches_played"`
+	MinutesPlayed *int     `json:"minutes_played"`
+	Goals         int      `json:"goals"`
+	Assists       *int     `json:"assists"`
+	YellowCards   int      `json:"yellow_cards"`
+	RedCards      int      `json:"red_cards"`
+}
+
+// leaderValue คืนค่าของ stat ที่ใช้จัดอันดับ
+func leaderValue(l models.PlayerLeaderDB, stat string) int {
+	switch stat {
+	case "assists":
+		return int(l.Assists.Int64)
+	case "yellow_cards":
+		return l.YellowCards
+	case "red_cards":
+		return l.RedCards
+	case "matches_played":
+		return l.MatchesPlayed
+	case "minutes":
+		return int(l.MinutesPlayed.Int64)
+	}
+	return l.Goals
+}
+
+// GetPlayerLeaders คืน leaderboard ผู้เล่นตาม stat ที่เลือก ผู้เล่นที่ค่าเท่ากันได้อันดับเดียวกัน
+// GET /api/players/leaders?stat=goals|assists|yellow_cards|red_cards|matches_played|minutes&league=&season=&team=&limit=
+// per_90 จะมีเมื่อทราบจำนวนนาทีที่ลงเล่น (ไม่คำนวณสำหรับ stat=minutes และ matches_played)
+func GetPlayerLeaders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	q := r.URL.Query()
+
+	f := models.PlayerLeaderFilter{Stat: q.Get("stat"), Season: q.Get("season"), Limit: 50}
+	if f.Stat == "" {
+		f.Stat = "goals"
+	}
+	if !database.IsLeaderStat(f.Stat) {
+		http.Error(w, `{"success": false, "error": "invalid stat"}`, http.StatusBadRequest)
+		return
+	}
+	// league/team รับได้ทั้ง id, slug, alias หรือชื่อ
+	var ok bool
+	if f.LeagueID, ok = leagueParam(w, r); !ok {
+		return
+	}
+	if f.TeamID, ok = teamParam(w, r); !ok {
+		return
+	}
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 {
+		f.Limit = l
+	}
+
+	leaders, err := database.GetPlayerLeaders(database.DB, f)
+	if err != nil {
+		log.Printf("GetPlayerLeaders: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load leaders"}`, http.StatusInternalServerError)
+		return
+	}
+
+	withPer90 := f.Stat != "minutes" && f.Stat != "matches_played"
+	lang := requestLang(w, r)
+	result := make([]playerLeader, len(leaders))
+	for i, l := range leaders {
+		row := playerLeader{
+			PlayerID:      l.PlayerID,
+			Name:          l.Name,
+			Value:         leaderValue(l, f.Stat),
+			MatchesPlayed: l.MatchesPlayed,
+			Goals:         l.Goals,
+			YellowCards:   l.YellowCards,
+			RedCards:      l.RedCards,
+		}
+		if l.PhotoURL.Valid {
+			row.PhotoURL = &l.PhotoURL.String
+		}
+		if l.TeamID.Valid {
+			id := int(l.TeamID.Int64)
+			row.TeamID = &id
+		}
+		if l.TeamName.Valid {
+			row.TeamName = &l.TeamName.String
+		}
+		if lang == LangEN {
+			if l.NameEN.String != "" {
+				row.Name = l.NameEN.String
+			}
+			if l.TeamNameEN.String != "" {
+				row.TeamName = &l.TeamNameEN.String
+			}
+		}
+		if l.Assists.Valid {
+			v := int(l.Assists.Int64)
+			row.Assists = &v
+		}
+		if l.MinutesPlayed.Valid {
+			v := int(l.MinutesPlayed.Int64)
+			row.MinutesPlayed = &v
+			if withPer90 && v > 0 {
+				p := math.Round(float64(row.Value)*90/float64(v)*100) / 100
+				row.Per90 = &p
+			}
+		}
+		// อันดับร่วม: ค่าเท่ากับแถวก่อนหน้าได้อันดับเดียวกัน (1, 1, 3)
+		row.Rank = i + 1
+		if i > 0 && row.Value == result[i-1].Value {
+			row.Rank = result[i-1].Rank
+		}
+		result[i] = row
+	}
+
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: result})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"go-ballthai-scraper/database"
+)
+
+// GetDuplicatePlayers คืนรายการกลุ่มผู้เล่นที่น่าจะซ้ำกันเพื่อให้ทีมงานตรวจสอบ
+// GET /api/players/duplicates
+func GetDuplicatePlayers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	groups, err := database.FindDuplicatePlayers(database.DB)
+	if err != nil {
+		log.Printf("GetDuplicatePlayers: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to find duplicates"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: groups})
+}
+
+// IgnoreDuplicatePlayers บันทึกว่าผู้เล่นในรายการไม่ใช่คนเดียวกัน
+// POST /api/players/duplicates/ignore {"player_ids": [1, 2]}
+func IgnoreDuplicatePlayers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var body struct {
+		PlayerIDs []int `json:"player_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.PlayerIDs) < 2 {
+		http.Error(w, `{"success": false, "error": "player_ids must contain at least two ids"}`, http.StatusBadRequest)
+		return
+	}
+	for i := 0; i < len(body.PlayerIDs); i++ {
+		for j := i + 1; j < len(body.PlayerIDs); j++ {
+			if err := database.IgnoreDuplicatePair(database.DB, body.PlayerIDs[i], body.PlayerIDs[j]); err != nil {
+				log.Printf("IgnoreDuplicatePlayers: %v", err)
+				http.Error(w, `{"success": false, "error": "failed to save"}`, http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true})
+}
+
+// MergePlayers รวมผู้เล่นซ้ำเข้ากับ record หลัก
+// POST /api/players/merge {"keep_id": 1, "merge_ids": [2, 3]}
+func MergePlayers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var body struct {
+		KeepID   int   `json:"keep_id"`
+		MergeIDs []int `json:"merge_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.KeepID == 0 || len(body.MergeIDs) == 0 {
+		http.Error(w, `{"success": false, "error": "keep_id and merge_ids are required"}`, http.StatusBadRequest)
+		return
+	}
+	err := database.MergePlayers(database.DB, body.KeepID, body.MergeIDs)
+	if errors.Is(err, database.ErrAlreadyMerged) {
+		http.Error(w, `{"success": false, "error": "`+err.Error()+`"}`, http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("MergePlayers: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to merge players"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]interface{}{
+		"keep_id":    body.KeepID,
+		"merged_ids": body.MergeIDs,
+	}})
+}
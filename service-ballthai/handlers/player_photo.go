@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// maxPlayerPhotoSize คือขนาดไฟล์รูปผู้เล่นสูงสุดที่รับได้
+const maxPlayerPhotoSize = 5 << 20 // 5 MB
+
+// allowedPhotoContentTypes คือชนิดไฟล์จริง (ตรวจจากเนื้อไฟล์) ที่รับได้
+var allowedPhotoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// UploadPlayerPhoto handles POST /api/players/{id}/photo (multipart field "photo")
+// รูปที่อัปโหลดจะถูกปักหมุด (photo_pinned = 1) เพื่อไม่ให้ ScrapePlayers เขียนทับ ส่ง pin=0 ถ้าไม่ต้องการปักหมุด
+func UploadPlayerPhoto(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	playerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Invalid player ID"}`, http.StatusBadRequest)
+		return
+	}
+	var exists int
+	if err := DB.QueryRow("SELECT id FROM players WHERE id = ?", playerID).Scan(&exists); err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "Player not found"}`, http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("Error checking player %d: %v", playerID, err)
+		http.Error(w, `{"success": false, "error": "Database error"}`, http.StatusInternalServerError)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPlayerPhotoSize+(1<<20))
+	if err := r.ParseMultipartForm(maxPlayerPhotoSize); err != nil {
+		http.Error(w, `{"success": false, "error": "Failed to parse multipart form (max 5 MB)"}`, http.StatusBadRequest)
+		return
+	}
+	file, handler, err := r.FormFile("photo")
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Failed to get file from form"}`, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	if handler.Size > maxPlayerPhotoSize {
+		http.Error(w, `{"success": false, "error": "File too large (max 5 MB)"}`, http.StatusBadRequest)
+		return
+	}
+	ext := strings.ToLower(filepath.Ext(handler.Filename))
+	if !isValidImageType(handler.Filename) && ext != ".webp" {
+		http.Error(w, `{"success": false, "error": "Invalid file type. Only JPG, PNG, GIF and WEBP are allowed"}`, http.StatusBadRequest)
+		return
+	}
+	// ตรวจชนิดไฟล์จากเนื้อไฟล์ ไม่เชื่อนามสกุลอย่างเดียว
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	if !allowedPhotoContentTypes[http.DetectContentType(head[:n])] {
+		http.Error(w, `{"success": false, "error": "File content is not a supported image"}`, http.StatusBadRequest)
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		http.Error(w, `{"success": false, "error": "Failed to read file"}`, http.StatusInternalServerError)
+		return
+	}
+
+	uploadDir := "./img/player"
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		log.Printf("Error creating upload directory: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to create upload directory"}`, http.StatusInternalServerError)
+		return
+	}
+	filename := fmt.Sprintf("%s-player-%d%s", time.Now().Format("20060102-150405"), playerID, ext)
+	dst, err := os.Create(filepath.Join(uploadDir, filename))
+	if err != nil {
+		log.Printf("Error creating file: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to create file"}`, http.StatusInternalServerError)
+		return
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, file); err != nil {
+		log.Printf("Error copying file: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to save file"}`, http.StatusInternalServerError)
+		return
+	}
+
+	pinned := r.FormValue("pin") != "0"
+	photoURL := "/img/player/" + filename
+	if _, err := DB.Exec("UPDATE players SET photo_url = ?, photo_pinned = ? WHERE id = ?", photoURL, pinned, playerID); err != nil {
+		log.Printf("Error updating player photo: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to update player photo"}`, http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"message":   "Photo uploaded successfully",
+			"photo_url": photoURL,
+			"pinned":    pinned,
+		},
+	})
+}
+
+// SetPlayerPhotoPin handles PUT /api/players/{id}/photo/pin with body {"pinned": true|false}
+// ยกเลิกปักหมุดเพื่อให้ ScrapePlayers กลับมาอัปเดตรูปจากแหล่งข้อมูลได้
+func SetPlayerPhotoPin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	playerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "Invalid player ID"}`, http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		Pinned bool `json:"pinned"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, `{"success": false, "error": "Invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	res, err := DB.Exec("UPDATE players SET photo_pinned = ? WHERE id = ?", body.Pinned, playerID)
+	if err != nil {
+		log.Printf("Error updating photo pin for player %d: %v", playerID, err)
+		http.Error(w, `{"success": false, "error": "Failed to update photo pin"}`, http.StatusInternalServerError)
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		var exists int
+		if err := DB.QueryRow("SELECT id FROM players WHERE id = ?", playerID).Scan(&exists); err == sql.ErrNoRows {
+			http.Error(w, `{"success": false, "error": "Player not found"}`, http.StatusNotFound)
+			return
+		}
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]interface{}{"pinned": body.Pinned}})
+}
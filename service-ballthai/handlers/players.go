@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"go-ballthai-scraper/database"
@@ -37,15 +38,63 @@ func UpdatePlayer(w http.ResponseWriter, r *http.Request) {
 		YellowCards   int    `json:"yellow_cards"`
 		RedCards      int    `json:"red_cards"`
 		Status        int    `json:"status"`
+		// ข้อมูลโปรไฟล์: อัปเดตเฉพาะ field ที่ส่งมา (ส่งค่าว่าง "" หรือ 0 เพื่อล้างค่า)
+		DateOfBirth   *string `json:"date_of_birth"`
+		Height        *int    `json:"height"`
+		Weight        *int    `json:"weight"`
+		PlaceOfBirth  *string `json:"place_of_birth"`
+		CareerStart   *int    `json:"career_start"`
+		PreferredFoot *string `json:"preferred_foot"`
+		// API ของ ballthai ไม่มี assists/นาทีที่ลงเล่น จึงกรอกเองได้ที่นี่ (ส่งค่าติดลบเพื่อล้างค่า)
+		Assists       *int    `json:"assists"`
+		MinutesPlayed *int    `json:"minutes_played"`
 	}
 	var body reqBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	sets := "name=?, shirt_number=?, position=?, matches_played=?, goals=?, yellow_cards=?, red_cards=?, status=?"
+	args := []interface{}{body.Name, body.ShirtNumber, body.Position, body.MatchesPlayed, body.Goals, body.YellowCards, body.RedCards, body.Status}
+	if body.DateOfBirth != nil {
+		dob := sql.NullString{String: *body.DateOfBirth, Valid: *body.DateOfBirth != ""}
+		if dob.Valid {
+			if _, err := time.Parse("2006-01-02", dob.String); err != nil {
+				http.Error(w, "Invalid date_of_birth (expected YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+		sets += ", birth_date=?"
+		args = append(args, dob)
+	}
+	if body.PreferredFoot != nil {
+		foot := *body.PreferredFoot
+		if foot != "" && foot != "left" && foot != "right" && foot != "both" {
+			http.Error(w, "Invalid preferred_foot (left, right or both)", http.StatusBadRequest)
+			return
+		}
+		sets += ", preferred_foot=?"
+		args = append(args, sql.NullString{String: foot, Valid: foot != ""})
+	}
+	if body.PlaceOfBirth != nil {
+		sets += ", birthplace=?"
+		args = append(args, sql.NullString{String: *body.PlaceOfBirth, Valid: *body.PlaceOfBirth != ""})
+	}
+	for col, v := range map[string]*int{"height_cm": body.Height, "weight_kg": body.Weight, "career_start": body.CareerStart} {
+		if v != nil {
+			sets += ", " + col + "=?"
+			args = append(args, sql.NullInt64{Int64: int64(*v), Valid: *v > 0})
+		}
+	}
+	for col, v := range map[string]*int{"assists": body.Assists, "minutes_played": body.MinutesPlayed} {
+		if v != nil {
+			sets += ", " + col + "=?"
+			args = append(args, sql.NullInt64{Int64: int64(*v), Valid: *v >= 0})
+		}
+	}
+	args = append(args, id)
 	// Update player in DB
-	_, err = db.Exec(`UPDATE players SET name=?, shirt_number=?, position=?, matches_played=?, goals=?, yellow_cards=?, red_cards=?, status=? WHERE id=?`,
-		body.Name, body.ShirtNumber, body.Position, body.MatchesPlayed, body.Goals, body.YellowCards, body.RedCards, body.Status, id)
+	_, err = db.Exec("UPDATE players SET "+sets+" WHERE id=?", args...)
 	if err != nil {
 		log.Println("Update player error:", err)
 		http.Error(w, "Failed to update player", http.StatusInternalServerError)
@@ -80,10 +129,15 @@ func GetPlayers(w http.ResponseWriter, r *http.Request) {
 	// Get pagination parameters
 	limitStr := r.URL.Query().Get("limit")
 	offsetStr := r.URL.Query().Get("offset")
-	teamIDStr := r.URL.Query().Get("team_id")
 	positionFilter := r.URL.Query().Get("position")
 	nationalityFilter := r.URL.Query().Get("nationality")
 
+	// team_id รับได้ทั้งตัวเลข, slug หรือชื่อทีม
+	teamID, ok := teamParam(w, r)
+	if !ok {
+		return
+	}
+
 	limit := 20 // default
 	offset := 0 // default
 
@@ -102,22 +156,19 @@ func GetPlayers(w http.ResponseWriter, r *http.Request) {
 	// Build query with optional filters
 	// Select only columns that exist in the schema. Avoid referencing p.age, p.height, etc.
 	baseQuery := `
-		SELECT p.id, p.name, p.position, p.shirt_number, p.team_id, t.name_th as team_name,
-			   t.team_post_ballthai as team_post_id, p.photo_url, p.matches_played, p.goals,
-			   p.yellow_cards, p.red_cards, p.status, n.code as nationality, p.player_ref_id as player_post_id
+		SELECT ` + playerColumns(requestLang(w, r), "n.code") + `
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id
 		LEFT JOIN nationalities n ON p.nationality_id = n.id
 	`
 
-	var whereConditions []string
+	// ไม่แสดงผู้เล่นที่ถูกรวมเข้ากับ record อื่นแล้ว
+	whereConditions := []string{"p.merged_into_id IS NULL"}
 	var args []interface{}
 
-	if teamIDStr != "" {
-		if teamID, err := strconv.Atoi(teamIDStr); err == nil {
-			whereConditions = append(whereConditions, "p.team_id = ?")
-			args = append(args, teamID)
-		}
+	if teamID != 0 {
+		whereConditions = append(whereConditions, "p.team_id = ?")
+		args = append(args, teamID)
 	}
 
 	if positionFilter != "" {
@@ -155,40 +206,14 @@ func GetPlayers(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	players := make([]Player, 0)
+	now := time.Now()
 	for rows.Next() {
-		var player Player
-		var pos sql.NullString
-		var shirt sql.NullInt64
-		var teamID sql.NullInt64
-		var teamName sql.NullString
-		var teamPost sql.NullString
-		var photo sql.NullString
-		var matchesPlayed sql.NullInt64
-		var goals sql.NullInt64
-		var yellow sql.NullInt64
-		var red sql.NullInt64
-		var status sql.NullInt64
-		var nationality sql.NullString
-		var playerPost sql.NullInt64
-
-		if err := rows.Scan(&player.ID, &player.Name, &pos, &shirt, &teamID, &teamName, &teamPost, &photo, &matchesPlayed, &goals, &yellow, &red, &status, &nationality, &playerPost); err != nil {
+		player, err := scanPlayer(rows, now)
+		if err != nil {
 			log.Printf("Scan error in GetPlayers: %v", err)
 			http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
 			return
 		}
-		if pos.Valid { p := pos.String; player.Position = &p }
-		if shirt.Valid { v := int(shirt.Int64); player.ShirtNumber = &v }
-		if teamID.Valid { v := int(teamID.Int64); player.TeamID = &v }
-		if teamName.Valid { s := teamName.String; player.TeamName = &s }
-		if teamPost.Valid { if tp, err := strconv.Atoi(teamPost.String); err == nil { player.TeamPostID = &tp } }
-		if photo.Valid { s := photo.String; player.ProfileImage = &s }
-		if matchesPlayed.Valid { v := int(matchesPlayed.Int64); /* not stored in struct currently */ _ = v }
-		if goals.Valid { v := int(goals.Int64); player.Goals = &v }
-		if yellow.Valid { v := int(yellow.Int64); /* optional */ _ = v }
-		if red.Valid { v := int(red.Int64); /* optional */ _ = v }
-		if nationality.Valid { s := nationality.String; player.Nationality = &s }
-		if playerPost.Valid { v := int(playerPost.Int64); player.PlayerPostID = &v }
-
 		players = append(players, player)
 	}
 
@@ -205,7 +230,11 @@ func GetTopScorers(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	limitStr := r.URL.Query().Get("limit")
-	leagueIDStr := r.URL.Query().Get("league_id")
+	// league_id รับได้ทั้งตัวเลข, slug/alias (t1, t2, ...) หรือชื่อลีก
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
 
 	limit := 50
 	if limitStr != "" {
@@ -214,8 +243,9 @@ func GetTopScorers(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	lang := requestLang(w, r)
 	query := `
-		SELECT p.id, p.name, p.position, p.shirt_number, p.team_id, t.name_th as team_name,
+		SELECT p.id, ` + localName(lang, "p.name", "p.full_name_en") + `, p.position, p.shirt_number, p.team_id, ` + localName(lang, "t.name_th", "t.name_en") + ` as team_name,
 			   t.team_post_ballthai as team_post_id, n.code as nationality,
 			   p.photo_url, p.goals
 		FROM players p
@@ -226,25 +256,9 @@ func GetTopScorers(w http.ResponseWriter, r *http.Request) {
 	var args []interface{}
 
 	// Always only include players with goals > 0
-	query += " WHERE p.goals > 0"
-
-	if leagueIDStr != "" {
-		var leagueID int
-		if id, err := strconv.Atoi(leagueIDStr); err == nil {
-			leagueID = id
-		} else {
-			// Support alias strings (e.g., "t1" -> 1). Add more mappings as needed.
-			aliasMap := map[string]int{
-				"t1": 1,
-				"t2": 2,
-			}
-			if mapped, ok := aliasMap[leagueIDStr]; ok {
-				leagueID = mapped
-			} else {
-				http.Error(w, "Invalid league_id parameter", http.StatusBadRequest)
-				return
-			}
-		}
+	query += " WHERE p.goals > 0 AND p.merged_into_id IS NULL"
+
+	if leagueID != 0 {
 	// filter by league using players.league_id
 	query += " AND p.league_id = ?"
 	args = append(args, leagueID)
@@ -333,13 +347,11 @@ func GetPlayersByTeamID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := `
-		SELECT p.id, p.name, p.position, p.shirt_number, p.team_id, t.name_th as team_name,
-			   t.team_post_ballthai as team_post_id, p.photo_url, p.matches_played, p.goals,
-			   p.yellow_cards, p.red_cards, p.status, n.code as nationality, p.player_ref_id as player_post_id
+		SELECT ` + playerColumns(requestLang(w, r), "n.code") + `
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id
 		LEFT JOIN nationalities n ON p.nationality_id = n.id
-		WHERE p.team_id = ?
+		WHERE p.team_id = ? AND p.merged_into_id IS NULL
 		ORDER BY p.shirt_number, p.name
 	`
 
@@ -352,13 +364,10 @@ func GetPlayersByTeamID(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	var players []Player
+	now := time.Now()
 	for rows.Next() {
-		var player Player
-		if err := rows.Scan(&player.ID, &player.Name, &player.Position, &player.ShirtNumber,
-			&player.TeamID, &player.TeamName, &player.TeamPostID, &player.Age,
-			&player.Height, &player.Weight, &player.Nationality, &player.PlayerPostID,
-			&player.ProfileImage, &player.DateOfBirth, &player.PlaceOfBirth,
-			&player.CareerStart, &player.PreferredFoot); err != nil {
+		player, err := scanPlayer(rows, now)
+		if err != nil {
 			log.Printf("Scan error in GetPlayersByTeamID: %v", err)
 			http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
 			return
@@ -386,13 +395,11 @@ func GetPlayersByTeamPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := `
-		SELECT p.id, p.name, p.position, p.shirt_number, p.team_id, t.name_th as team_name,
-			   t.team_post_ballthai as team_post_id, p.photo_url, p.matches_played, p.goals,
-			   p.yellow_cards, p.red_cards, p.status, n.name as nationality, p.player_ref_id as player_post_id
+		SELECT ` + playerColumns(requestLang(w, r), "n.name") + `
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id
 		LEFT JOIN nationalities n ON p.nationality_id = n.id
-		WHERE t.team_post_ballthai = ?
+		WHERE t.team_post_ballthai = ? AND p.merged_into_id IS NULL
 		ORDER BY p.shirt_number, p.name
 	`
 
@@ -405,13 +412,10 @@ func GetPlayersByTeamPost(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	var players []Player
+	now := time.Now()
 	for rows.Next() {
-		var player Player
-		if err := rows.Scan(&player.ID, &player.Name, &player.Position, &player.ShirtNumber,
-			&player.TeamID, &player.TeamName, &player.TeamPostID, &player.Age,
-			&player.Height, &player.Weight, &player.Nationality, &player.PlayerPostID,
-			&player.ProfileImage, &player.DateOfBirth, &player.PlaceOfBirth,
-			&player.CareerStart, &player.PreferredFoot); err != nil {
+		player, err := scanPlayer(rows, now)
+		if err != nil {
 			log.Printf("Scan error in GetPlayersByTeamPost: %v", err)
 			http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
 			return
@@ -426,3 +430,62 @@ func GetPlayersByTeamPost(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(response)
 }
+
+// playerColumns คือคอลัมน์ที่ API ผู้เล่นเลือก เรียงตามที่ scanPlayer อ่าน
+// nationalityCol คือคอลัมน์สัญชาติที่ต้องการแสดง (n.code หรือ n.name), lang คือภาษาของชื่อผู้เล่น/ทีม
+func playerColumns(lang, nationalityCol string) string {
+	return `p.id, ` + localName(lang, "p.name", "p.full_name_en") + `, p.position, p.shirt_number, p.team_id, ` + localName(lang, "t.name_th", "t.name_en") + ` as team_name,
+			   t.team_post_ballthai as team_post_id, p.photo_url, p.matches_played, p.goals,
+			   p.yellow_cards, p.red_cards, p.status, ` + nationalityCol + ` as nationality, p.player_ref_id as player_post_id,
+			   DATE_FORMAT(p.birth_date, '%Y-%m-%d'), p.height_cm, p.weight_kg, p.birthplace, p.career_start, p.preferred_foot`
+}
+
+// scanPlayer อ่านหนึ่งแถวจาก playerColumns เป็น Player โดยคำนวณอายุจากวันเกิด ณ เวลา now
+func scanPlayer(rows *sql.Rows, now time.Time) (Player, error) {
+	var player Player
+	var pos, teamName, teamPost, photo, nationality sql.NullString
+	// matches_played, ใบเหลือง/แดง และ status อ่านแต่ไม่ได้ส่งใน response (เหมือน GetPlayers เดิม)
+	var shirt, teamID, matchesPlayed, goals, yellow, red, status, playerPost sql.NullInt64
+	var birthDate, birthplace, foot sql.NullString
+	var height, weight, careerStart sql.NullInt64
+	if err := rows.Scan(&player.ID, &player.Name, &pos, &shirt, &teamID, &teamName, &teamPost, &photo,
+		&matchesPlayed, &goals, &yellow, &red, &status, &nationality, &playerPost,
+		&birthDate, &height, &weight, &birthplace, &careerStart, &foot); err != nil {
+		return player, err
+	}
+	if pos.Valid { p := pos.String; player.Position = &p }
+	if shirt.Valid { v := int(shirt.Int64); player.ShirtNumber = &v }
+	if teamID.Valid { v := int(teamID.Int64); player.TeamID = &v }
+	if teamName.Valid { s := teamName.String; player.TeamName = &s }
+	if teamPost.Valid { if tp, err := strconv.Atoi(teamPost.String); err == nil { player.TeamPostID = &tp } }
+	if photo.Valid { s := photo.String; player.ProfileImage = &s }
+	if goals.Valid { v := int(goals.Int64); player.Goals = &v }
+	if nationality.Valid { s := nationality.String; player.Nationality = &s }
+	if playerPost.Valid { v := int(playerPost.Int64); player.PlayerPostID = &v }
+	if birthDate.Valid {
+		s := birthDate.String
+		player.DateOfBirth = &s
+		if age, ok := ageOn(s, now); ok {
+			player.Age = &age
+		}
+	}
+	if height.Valid { s := strconv.FormatInt(height.Int64, 10); player.Height = &s }
+	if weight.Valid { s := strconv.FormatInt(weight.Int64, 10); player.Weight = &s }
+	if birthplace.Valid { s := birthplace.String; player.PlaceOfBirth = &s }
+	if careerStart.Valid { v := int(careerStart.Int64); player.CareerStart = &v }
+	if foot.Valid { s := foot.String; player.PreferredFoot = &s }
+	return player, nil
+}
+
+// ageOn คำนวณอายุเต็มปีจากวันเกิด (YYYY-MM-DD) ณ วันที่ now
+func ageOn(birthDate string, now time.Time) (int, bool) {
+	dob, err := time.Parse("2006-01-02", birthDate)
+	if err != nil {
+		return 0, false
+	}
+	age := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || (now.Month() == dob.Month() && now.Day() < dob.Day()) {
+		age--
+	}
+	return age, true
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go-ballthai-scraper/database"
+)
+
+// GetQuotaCompliance คืนจำนวนผู้เล่นไทย/อาเซียน/AFC/อื่นๆ ของแต่ละทีมเทียบกับโควตาของลีก
+// GET /api/quota?league_id=&season=&team_id=
+// ระบุ team_id เพื่อดูรายชื่อผู้เล่นพร้อมกลุ่มสัญชาติของทีมนั้น
+func GetQuotaCompliance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	q := r.URL.Query()
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+	if leagueID == 0 {
+		http.Error(w, `{"success": false, "error": "league_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	teamID, ok := teamParam(w, r)
+	if !ok {
+		return
+	}
+	season := q.Get("season")
+
+	rules, err := database.GetQuotaRules(database.DB, leagueID, season)
+	if err != nil {
+		log.Printf("GetQuotaCompliance: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load quota rules"}`, http.StatusInternalServerError)
+		return
+	}
+	teams, err := database.GetTeamQuotas(database.DB, rules, season, teamID)
+	if err != nil {
+		log.Printf("GetQuotaCompliance: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load squads"}`, http.StatusInternalServerError)
+		return
+	}
+	violations := 0
+	for _, t := range teams {
+		if !t.Compliant {
+			violations++
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"rules":         rules,
+			"season":        season,
+			"teams":         teams,
+			"non_compliant": violations,
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+
+	"go-ballthai-scraper/database"
+)
+
+// leagueParam คืน league id จาก ?league= หรือ ?league_id= ซึ่งเป็นได้ทั้ง id, slug, alias หรือชื่อลีก
+// คืน 0 เมื่อไม่ได้ระบุ; ถ้าหาไม่พบจะเขียน error ลง w แล้วคืน ok = false
+func leagueParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	q := r.URL.Query()
+	value := q.Get("league")
+	if value == "" {
+		value = q.Get("league_id")
+	}
+	return resolveID(w, value, "league", database.ResolveLeagueID)
+}
+
+// teamParam คืน team id จาก ?team= หรือ ?team_id= ซึ่งเป็นได้ทั้ง id, slug หรือชื่อทีม
+func teamParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	q := r.URL.Query()
+	value := q.Get("team")
+	if value == "" {
+		value = q.Get("team_id")
+	}
+	return resolveID(w, value, "team", database.ResolveTeamID)
+}
+
+// resolveID แปลงค่าด้วย resolve และเขียน error ตามชนิด (ไม่พบ = 400, อื่นๆ = 500)
+func resolveID(w http.ResponseWriter, value, kind string, resolve func(*sql.DB, string) (int, error)) (int, bool) {
+	if value == "" {
+		return 0, true
+	}
+	id, err := resolve(database.DB, value)
+	if errors.Is(err, database.ErrNotFound) {
+		http.Error(w, `{"success": false, "error": "unknown `+kind+`"}`, http.StatusBadRequest)
+		return 0, false
+	}
+	if err != nil {
+		log.Printf("resolve %s: %v", kind, err)
+		http.Error(w, `{"success": false, "error": "failed to resolve `+kind+`"}`, http.StatusInternalServerError)
+		return 0, false
+	}
+	return id, true
+}
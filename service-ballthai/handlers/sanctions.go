@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/models"
+)
+
+// sanctionFootnote คือหมายเหตุใต้ตารางคะแนนของคะแนนที่ถูกตัด/ได้เพิ่ม แถวในตารางอ้างถึงด้วย n
+type sanctionFootnote struct {
+	N       int    `json:"n"`
+	TeamID  int    `json:"team_id"`
+	StageID *int   `json:"stage_id,omitempty"`
+	Points  int    `json:"points"`
+	Date    string `json:"date"`
+	Text    string `json:"text"`
+}
+
+// footnotesFor คืนเลขหมายเหตุของแถว (ทีมใน stage นั้น)
+func footnotesFor(notes []sanctionFootnote, teamID int, stageID int64) []int {
+	var refs []int
+	for _, n := range notes {
+		if n.TeamID == teamID && (n.StageID == nil || int64(*n.StageID) == stageID) {
+			refs = append(refs, n.N)
+		}
+	}
+	return refs
+}
+
+// sanctionText คืนข้อความหมายเหตุตามภาษา เช่น "<ทีม> ถูกหัก 3 คะแนน: ส่งผู้เล่นไม่มีสิทธิ์ลงสนาม (2025-01-10)"
+func sanctionText(lang, teamName string, s models.SanctionDB) string {
+	reason := s.Reason
+	if lang == LangEN {
+		if s.ReasonEN != nil {
+			reason = *s.ReasonEN
+		}
+		if s.Points < 0 {
+			return fmt.Sprintf("%s: %d points deducted – %s (%s)", teamName, -s.Points, reason, s.SanctionDate)
+		}
+		return fmt.Sprintf("%s: %d points awarded – %s (%s)", teamName, s.Points, reason, s.SanctionDate)
+	}
+	if s.Points < 0 {
+		return fmt.Sprintf("%s ถูกหัก %d คะแนน: %s (%s)", teamName, -s.Points, reason, s.SanctionDate)
+	}
+	return fmt.Sprintf("%s ได้รับ %d คะแนน: %s (%s)", teamName, s.Points, reason, s.SanctionDate)
+}
+
+// sanctionFootnotes สร้างหมายเหตุของ sanctions ที่ใช้กับทีมในตาราง (teamStages: team_id -> stage ของแถวนั้น)
+// คืนหมายเหตุเรียงตามวันที่ (แถวในตารางหาเลขของตัวเองด้วย footnotesFor)
+func sanctionFootnotes(lang string, sanctions []models.SanctionDB, teamStages map[int][]int64, names map[int]string) []sanctionFootnote {
+	notes := []sanctionFootnote{}
+	for _, s := range sanctions {
+		applies := false
+		for _, stage := range teamStages[s.TeamID] {
+			if s.StageID == nil || int64(*s.StageID) == stage {
+				applies = true
+			}
+		}
+		if !applies {
+			continue
+		}
+		name := names[s.TeamID]
+		if name == "" && s.TeamName != nil {
+			name = *s.TeamName
+		}
+		notes = append(notes, sanctionFootnote{N: len(notes) + 1, TeamID: s.TeamID, StageID: s.StageID, Points: s.Points,
+			Date: s.SanctionDate, Text: sanctionText(lang, name, s)})
+	}
+	return notes
+}
+
+// seasonScope คืน season id จาก ?season= (ชื่อฤดูกาล) หรือฤดูกาลปัจจุบันของลีกถ้าไม่ระบุ
+func seasonScope(w http.ResponseWriter, r *http.Request, leagueID int) (sql.NullInt64, bool) {
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		id, err := database.GetCurrentSeasonID(database.DB, leagueID)
+		if err != nil {
+			log.Printf("seasonScope: %v", err)
+			http.Error(w, `{"success": false, "error": "failed to resolve season"}`, http.StatusInternalServerError)
+			return id, false
+		}
+		return id, true
+	}
+	id, err := database.GetSeasonIDByName(database.DB, season, leagueID)
+	if err != nil {
+		log.Printf("seasonScope: %v", err)
+		http.Error(w, `{"success": false, "error": "season not found"}`, http.StatusBadRequest)
+		return sql.NullInt64{}, false
+	}
+	return sql.NullInt64{Int64: int64(id), Valid: true}, true
+}
+
+// GetSanctions คืนบทลงโทษ/คะแนนปรับทั้งหมดของลีกในฤดูกาล (รวมที่ลงวันที่ล่วงหน้า)
+// GET /api/sanctions?league=&season=
+func GetSanctions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+	if leagueID == 0 {
+		http.Error(w, `{"success": false, "error": "league_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	seasonID, ok := seasonScope(w, r, leagueID)
+	if !ok {
+		return
+	}
+	sanctions, err := database.GetSanctions(database.DB, leagueID, seasonID, "*")
+	if err != nil {
+		log.Printf("GetSanctions: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch sanctions"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: sanctions})
+}
+
+// CreateSanction บันทึกการหัก/เพิ่มคะแนน ถ้าไม่ระบุ season_id จะผูกกับฤดูกาลปัจจุบันของลีก
+// POST /api/sanctions  body: {"league_id": 1, "team_id": 5, "points": -3, "reason": "...", "sanction_date": "2025-01-10"}
+func CreateSanction(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req models.SanctionDB
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"success": false, "error": "invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	if errs := req.Validate(); len(errs) > 0 {
+		models.WriteValidationErrors(w, errs)
+		return
+	}
+	if req.SeasonID == nil {
+		seasonID, err := database.GetCurrentSeasonID(database.DB, req.LeagueID)
+		if err != nil {
+			log.Printf("CreateSanction: %v", err)
+			http.Error(w, `{"success": false, "error": "failed to resolve season"}`, http.StatusInternalServerError)
+			return
+		}
+		if seasonID.Valid {
+			v := int(seasonID.Int64)
+			req.SeasonID = &v
+		}
+	}
+	id, err := database.CreateSanction(database.DB, req)
+	if err != nil {
+		log.Printf("CreateSanction: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to create sanction"}`, http.StatusInternalServerError)
+		return
+	}
+	req.ID = id
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: req})
+}
+
+// DeleteSanction ลบการหัก/เพิ่มคะแนน
+// DELETE /api/sanctions/{id}
+func DeleteSanction(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid sanction id"}`, http.StatusBadRequest)
+		return
+	}
+	err = database.DeleteSanction(database.DB, id)
+	if err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "sanction not found"}`, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("DeleteSanction: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to delete sanction"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]int{"id": id}})
+}
@@ -56,11 +56,19 @@ func ScrapeMatchesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ตรวจโปรแกรมชนกันหลัง scrape ทุกครั้ง (ผลดูได้ที่ /conflicts.html) ตรวจไม่สำเร็จไม่ถือว่า scrape ล้มเหลว
+	var conflictMsg string
+	if found, err := runConflictCheck(); err != nil {
+		log.Println("Conflict check error:", err)
+	} else if len(found) > 0 {
+		conflictMsg = fmt.Sprintf("\nFixture conflicts found: %d (see /conflicts.html)\n", len(found))
+	}
+
 	w.WriteHeader(http.StatusOK)
 	if resultMsg == "" {
-		w.Write([]byte("Scrape completed successfully (no leagues found)"))
+		w.Write([]byte("Scrape completed successfully (no leagues found)" + conflictMsg))
 	} else {
-		w.Write([]byte("Scrape completed successfully.\n\nLeagues scraped:\n" + resultMsg))
+		w.Write([]byte("Scrape completed successfully.\n\nLeagues scraped:\n" + resultMsg + conflictMsg))
 	}
 }
 
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/models"
+)
+
+// GetStages คืน stage เรียงตามลำดับภายในลีก/ฤดูกาล
+// GET /api/stages?league=&season=  (ไม่ระบุ league = ทุกลีก, season ต้องใช้คู่กับ league)
+func GetStages(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+
+	var seasonID sql.NullInt64
+	if season := r.URL.Query().Get("season"); season != "" {
+		if leagueID == 0 {
+			http.Error(w, `{"success": false, "error": "season requires league"}`, http.StatusBadRequest)
+			return
+		}
+		id, err := database.GetSeasonIDByName(database.DB, season, leagueID)
+		if err != nil {
+			log.Printf("GetStages: %v", err)
+			http.Error(w, `{"success": false, "error": "season not found"}`, http.StatusBadRequest)
+			return
+		}
+		seasonID = sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+
+	lang := requestLang(w, r)
+	stages, err := database.GetStages(database.DB, leagueID, seasonID,
+		localName(lang, "st.stage_name", "st.stage_name_en"), localName(lang, "l.name", "l.name_en"))
+	if err != nil {
+		log.Printf("GetStages: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch stages"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: stages})
+}
+
+// UpdateStage แก้ไขประเภท ลำดับ ชื่อภาษาอังกฤษ และ stage ถัดไป
+// PUT /api/stages/{id}  body: {"stage_type": "group", "stage_order": 1, "stage_name_en": "...", "next_stage_id": 12}
+func UpdateStage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid stage id"}`, http.StatusBadRequest)
+		return
+	}
+	var req database.StageUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"success": false, "error": "invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	if req.StageType != nil && !models.IsStageType(*req.StageType) {
+		http.Error(w, `{"success": false, "error": "stage_type must be regular_season, group, knockout or playoff"}`, http.StatusBadRequest)
+		return
+	}
+	err = database.UpdateStage(database.DB, id, req)
+	if err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "stage not found"}`, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, database.ErrInvalidNextStage) {
+		http.Error(w, `{"success": false, "error": "`+err.Error()+`"}`, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("UpdateStage: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to update stage"}`, http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: map[string]int{"id": id}})
+}
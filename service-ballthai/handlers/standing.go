@@ -4,9 +4,11 @@ import (
    "encoding/json"
    "go-ballthai-scraper/database"
    "go-ballthai-scraper/models"
+   calc "go-ballthai-scraper/standings"
    "net/http"
    "strconv"
    "database/sql"
+   "time"
 )
 
 // UpdateStandingsOrder อัปเดต current_rank ของ standings หลายรายการ
@@ -154,56 +156,46 @@ func splitPath(path string) []string {
 
 // GetStandings คืนข้อมูล standings ตาม league_id
 func GetStandings(w http.ResponseWriter, r *http.Request) {
-       // ใช้ leagueNameMap สำหรับชื่อเต็มลีก
-       leagueNameMap := map[string]string{
-	       "t1": "ไทยลีก 1",
-	       "t2": "ไทยลีก 2",
-	       "t3": "ไทยลีก 3",
-	       "fa": "FA Cup",
-	       "league_cup": "League Cup",
-	       "bgc": "BGC Cup",
-	       "samipro": "Samipro",
-	       "t1-jpy": "J1 League",
-		   "pea-u21": "PEA U-21 YOUNGSTER LEAGUE",
-       }
 	w.Header().Set("Content-Type", "application/json")
-       leagueIDStr := r.URL.Query().Get("league_id")
-       if leagueIDStr == "" {
+       // league_id รับได้ทั้งตัวเลข, slug (t1, samipro, pea-u21, ...), alias หรือชื่อลีก
+       leagueID, ok := leagueParam(w, r)
+       if !ok {
+	       return
+       }
+       if leagueID == 0 {
 	       http.Error(w, `{"success": false, "error": "league_id is required"}`, http.StatusBadRequest)
 	       return
        }
-       // รองรับ league_id เป็นตัวเลข หรือ t1/t2/t3 ที่มีใน leagueNameMap เท่านั้น
-       var leagueID int
        var err error
-	   if _, ok := leagueNameMap[leagueIDStr]; ok {
-		  // ถ้าเป็น t1/t2/t3/samipro ให้ map เป็นเลข
-		  switch leagueIDStr {
-		  case "t1": leagueID = 1
-		  case "t2": leagueID = 2
-		  case "t3": leagueID = 3
-		  case "samipro": leagueID = 59
-		  case "t1-jpy": leagueID = 60
-		  case "pea-u21": leagueID = 61
-		  default:
-			  http.Error(w, `{"success": false, "error": "invalid league_id"}`, http.StatusBadRequest)
-			  return
-		  }
-       } else {
-	       leagueID, err = strconv.Atoi(leagueIDStr)
-	       if err != nil {
-		       http.Error(w, `{"success": false, "error": "invalid league_id"}`, http.StatusBadRequest)
-		       return
-	       }
-       }
-	// ...existing code...
-       leagueName := ""
-       if name, ok := leagueNameMap[leagueIDStr]; ok {
-	       leagueName = name
+       // ชื่อลีกจากตาราง leagues (?lang=en / Accept-Language ใช้ name_en ถ้ามี)
+       leagueName, leagueNameEN, _ := database.GetLeagueNames(database.DB, leagueID)
+       lang := requestLang(w, r)
+       if lang == LangEN && leagueNameEN != "" {
+	       leagueName = leagueNameEN
        }
        // รองรับ stage (stage_id) จาก query string
 	stageStr := r.URL.Query().Get("stage")
+	// as_of=YYYY-MM-DD: คืนตารางคะแนน ณ วันที่ระบุจาก standing_snapshots
+	asOf := r.URL.Query().Get("as_of")
+	if asOf != "" {
+		if _, err := time.Parse("2006-01-02", asOf); err != nil {
+			http.Error(w, `{"success": false, "error": "invalid as_of (expected YYYY-MM-DD)"}`, http.StatusBadRequest)
+			return
+		}
+	}
 	var standings []models.StandingDB
-	if stageStr != "" {
+	if asOf != "" {
+		var stageID sql.NullInt64
+		if sID, err := strconv.ParseInt(stageStr, 10, 64); err == nil {
+			stageID = sql.NullInt64{Int64: sID, Valid: true}
+		}
+		standings, err = database.GetStandingsAsOf(database.DB, leagueID, stageID, asOf)
+		if err != nil {
+			println("[ERROR] GetStandingsAsOf:", err.Error())
+			http.Error(w, `{"success": false, "error": "failed to fetch standings snapshot"}`, http.StatusInternalServerError)
+			return
+		}
+	} else if stageStr != "" {
 	       // ถ้า stage เป็นตัวเลข ให้ filter ด้วย stage_id
 	       var stageID sql.NullInt64
 	       if sID, err := strconv.ParseInt(stageStr, 10, 64); err == nil {
@@ -228,6 +220,16 @@ func GetStandings(w http.ResponseWriter, r *http.Request) {
 		       return
 	       }
        }
+       // หัก/เพิ่มคะแนนตาราง sanctions บนคะแนนที่ดึงมา (points = หลังปรับ, raw_points = ก่อนปรับ)
+       seasonID, err := standingSeasonID(leagueID, asOf)
+       if err != nil {
+	       println("[ERROR] standingSeasonID:", err.Error())
+       }
+       sanctions, err := database.GetSanctions(database.DB, leagueID, seasonID, asOf)
+       if err != nil {
+	       println("[ERROR] GetSanctions:", err.Error())
+       }
+       adjustments := calc.AdjustStandings(standings, sanctions)
        // เพิ่ม stage_name ให้แต่ละ standing ถ้ามี stage_id
        type standingAPI struct {
 	       ID             int             `json:"id"`
@@ -242,31 +244,66 @@ func GetStandings(w http.ResponseWriter, r *http.Request) {
 	       GoalsAgainst   int             `json:"goals_against"`
 	       GoalDifference int             `json:"goal_difference"`
 	       Points         int             `json:"points"`
+	       RawPoints      int             `json:"raw_points"`
+	       PointsAdjustment int           `json:"points_adjustment"`
+	       Footnotes      []int           `json:"footnotes,omitempty"`
 	       CurrentRank    int             `json:"current_rank"`
 			   StageName      string          `json:"stage_name"`
 			   Status         sql.NullInt64   `json:"status"`
-			LogoHome       *string         `json:"logo_home"`
-			LogoAway       *string         `json:"logo_away"`
+			TeamLogo       *string         `json:"team_logo"`
 			TeamPostID     *string         `json:"team_post_id,omitempty"`
+			Form           []calc.FormEntry `json:"form"`
+			Streak         *calc.Streak     `json:"streak,omitempty"`
+			Home           *calc.Row        `json:"home,omitempty"`
+			Away           *calc.Row        `json:"away,omitempty"`
+			PointsPerGame  float64               `json:"points_per_game"`
+			standingZone
        }
-       var result []standingAPI
+       // ฟอร์ม 5 นัดล่าสุด, streak และสถิติเหย้า/เยือน คำนวณจาก matches ในขอบเขตเดียวกับตาราง
+       forms := map[int]*calc.TeamForm{}
+       formStage := sql.NullInt64{}
+       if sID, err := strconv.ParseInt(stageStr, 10, 64); err == nil {
+	       formStage = sql.NullInt64{Int64: sID, Valid: true}
+       }
+       if rules, err := database.GetStandingRules(database.DB, leagueID); err != nil {
+	       println("[ERROR] GetStandingRules:", err.Error())
+       } else if results, err := database.GetFinishedMatchResults(database.DB, leagueID, formStage, asOf); err != nil {
+	       println("[ERROR] GetFinishedMatchResults:", err.Error())
+       } else {
+	       forms = calc.BuildForms(results, rules, 5)
+       }
+       var namesEN map[int]string
+       if lang == LangEN {
+	       teamIDs := make([]int, len(standings))
+	       for i, s := range standings {
+		       teamIDs[i] = s.TeamID
+	       }
+	       if namesEN, err = database.GetTeamNamesEN(database.DB, teamIDs); err != nil {
+		       println("[ERROR] GetTeamNamesEN:", err.Error())
+	       }
+       }
+       teamStages := map[int][]int64{}
        for _, s := range standings {
-	       stageName := ""
+	       teamStages[s.TeamID] = append(teamStages[s.TeamID], s.StageID.Int64)
+       }
+       footnotes := sanctionFootnotes(lang, sanctions, teamStages, namesEN)
+       // โซน (แชมป์/AFC/เลื่อนชั้น/ตกชั้น) จาก standing_zones และ clinched/eliminated จากนัดที่เหลือ
+       rowZones, zoneLegend := standingZones(lang, leagueID, seasonID, formStage, standings, asOf == "")
+       var result []standingAPI
+       for i, s := range standings {
+	       stageLabel := ""
 	       if s.StageID.Valid {
-		       name, err := database.GetStageNameByID(database.DB, s.StageID.Int64)
-		       if err == nil {
-			       stageName = name
-		       }
+		       stageLabel = stageName(lang, s.StageID.Int64)
 	       }
 	       // fallback: ถ้า stageName ยังว่าง ให้ลองใช้ s.TeamName (กรณี scraper เคยบันทึก stage_name ลง DB โดยตรง)
-	       if stageName == "" && s.TeamName != nil {
+	       if stageLabel == "" && s.TeamName != nil {
 		       // ลอง parse จาก team_name ถ้ามีรูปแบบ "... (stage)" เช่น "ทีม A (โซนเหนือ)"
 		       tn := *s.TeamName
 		       if idx := len(tn) - 1; idx > 0 && tn[idx] == ')' {
 			       if open := idx - 1; open > 0 {
 				       for ; open >= 0 && tn[open] != '('; open-- {}
 				       if open >= 0 && open < idx-1 {
-					       stageName = tn[open+1 : idx]
+					       stageLabel = tn[open+1 : idx]
 				       }
 			       }
 		       }
@@ -281,7 +318,7 @@ func GetStandings(w http.ResponseWriter, r *http.Request) {
        			v := s.TeamPost.String
        			teamPostPtr = &v
        		}
-       		result = append(result, standingAPI{
+       		row := standingAPI{
 		       ID:             s.ID,
 		       LeagueID:       s.LeagueID,
 		       TeamID:         s.TeamID,
@@ -294,17 +331,99 @@ func GetStandings(w http.ResponseWriter, r *http.Request) {
 		       GoalsAgainst:   s.GoalsAgainst,
 		       GoalDifference: s.GoalDifference,
 		       Points:         s.Points,
+		       RawPoints:      s.Points - adjustments[i],
+		       PointsAdjustment: adjustments[i],
 		       CurrentRank:    currentRank,
-		       StageName:      stageName,
+		       StageName:      stageLabel,
 				   Status:         s.Status,
-				   LogoHome:       s.TeamLogo,
-				   LogoAway:       s.TeamLogo,
+				   TeamLogo:       s.TeamLogo,
 			   TeamPostID:     teamPostPtr,
-	       })
+			   Form:           []calc.FormEntry{},
+			   standingZone:   rowZones[i],
+	       }
+	       if name, ok := namesEN[s.TeamID]; ok {
+		       row.TeamName = &name
+	       }
+	       row.Footnotes = footnotesFor(footnotes, s.TeamID, s.StageID.Int64)
+	       if f, ok := forms[s.TeamID]; ok {
+		       row.Form = f.Last
+		       row.Streak = f.Streak
+		       row.Home = &f.Home
+		       row.Away = &f.Away
+		       row.PointsPerGame = f.PointsPerGame
+	       }
+	       result = append(result, row)
        }
-       json.NewEncoder(w).Encode(map[string]interface{}{
+       response := map[string]interface{}{
 	       "success": true,
 	       "data":    result,
 	       "league_name": leagueName,
-       })
+	       "zones": zoneLegend,
+	       "footnotes": footnotes,
+       }
+       if asOf != "" {
+	       response["as_of"] = asOf
+       }
+       json.NewEncoder(w).Encode(response)
+}
+
+// GetStandingsHistory คืนอันดับและคะแนนของทีมตามเวลา (GET /api/standings/history?league_id=&team_id=)
+func GetStandingsHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+	if leagueID == 0 {
+		http.Error(w, `{"success": false, "error": "league_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	teamID, ok := teamParam(w, r)
+	if !ok {
+		return
+	}
+	if teamID == 0 {
+		http.Error(w, `{"success": false, "error": "team_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	history, err := database.GetStandingHistory(database.DB, leagueID, teamID)
+	if err != nil {
+		println("[ERROR] GetStandingHistory:", err.Error())
+		http.Error(w, `{"success": false, "error": "failed to fetch standings history"}`, http.StatusInternalServerError)
+		return
+	}
+	type point struct {
+		Date          string `json:"date"`
+		Matchday      int    `json:"matchday"`
+		Rank          *int   `json:"rank"`
+		Points        int    `json:"points"`
+		MatchesPlayed int    `json:"matches_played"`
+		GoalDifference int   `json:"goal_difference"`
+		StageID       *int   `json:"stage_id,omitempty"`
+	}
+	series := make([]point, 0, len(history))
+	for _, h := range history {
+		p := point{
+			Date:           h.SnapshotDate,
+			Matchday:       h.Matchday,
+			Points:         h.Points,
+			MatchesPlayed:  h.MatchesPlayed,
+			GoalDifference: h.GoalDifference,
+		}
+		if h.CurrentRank.Valid {
+			v := int(h.CurrentRank.Int64)
+			p.Rank = &v
+		}
+		if h.StageID.Valid {
+			v := int(h.StageID.Int64)
+			p.StageID = &v
+		}
+		series = append(series, p)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"league_id": leagueID,
+		"team_id":   teamID,
+		"data":      series,
+	})
 }
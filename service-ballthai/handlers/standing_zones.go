@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/kickoff"
+	"go-ballthai-scraper/models"
+	calc "go-ballthai-scraper/standings"
+)
+
+// standingZone คือโซนของแถวในตารางคะแนน (ใส่ใน standingAPI ของ GetStandings)
+type standingZone struct {
+	Zone       *string  `json:"zone"`
+	ZoneColor  *string  `json:"zone_color"`
+	ZoneLabel  *string  `json:"zone_label"`
+	Clinched   []string `json:"clinched,omitempty"`
+	Eliminated []string `json:"eliminated,omitempty"`
+}
+
+// zoneLabel คืนชื่อโซนตามภาษา (ใช้ label_en เมื่อขอภาษาอังกฤษและมีค่า)
+func zoneLabel(lang string, z models.StandingZone) string {
+	if lang == LangEN && z.LabelEN != nil && *z.LabelEN != "" {
+		return *z.LabelEN
+	}
+	return z.Label
+}
+
+// standingZones คำนวณโซนของทุกแถว (ลำดับเดียวกับ rows) และคืนโซนที่ใช้กับขอบเขตที่ขอ (ไว้ทำ legend)
+// ตารางที่มีหลาย stage (เช่น T3 แต่ละโซน) จะคิดอันดับและโอกาสแยกตาม stage
+// seasonID คือฤดูกาลของตาราง (ดู standingSeasonID) ใช้เลือกชุดโซนและนับนัดที่เหลือ
+// withFlags = false (เช่น ตาราง as_of ย้อนหลัง) จะไม่คำนวณ clinched/eliminated
+func standingZones(lang string, leagueID int, seasonID, stageID sql.NullInt64, rows []models.StandingDB, withFlags bool) ([]standingZone, []models.StandingZone) {
+	out := make([]standingZone, len(rows))
+	zones, err := database.GetStandingZones(database.DB, leagueID, seasonID)
+	if err != nil {
+		log.Printf("standingZones: %v", err)
+		return out, []models.StandingZone{}
+	}
+	legend := calc.SelectZones(zones, int(seasonID.Int64), int(stageID.Int64))
+	if legend == nil {
+		legend = []models.StandingZone{}
+	}
+	if len(zones) == 0 {
+		return out, legend
+	}
+
+	var remaining map[int64]map[int]int
+	maxPerMatch := 3
+	seasonOver := false
+	if withFlags {
+		if remaining, err = database.GetRemainingFixtures(database.DB, leagueID, seasonID); err != nil {
+			log.Printf("standingZones: %v", err)
+			withFlags = false
+		}
+		if seasonOver, err = database.SeasonEnded(database.DB, seasonID, kickoff.Today(kickoff.ZoneOrDefault(""))); err != nil {
+			log.Printf("standingZones: %v", err)
+			withFlags = false
+		}
+		if rules, err := database.GetStandingRules(database.DB, leagueID); err == nil {
+			maxPerMatch = rules.PointsWin
+			if rules.PointsDraw > maxPerMatch {
+				maxPerMatch = rules.PointsDraw
+			}
+		}
+	}
+
+	// แบ่งแถวตาม stage โดยคงลำดับเดิม
+	groups := map[int64][]int{}
+	var order []int64
+	for i, s := range rows {
+		key := s.StageID.Int64
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	for _, key := range order {
+		idx := groups[key]
+		stageZones := calc.SelectZones(zones, int(seasonID.Int64), int(key))
+		size := len(idx)
+		table := make([]calc.Position, size)
+		for n, i := range idx {
+			s := rows[i]
+			rank := n + 1
+			if s.CurrentRank.Valid && s.CurrentRank.Int64 > 0 {
+				rank = int(s.CurrentRank.Int64)
+			}
+			table[n] = calc.Position{TeamID: s.TeamID, Rank: rank, Points: s.Points}
+			if z := calc.ZoneFor(stageZones, rank, size); z != nil {
+				label := zoneLabel(lang, *z)
+				out[i] = standingZone{Zone: &z.Zone, ZoneColor: &z.Color, ZoneLabel: &label}
+			}
+			if withFlags {
+				left := remaining[key][s.TeamID]
+				if key == 0 {
+					left = 0
+					for _, counts := range remaining {
+						left += counts[s.TeamID]
+					}
+				}
+				// ถ้ายังไม่มีโปรแกรมครบ ให้ถือว่าเตะเหย้า-เยือนกับทุกทีม (ประเมินนัดที่เหลือไว้สูงเสมอ)
+				if rr := 2*(size-1) - s.MatchesPlayed; !seasonOver && rr > left {
+					left = rr
+				}
+				table[n].Remaining = left
+			}
+		}
+		if !withFlags {
+			continue
+		}
+		flags := calc.Flags(table, stageZones, maxPerMatch, seasonOver)
+		for _, i := range idx {
+			f := flags[rows[i].TeamID]
+			out[i].Clinched, out[i].Eliminated = f.Clinched, f.Eliminated
+		}
+	}
+	return out, legend
+}
+
+// GetStandingZones คืนโซนของตารางคะแนน (แชมป์, โควตา AFC, เลื่อนชั้น, ตกชั้น) สำหรับทำ legend
+// GET /api/standing-zones?league=&stage=
+func GetStandingZones(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+	if leagueID == 0 {
+		http.Error(w, `{"success": false, "error": "league_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	// stage รับได้ทั้ง stage_id และชื่อ stage ของลีก
+	var stageID sql.NullInt64
+	if stage := r.URL.Query().Get("stage"); stage != "" {
+		id, err := strconv.Atoi(stage)
+		if err != nil {
+			if id, err = database.GetStageIDByName(database.DB, stage, leagueID); err != nil {
+				http.Error(w, `{"success": false, "error": "unknown stage"}`, http.StatusBadRequest)
+				return
+			}
+		}
+		stageID = sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+	seasonID, err := standingSeasonID(leagueID, "")
+	if err != nil {
+		log.Printf("GetStandingZones: %v", err)
+		http.Error(w, `{"success": false, "error": "Failed to fetch season"}`, http.StatusInternalServerError)
+		return
+	}
+	lang := requestLang(w, r)
+	_, zones := standingZones(lang, leagueID, seasonID, stageID, nil, false)
+	for i := range zones {
+		zones[i].Label = zoneLabel(lang, zones[i])
+	}
+	json.NewEncoder(w).Encode(APIResponse{Success: true, Data: zones})
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/models"
+	"go-ballthai-scraper/standings"
+)
+
+// computedStandingRow คือแถวตารางคะแนนที่คำนวณเองพร้อมชื่อทีม (points รวมคะแนนปรับจาก sanctions แล้ว)
+type computedStandingRow struct {
+	standings.Row
+	TeamName         string `json:"team_name"`
+	RawPoints        int    `json:"raw_points"`
+	PointsAdjustment int    `json:"points_adjustment"`
+	Footnotes        []int  `json:"footnotes,omitempty"`
+}
+
+// parseStandingScope อ่าน league_id, stage และ as_of จาก query string ที่ใช้ร่วมกันใน endpoint คำนวณตาราง
+// league/league_id รับได้ทั้ง id, slug, alias หรือชื่อลีก
+func parseStandingScope(r *http.Request) (leagueID int, stageID sql.NullInt64, asOf string, errMsg string) {
+	league := r.URL.Query().Get("league")
+	if league == "" {
+		league = r.URL.Query().Get("league_id")
+	}
+	if league == "" {
+		return 0, stageID, "", "league_id is required"
+	}
+	leagueID, err := database.ResolveLeagueID(database.DB, league)
+	if err != nil {
+		log.Printf("parseStandingScope: %v", err)
+		return 0, stageID, "", "unknown league"
+	}
+	if s := r.URL.Query().Get("stage"); s != "" {
+		sID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, stageID, "", "invalid stage"
+		}
+		stageID = sql.NullInt64{Int64: sID, Valid: true}
+	}
+	asOf = r.URL.Query().Get("as_of")
+	if asOf != "" {
+		if _, err := time.Parse("2006-01-02", asOf); err != nil {
+			return 0, stageID, "", "invalid as_of (expected YYYY-MM-DD)"
+		}
+	}
+	return leagueID, stageID, asOf, ""
+}
+
+// standingSeasonID คืนฤดูกาลของตารางคะแนน: ฤดูกาลที่ครอบคลุม as_of หรือฤดูกาลปัจจุบันเมื่อไม่ระบุ
+func standingSeasonID(leagueID int, asOf string) (sql.NullInt64, error) {
+	if asOf == "" {
+		return database.GetCurrentSeasonID(database.DB, leagueID)
+	}
+	return database.GetSeasonIDAt(database.DB, leagueID, asOf)
+}
+
+// computeLeagueTable โหลดกติกาและผลการแข่งขันแล้วคำนวณตารางของลีก/stage ณ วันที่ระบุ
+// adjustments (team_id -> คะแนนปรับ) ถูกบวกก่อนจัดอันดับ, nil = ตารางจากผลการแข่งขันล้วน
+func computeLeagueTable(db *sql.DB, leagueID int, stageID sql.NullInt64, asOf string, adjustments map[int]int, teamIDs ...int) ([]standings.Row, models.StandingRules, error) {
+	rules, err := database.GetStandingRules(db, leagueID)
+	if err != nil {
+		return nil, rules, err
+	}
+	results, err := database.GetFinishedMatchResults(db, leagueID, stageID, asOf)
+	if err != nil {
+		return nil, rules, err
+	}
+	return standings.ComputeAdjusted(results, rules, adjustments, teamIDs...), rules, nil
+}
+
+// GetComputedStandings คืนตารางคะแนนที่คำนวณจากผลใน matches
+// GET /api/standings/computed?league_id=&stage=&as_of=YYYY-MM-DD
+func GetComputedStandings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, stageID, asOf, errMsg := parseStandingScope(r)
+	if errMsg != "" {
+		http.Error(w, `{"success": false, "error": "`+errMsg+`"}`, http.StatusBadRequest)
+		return
+	}
+	seasonID, err := standingSeasonID(leagueID, asOf)
+	if err != nil {
+		log.Printf("GetComputedStandings: %v", err)
+	}
+	sanctions, err := database.GetSanctions(database.DB, leagueID, seasonID, asOf)
+	if err != nil {
+		log.Printf("GetComputedStandings: %v", err)
+	}
+	adjustments := standings.Adjustments(sanctions, stageID.Int64)
+	table, rules, err := computeLeagueTable(database.DB, leagueID, stageID, asOf, adjustments)
+	if err != nil {
+		log.Printf("GetComputedStandings: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to compute standings"}`, http.StatusInternalServerError)
+		return
+	}
+	teamIDs := make([]int, len(table))
+	for i, row := range table {
+		teamIDs[i] = row.TeamID
+	}
+	names, err := teamNames(requestLang(w, r), teamIDs)
+	if err != nil {
+		log.Printf("GetComputedStandings: %v", err)
+	}
+	teamStages := map[int][]int64{}
+	for _, id := range teamIDs {
+		teamStages[id] = []int64{stageID.Int64}
+	}
+	footnotes := sanctionFootnotes(requestLang(w, r), sanctions, teamStages, names)
+	data := make([]computedStandingRow, len(table))
+	for i, row := range table {
+		adj := adjustments[row.TeamID]
+		data[i] = computedStandingRow{Row: row, TeamName: names[row.TeamID], RawPoints: row.Points - adj, PointsAdjustment: adj,
+			Footnotes: footnotesFor(footnotes, row.TeamID, stageID.Int64)}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"data":      data,
+		"rules":     rules,
+		"as_of":     asOf,
+		"footnotes": footnotes,
+	})
+}
+
+// ReconcileStandings เทียบตาราง standings ที่ดึงมากับตารางที่คำนวณจาก matches และคืนเฉพาะแถวที่ไม่ตรงกัน
+// ถ้าไม่ระบุ stage จะเทียบแยกตามทุก stage ที่มีใน standings ของลีก
+// GET /api/standings/reconcile?league_id=&stage=
+func ReconcileStandings(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	leagueID, stageID, _, errMsg := parseStandingScope(r)
+	if errMsg != "" {
+		http.Error(w, `{"success": false, "error": "`+errMsg+`"}`, http.StatusBadRequest)
+		return
+	}
+	var scraped []models.StandingDB
+	var err error
+	if stageID.Valid {
+		scraped, err = database.GetStandingsByLeagueIDAndStageID(database.DB, leagueID, stageID)
+	} else {
+		scraped, err = database.GetStandingsByLeagueID(database.DB, leagueID)
+	}
+	if err != nil {
+		log.Printf("ReconcileStandings: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to fetch standings"}`, http.StatusInternalServerError)
+		return
+	}
+
+	// แยก standings ตาม stage เพื่อคำนวณเทียบทีละ stage
+	byStage := map[int64][]models.StandingDB{}
+	var order []int64
+	for _, s := range scraped {
+		key := int64(0)
+		if s.StageID.Valid {
+			key = s.StageID.Int64
+		}
+		if _, ok := byStage[key]; !ok {
+			order = append(order, key)
+		}
+		byStage[key] = append(byStage[key], s)
+	}
+
+	type stageReport struct {
+		StageID *int64                   `json:"stage_id"`
+		Rows    []standings.ReconcileRow `json:"rows"`
+	}
+	reports := []stageReport{}
+	mismatches := 0
+	for _, key := range order {
+		stage := sql.NullInt64{Int64: key, Valid: key != 0}
+		var teamIDs []int
+		for _, s := range byStage[key] {
+			teamIDs = append(teamIDs, s.TeamID)
+		}
+		table, _, err := computeLeagueTable(database.DB, leagueID, stage, "", nil, teamIDs...)
+		if err != nil {
+			log.Printf("ReconcileStandings: %v", err)
+			http.Error(w, `{"success": false, "error": "failed to compute standings"}`, http.StatusInternalServerError)
+			return
+		}
+		rows := standings.Reconcile(byStage[key], table)
+		mismatches += len(rows)
+		rep := stageReport{Rows: rows}
+		if stage.Valid {
+			v := stage.Int64
+			rep.StageID = &v
+		}
+		reports = append(reports, rep)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"league_id":  leagueID,
+		"mismatches": mismatches,
+		"data":       reports,
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"go-ballthai-scraper/database"
+	"go-ballthai-scraper/standings"
+)
+
+// GetTeamStats คืนสถิติของทีมในฤดูกาลที่ระบุ คำนวณจากตาราง matches และ player_season_stats
+// GET /api/teams/{id}/stats?season=&league_id=
+// ถ้าไม่ระบุ season จะนับทุกนัดที่มีผลแล้ว
+func GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	teamID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, `{"success": false, "error": "invalid team id"}`, http.StatusBadRequest)
+		return
+	}
+	leagueID, ok := leagueParam(w, r)
+	if !ok {
+		return
+	}
+
+	var teamName string
+	err = database.DB.QueryRow("SELECT name_th FROM teams WHERE id = ?", teamID).Scan(&teamName)
+	if err == sql.ErrNoRows {
+		http.Error(w, `{"success": false, "error": "team not found"}`, http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("GetTeamStats: %v", err)
+		http.Error(w, `{"success": false, "error": "database error"}`, http.StatusInternalServerError)
+		return
+	}
+
+	season := r.URL.Query().Get("season")
+	var from, to string
+	if season != "" {
+		from, to, err = database.GetSeasonDateRange(database.DB, season, leagueID)
+		if err != nil {
+			log.Printf("GetTeamStats: %v", err)
+			http.Error(w, `{"success": false, "error": "season not found"}`, http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := database.GetTeamFinishedResults(database.DB, teamID, leagueID, from, to)
+	if err != nil {
+		log.Printf("GetTeamStats: %v", err)
+		http.Error(w, `{"success": false, "error": "failed to load results"}`, http.StatusInternalServerError)
+		return
+	}
+	rules, err := database.GetStandingRules(database.DB, leagueID)
+	if err != nil {
+		log.Printf("GetTeamStats: %v", err)
+	}
+	stats := standings.BuildTeamStats(teamID, results, rules)
+
+	topScorer, err := database.GetTeamTopScorer(database.DB, teamID, leagueID, season)
+	if err != nil {
+		log.Printf("GetTeamStats: %v", err)
+	}
+	discipline, err := database.GetTeamDisciplineTotals(database.DB, teamID, leagueID, season)
+	if err != nil {
+		log.Printf("GetTeamStats: %v", err)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"data": map[string]interface{}{
+			"team_id":   teamID,
+			"team_name": teamName,
+			"season":    season,
+			"league_id": leagueID,
+			"stats":     stats,
+			// top_scorer และ discipline มาจาก player_season_stats ของฤดูกาล (ไม่ระบุ season = ทุกฤดูกาลที่เก็บไว้)
+			"top_scorer": topScorer,
+			"discipline": discipline,
+		},
+	})
+}
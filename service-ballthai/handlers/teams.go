@@ -24,6 +24,7 @@ func CreateTeam(w http.ResponseWriter, r *http.Request) {
 	var team struct {
 		Name       string `json:"name"`
 		NameTh     string `json:"name_th"`
+		NameEn     string `json:"name_en"`
 		StadiumID  *int   `json:"stadium_id"`
 		TeamPostID *int   `json:"team_post_id"`
 		LogoURL    string `json:"logo_url"`
@@ -67,11 +68,11 @@ func CreateTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := `
-		INSERT INTO teams (name_th, stadium_id, team_post_ballthai, logo_url)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO teams (name_th, name_en, stadium_id, team_post_ballthai, logo_url)
+		VALUES (?, NULLIF(?, ''), ?, ?, ?)
 	`
 
-	result, err := DB.Exec(query, team.NameTh, team.StadiumID, team.TeamPostID, normalizedLogo)
+	result, err := DB.Exec(query, team.NameTh, strings.TrimSpace(team.NameEn), team.StadiumID, team.TeamPostID, normalizedLogo)
 	if err != nil {
 		log.Printf("Error creating team: %v", err)
 		http.Error(w, `{"success": false, "error": "Failed to create team"}`, http.StatusInternalServerError)
@@ -79,6 +80,9 @@ func CreateTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id, _ := result.LastInsertId()
+	if err := database.AssignSlug(DB, "teams", int(id)); err != nil {
+		log.Printf("CreateTeam: %v", err)
+	}
 
 	response := APIResponse{
 		Success: true,
@@ -106,6 +110,7 @@ func UpdateTeam(w http.ResponseWriter, r *http.Request) {
 	var raw struct {
 		Name       *string          `json:"name"`
 		NameTh     *string          `json:"name_th"`
+		NameEn     *string          `json:"name_en"`
 		StadiumRaw *json.RawMessage `json:"stadium_id"`
 		TeamPostRaw *json.RawMessage `json:"team_post_id"`
 		LogoURL    *string          `json:"logo_url"`
@@ -179,6 +184,11 @@ func UpdateTeam(w http.ResponseWriter, r *http.Request) {
 		setParts = append(setParts, "name_th = ?")
 		args = append(args, *raw.Name)
 	}
+	if raw.NameEn != nil {
+		// ส่ง "" เพื่อล้างชื่อภาษาอังกฤษ (API จะกลับไปใช้ชื่อไทย)
+		setParts = append(setParts, "name_en = NULLIF(?, '')")
+		args = append(args, strings.TrimSpace(*raw.NameEn))
+	}
 	if stadiumID != nil {
 		setParts = append(setParts, "stadium_id = ?")
 		args = append(args, stadiumID)
@@ -272,16 +282,17 @@ func SearchTeams(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	lang := requestLang(w, r)
 	query := `
-		SELECT t.id, t.name_th, t.team_post_ballthai, t.stadium_id, s.name as stadium_name, 
+		SELECT t.id, ` + localName(lang, "t.name_th", "t.name_en") + `, t.name_th, t.name_en, t.team_post_ballthai, t.stadium_id, ` + localName(lang, "s.name", "s.name_en") + ` as stadium_name, 
 		       t.logo_url, NULL as established_year
 		FROM teams t 
 		LEFT JOIN stadiums s ON t.stadium_id = s.id
-		WHERE t.name_th LIKE ?
+		WHERE t.name_th LIKE ? OR t.name_en LIKE ?
 		ORDER BY t.name_th
 	`
 
-	rows, err := DB.Query(query, "%"+searchQuery+"%")
+	rows, err := DB.Query(query, "%"+searchQuery+"%", "%"+searchQuery+"%")
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Database error: %v", err), http.StatusInternalServerError)
 		return
@@ -291,7 +302,7 @@ func SearchTeams(w http.ResponseWriter, r *http.Request) {
 	var teams []Team
 	for rows.Next() {
 		var team Team
-		if err := rows.Scan(&team.ID, &team.NameTh, &team.TeamPostID, &team.StadiumID,
+		if err := rows.Scan(&team.ID, &team.Name, &team.NameTh, &team.NameEn, &team.TeamPostID, &team.StadiumID,
 			&team.StadiumName, &team.Logo, &team.EstablishedYear); err != nil {
 			http.Error(w, fmt.Sprintf("Scan error: %v", err), http.StatusInternalServerError)
 			return
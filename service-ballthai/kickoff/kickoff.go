@@ -0,0 +1,104 @@
+// Package kickoff แปลงเวลาเตะระหว่างเวลาท้องถิ่นของสนาม (start_date/start_time) กับเวลา UTC (matches.kickoff_at)
+// และสร้างเวลาที่แสดงตาม timezone ที่ผู้เรียกขอ
+package kickoff
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // ให้ใช้ IANA zone ได้แม้เครื่องไม่มี zoneinfo
+)
+
+// DefaultZone คือ timezone ของสนามเมื่อไม่ได้ระบุ และ timezone ที่ใช้ตีความ "วันนี้" โดยปริยาย
+const DefaultZone = "Asia/Bangkok"
+
+// DBLayout คือรูปแบบ DATETIME ที่ใช้อ่าน/เขียน kickoff_at (UTC เสมอ)
+const DBLayout = "2006-01-02 15:04:05"
+
+// Zone โหลด IANA zone ("" = DefaultZone)
+func Zone(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// ZoneOrDefault โหลด zone และใช้ DefaultZone เมื่อชื่อไม่ถูกต้อง
+func ZoneOrDefault(name string) *time.Location {
+	if loc, err := Zone(name); err == nil {
+		return loc
+	}
+	loc, _ := Zone(DefaultZone)
+	return loc
+}
+
+// parseClock รับ HH:MM หรือ HH:MM:SS
+func parseClock(clock string) (time.Time, error) {
+	if t, err := time.Parse("15:04:05", clock); err == nil {
+		return t, nil
+	}
+	return time.Parse("15:04", clock)
+}
+
+// UTC แปลงวันที่/เวลาเตะตามเวลาท้องถิ่นของสนามเป็นเวลา UTC ในรูปแบบ DBLayout
+func UTC(date, clock, zone string) (string, error) {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", fmt.Errorf("invalid start_date %q: %w", date, err)
+	}
+	c, err := parseClock(clock)
+	if err != nil {
+		return "", fmt.Errorf("invalid start_time %q: %w", clock, err)
+	}
+	loc, err := Zone(zone)
+	if err != nil {
+		return "", err
+	}
+	local := time.Date(d.Year(), d.Month(), d.Day(), c.Hour(), c.Minute(), c.Second(), 0, loc)
+	return local.UTC().Format(DBLayout), nil
+}
+
+// View คือเวลาเตะในผลลัพธ์ API
+type View struct {
+	KickoffAt     string `json:"kickoff_at"`     // RFC3339 (UTC)
+	VenueTimezone string `json:"venue_timezone"` // IANA zone ของสนาม
+	LocalDate     string `json:"local_date"`     // วันที่ตาม local_timezone
+	LocalTime     string `json:"local_time"`     // เวลา HH:MM ตาม local_timezone
+	LocalTimezone string `json:"local_timezone"` // timezone ที่ขอผ่าน ?tz=
+}
+
+// NewView สร้าง View จาก kickoff_at (DBLayout, UTC) คืน nil เมื่อไม่มีค่า
+func NewView(utc, venueZone string, display *time.Location) *View {
+	t, err := time.ParseInLocation(DBLayout, utc, time.UTC)
+	if err != nil {
+		return nil
+	}
+	if venueZone == "" {
+		venueZone = DefaultZone
+	}
+	local := t.In(display)
+	return &View{
+		KickoffAt:     t.Format(time.RFC3339),
+		VenueTimezone: venueZone,
+		LocalDate:     local.Format("2006-01-02"),
+		LocalTime:     local.Format("15:04"),
+		LocalTimezone: display.String(),
+	}
+}
+
+// DayRangeUTC คืนช่วง [from, to) เป็น UTC (DBLayout) ของวันที่ date ตาม loc
+func DayRangeUTC(date string, loc *time.Location) (from, to string, err error) {
+	d, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	return d.UTC().Format(DBLayout), d.AddDate(0, 0, 1).UTC().Format(DBLayout), nil
+}
+
+// Today คืนวันที่ปัจจุบันตาม loc (YYYY-MM-DD)
+func Today(loc *time.Location) string {
+	return time.Now().In(loc).Format("2006-01-02")
+}
@@ -0,0 +1,83 @@
+package kickoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUTC(t *testing.T) {
+	tests := []struct {
+		name, date, clock, zone string
+		want                    string
+		wantErr                 bool
+	}{
+		{"default zone is Bangkok", "2025-08-09", "19:00", "", "2025-08-09 12:00:00", false},
+		{"seconds are accepted", "2025-08-09", "19:00:30", "Asia/Bangkok", "2025-08-09 12:00:30", false},
+		{"early kick-off falls on the previous UTC day", "2025-08-09", "06:30", "Asia/Tokyo", "2025-08-08 21:30:00", false},
+		{"daylight saving is applied by date", "2025-07-01", "15:00", "Europe/London", "2025-07-01 14:00:00", false},
+		{"winter time in the same zone", "2025-01-15", "15:00", "Europe/London", "2025-01-15 15:00:00", false},
+		{"invalid date", "2025-13-01", "19:00", "", "", true},
+		{"invalid time", "2025-08-09", "7pm", "", "", true},
+		{"unknown zone", "2025-08-09", "19:00", "Mars/Olympus", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UTC(tt.date, tt.clock, tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UTC error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("UTC = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewView(t *testing.T) {
+	tokyo, _ := Zone("Asia/Tokyo")
+	tests := []struct {
+		name      string
+		utc, zone string
+		display   *time.Location
+		want      *View
+	}{
+		{
+			name: "shown in the requested zone", utc: "2025-08-09 12:00:00", zone: "", display: tokyo,
+			want: &View{KickoffAt: "2025-08-09T12:00:00Z", VenueTimezone: DefaultZone,
+				LocalDate: "2025-08-09", LocalTime: "21:00", LocalTimezone: "Asia/Tokyo"},
+		},
+		{
+			name: "local date can differ from the UTC date", utc: "2025-08-09 16:30:00", zone: "Asia/Bangkok", display: ZoneOrDefault(""),
+			want: &View{KickoffAt: "2025-08-09T16:30:00Z", VenueTimezone: "Asia/Bangkok",
+				LocalDate: "2025-08-09", LocalTime: "23:30", LocalTimezone: "Asia/Bangkok"},
+		},
+		{name: "missing kickoff_at", utc: "", display: tokyo, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewView(tt.utc, tt.zone, tt.display)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("NewView = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayRangeUTC(t *testing.T) {
+	from, to, err := DayRangeUTC("2025-08-09", ZoneOrDefault("Asia/Bangkok"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != "2025-08-08 17:00:00" || to != "2025-08-09 17:00:00" {
+		t.Errorf("DayRangeUTC = [%s, %s)", from, to)
+	}
+	if _, _, err := DayRangeUTC("09/08/2025", time.UTC); err == nil {
+		t.Error("DayRangeUTC accepted an invalid date")
+	}
+}
+
+func TestZoneOrDefault(t *testing.T) {
+	if got := ZoneOrDefault("Not/AZone").String(); got != DefaultZone {
+		t.Errorf("ZoneOrDefault(invalid) = %s, want %s", got, DefaultZone)
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import "database/sql"
+
+// KnockoutRoundDB คือหนึ่งรอบในสายการแข่งขันถ้วย
+type KnockoutRoundDB struct {
+	ID         int
+	LeagueID   int
+	SeasonID   sql.NullInt64
+	StageID    int
+	StageName  string
+	RoundOrder sql.NullInt64
+	Legs       int
+	AwayGoals  bool
+	FirstDate  sql.NullString // วันที่นัดแรกของรอบ ใช้เรียงเมื่อไม่ได้กำหนด round_order
+}
+
+// KnockoutLeg คือหนึ่งแมตช์ (เลก) ของคู่น็อกเอาต์
+type KnockoutLeg struct {
+	MatchID    int    `json:"match_id"`
+	StartDate  string `json:"start_date"`
+	HomeTeamID int    `json:"home_team_id"`
+	AwayTeamID int    `json:"away_team_id"`
+	HomeScore  *int   `json:"home_score"`
+	AwayScore  *int   `json:"away_score"`
+	// สกอร์หลังต่อเวลาและยิงจุดโทษจากตาราง matches (nil = ไม่มี)
+	HomeScoreET   *int           `json:"home_score_et,omitempty"`
+	AwayScoreET   *int           `json:"away_score_et,omitempty"`
+	HomePenalties *int           `json:"home_penalties,omitempty"`
+	AwayPenalties *int           `json:"away_penalties,omitempty"`
+	DecidedBy     *string        `json:"decided_by,omitempty"`
+	Finished      bool           `json:"finished"`
+	KickoffAt     sql.NullString `json:"-"` // UTC (kickoff.DBLayout)
+	Timezone      sql.NullString `json:"-"`
+}
+
+// KnockoutTieDB คือคู่การแข่งขันหนึ่งคู่ (หนึ่งหรือสองเลก)
+type KnockoutTieDB struct {
+	ID              int
+	RoundID         int
+	BracketPosition sql.NullInt64
+	TeamAID         sql.NullInt64
+	TeamBID         sql.NullInt64
+	Leg1            *KnockoutLeg
+	Leg2            *KnockoutLeg
+	ExtraTime       bool
+	PenaltiesA      sql.NullInt64
+	PenaltiesB      sql.NullInt64
+	WinnerTeamID    sql.NullInt64
+	NextTieID       sql.NullInt64
+	NextSlot        sql.NullString
+}
@@ -29,3 +29,16 @@ type ClubNameAPI struct {
 type BaseDB struct {
 	ID int
 }
+
+// NameSuggestionDB คือชื่อภาษาอังกฤษ/slug ที่ถอดจากชื่อไทยอัตโนมัติ รอผู้ดูแลตรวจ
+type NameSuggestionDB struct {
+	ID            int     `json:"id"`
+	EntityType    string  `json:"entity_type"`
+	EntityID      int     `json:"entity_id"`
+	SourceName    string  `json:"source_name"`
+	SuggestedName string  `json:"suggested_name"`
+	SuggestedSlug string  `json:"suggested_slug"`
+	Status        string  `json:"status"`
+	CreatedAt     string  `json:"created_at"`
+	ReviewedAt    *string `json:"reviewed_at"`
+}
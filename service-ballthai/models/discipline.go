@@ -0,0 +1,45 @@
+package models
+
+// DisciplineRules คือเกณฑ์โทษแบนและคะแนน fair play ของรายการแข่งขัน
+type DisciplineRules struct {
+	LeagueID             int `json:"league_id"`
+	YellowThreshold      int `json:"yellow_threshold"`
+	YellowBanMatches     int `json:"yellow_ban_matches"`
+	RedBanMatches        int `json:"red_ban_matches"`
+	FairPlayYellowPoints int `json:"fair_play_yellow_points"`
+	FairPlayRedPoints    int `json:"fair_play_red_points"`
+}
+
+// SuspensionDB คือโทษแบนหนึ่งรายการ พร้อมจำนวนนัดที่รับโทษไปแล้ว
+type SuspensionDB struct {
+	ID            int     `json:"id"`
+	PlayerID      int     `json:"player_id"`
+	PlayerName    string  `json:"player_name"`
+	TeamID        *int    `json:"team_id"`
+	TeamName      *string `json:"team_name"`
+	Reason        string  `json:"reason"`
+	CardCount     int     `json:"card_count"`
+	MatchesBanned int     `json:"matches_banned"`
+	MatchesServed int     `json:"matches_served"`
+	IncurredDate  string  `json:"incurred_date"`
+}
+
+// PlayerBookingDB คือผู้เล่นพร้อมจำนวนใบสะสม (ใช้แสดงรายชื่อที่ใกล้ถูกแบน)
+type PlayerBookingDB struct {
+	PlayerID    int     `json:"player_id"`
+	PlayerName  string  `json:"player_name"`
+	TeamID      *int    `json:"team_id"`
+	TeamName    *string `json:"team_name"`
+	YellowCards int     `json:"yellow_cards"`
+	RedCards    int     `json:"red_cards"`
+}
+
+// FairPlayRow คือหนึ่งแถวในตาราง fair play (คะแนนน้อยกว่าดีกว่า)
+type FairPlayRow struct {
+	Rank        int    `json:"rank"`
+	TeamID      int    `json:"team_id"`
+	TeamName    string `json:"team_name"`
+	YellowCards int    `json:"yellow_cards"`
+	RedCards    int    `json:"red_cards"`
+	Points      int    `json:"points"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"database/sql"
+
+	"go-ballthai-scraper/kickoff"
+)
+
+// ResolveMatchTimezone หา IANA zone ของแมตช์: สนามของทีมเหย้า > ลีก > kickoff.DefaultZone
+func ResolveMatchTimezone(db *sql.DB, leagueID, homeTeamID sql.NullInt64) string {
+	var zone sql.NullString
+	err := db.QueryRow(`
+		SELECT COALESCE(
+			(SELECT s.timezone FROM teams t JOIN stadiums s ON s.id = t.stadium_id WHERE t.id = ?),
+			(SELECT timezone FROM leagues WHERE id = ?))`, homeTeamID, leagueID).Scan(&zone)
+	if err != nil || !zone.Valid || zone.String == "" {
+		return kickoff.DefaultZone
+	}
+	if _, err := kickoff.Zone(zone.String); err != nil {
+		return kickoff.DefaultZone
+	}
+	return zone.String
+}
+
+// MatchKickoff คืน timezone ของแมตช์และ kickoff_at (UTC) จากวันที่/เวลาท้องถิ่นของสนาม
+func MatchKickoff(db *sql.DB, leagueID, homeTeamID sql.NullInt64, date, clock string) (zone string, kickoffAt sql.NullString) {
+	zone = ResolveMatchTimezone(db, leagueID, homeTeamID)
+	if utc, err := kickoff.UTC(date, clock, zone); err == nil {
+		kickoffAt = sql.NullString{String: utc, Valid: true}
+	}
+	return zone, kickoffAt
+}
+
+// nullID แปลง id (0 = ไม่มี) เป็น sql.NullInt64
+func nullID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id > 0}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "database/sql"
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+)
 
 // LeagueDB represents the structure of the 'leagues' table in the database
 type LeagueDB struct {
@@ -8,3 +12,101 @@ type LeagueDB struct {
 	Name        string
 	ThaileageID sql.NullInt64
 }
+
+// ประเภทรายการแข่งขันของลีก (leagues.competition_type)
+const (
+	CompetitionLeague   = "league"
+	CompetitionCup      = "cup"
+	CompetitionSuperCup = "super_cup"
+)
+
+// เพศของรายการแข่งขัน (leagues.gender)
+const (
+	GenderMen   = "men"
+	GenderWomen = "women"
+)
+
+var (
+	countryPattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	ageGroupPattern = regexp.MustCompile(`^U[0-9]{2}$`)
+	colorPattern    = regexp.MustCompile(`^#[0-9A-F]{6}$`)
+)
+
+// LeagueMetadata คือข้อมูลประกอบของลีกที่แก้ไขได้จาก /leagues.html
+// ค่า nil คือไม่มีข้อมูล (tier ของถ้วย, age_group ของชุดใหญ่)
+type LeagueMetadata struct {
+	CompetitionType string  `json:"competition_type"`
+	Tier            *int    `json:"tier"`
+	Country         *string `json:"country"`   // ISO 3166-1 alpha-2 เช่น TH, JP
+	Gender          string  `json:"gender"`    // men | women
+	AgeGroup        *string `json:"age_group"` // เช่น U21
+	LogoURL         *string `json:"logo_url"`
+	SponsorName     *string `json:"sponsor_name"`
+	PrimaryColor    *string `json:"primary_color"`   // #RRGGBB
+	SecondaryColor  *string `json:"secondary_color"` // #RRGGBB
+	SortOrder       int     `json:"sort_order"`
+	MinRestHours    *int    `json:"min_rest_hours"` // เวลาพักขั้นต่ำระหว่างสองนัดของทีม nil = DefaultMinRestHours
+}
+
+// DefaultLeagueMetadata คือค่าเริ่มต้นของลีกใหม่ (ลีกชายชุดใหญ่)
+func DefaultLeagueMetadata() LeagueMetadata {
+	return LeagueMetadata{CompetitionType: CompetitionLeague, Gender: GenderMen}
+}
+
+// normaliseOptional ตัดช่องว่าง แปลงตัวพิมพ์ และให้สตริงว่างเป็น nil
+func normaliseOptional(s *string, upper bool) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if upper {
+		v = strings.ToUpper(v)
+	}
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// Validate ปรับรูปแบบค่า (ตัวพิมพ์, ช่องว่าง) แล้วตรวจความถูกต้องของข้อมูลประกอบลีก
+func (m *LeagueMetadata) Validate() ValidationErrors {
+	var errs ValidationErrors
+	m.CompetitionType = strings.ToLower(strings.TrimSpace(m.CompetitionType))
+	m.Gender = strings.ToLower(strings.TrimSpace(m.Gender))
+	m.Country = normaliseOptional(m.Country, true)
+	m.AgeGroup = normaliseOptional(m.AgeGroup, true)
+	m.LogoURL = normaliseOptional(m.LogoURL, false)
+	m.SponsorName = normaliseOptional(m.SponsorName, false)
+	m.PrimaryColor = normaliseOptional(m.PrimaryColor, true)
+	m.SecondaryColor = normaliseOptional(m.SecondaryColor, true)
+
+	switch m.CompetitionType {
+	case CompetitionLeague, CompetitionCup, CompetitionSuperCup:
+	default:
+		errs.add("competition_type", "must be league, cup or super_cup")
+	}
+	if m.Tier != nil && (*m.Tier < 1 || *m.Tier > 20) {
+		errs.add("tier", "must be between 1 and 20")
+	}
+	if m.Country != nil && !countryPattern.MatchString(*m.Country) {
+		errs.add("country", "must be a 2-letter ISO country code")
+	}
+	switch m.Gender {
+	case GenderMen, GenderWomen:
+	default:
+		errs.add("gender", "must be men or women")
+	}
+	if m.AgeGroup != nil && !ageGroupPattern.MatchString(*m.AgeGroup) {
+		errs.add("age_group", "must look like U21 (empty = senior)")
+	}
+	if m.PrimaryColor != nil && !colorPattern.MatchString(*m.PrimaryColor) {
+		errs.add("primary_color", "must be #RRGGBB")
+	}
+	if m.SecondaryColor != nil && !colorPattern.MatchString(*m.SecondaryColor) {
+		errs.add("secondary_color", "must be #RRGGBB")
+	}
+	if m.MinRestHours != nil && (*m.MinRestHours < 1 || *m.MinRestHours > 14*24) {
+		errs.add("min_rest_hours", "must be between 1 and 336")
+	}
+	return errs
+}
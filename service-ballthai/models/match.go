@@ -3,6 +3,8 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -49,6 +51,41 @@ type MatchDB struct {
 	HomeScore     sql.NullInt64
 	AwayScore     sql.NullInt64
 	MatchStatus   sql.NullString
+	HomeScoreHT   sql.NullInt64 // สกอร์ครึ่งแรก
+	AwayScoreHT   sql.NullInt64
+	HomeScoreET   sql.NullInt64 // สกอร์รวมหลังต่อเวลา
+	AwayScoreET   sql.NullInt64
+	HomePenalties sql.NullInt64 // ยิงจุดโทษ
+	AwayPenalties sql.NullInt64
+	DecidedBy     sql.NullString
+	Timezone      sql.NullString // IANA zone ของสนาม
+	KickoffAt     sql.NullString // เวลาเตะ UTC (kickoff.DBLayout)
+}
+
+// วิธีที่ตัดสินผลของแมตช์ (matches.decided_by)
+const (
+	DecidedByRegularTime = "regular_time"
+	DecidedByExtraTime   = "extra_time"
+	DecidedByPenalties   = "penalties"
+)
+
+// ValidDecidedBy ตรวจว่าค่า decided_by ถูกต้อง
+func ValidDecidedBy(s string) bool {
+	return s == DecidedByRegularTime || s == DecidedByExtraTime || s == DecidedByPenalties
+}
+
+// InferDecidedBy หาวิธีตัดสินจากสกอร์ที่มี: มีผลยิงจุดโทษ = penalties, มีสกอร์ต่อเวลา = extra_time,
+// มีสกอร์ปกติ = regular_time และคืน "" เมื่อยังไม่มีผล
+func InferDecidedBy(homeScore, awayScore, homeET, awayET, homePens, awayPens *int) string {
+	switch {
+	case homePens != nil && awayPens != nil:
+		return DecidedByPenalties
+	case homeET != nil && awayET != nil:
+		return DecidedByExtraTime
+	case homeScore != nil && awayScore != nil:
+		return DecidedByRegularTime
+	}
+	return ""
 }
 
 // MatchInsertRequest represents the structure for inserting a new match
@@ -64,32 +101,63 @@ type MatchInsertRequest struct {
 	MatchStatus   string `json:"match_status"`
 	ChannelID     *int   `json:"channel_id"`
 	LiveChannelID *int   `json:"live_channel_id"`
+	MatchScoreDetail
+}
+
+func (req MatchInsertRequest) input() MatchInput {
+	return MatchInput{
+		LeagueID: req.LeagueID, StageID: &req.StageID, StartDate: req.StartDate, StartTime: req.StartTime,
+		HomeTeamID: req.HomeTeamID, AwayTeamID: req.AwayTeamID, HomeScore: req.HomeScore, AwayScore: req.AwayScore,
+	}
 }
 
 // InsertMatch inserts a new match into the database
+// match_status ตรวจด้วย ResolveMatchStatus (ว่าง = scheduled) คืน ErrInvalidMatchStatus เมื่อไม่ถูกต้อง
 func InsertMatch(db *sql.DB, req MatchInsertRequest) error {
 	log.Printf("InsertMatch payload: %+v\n", req)
+	status, msg := ResolveMatchStatus(req.MatchStatus, "", false)
+	if msg != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidMatchStatus, msg)
+	}
+	req.MatchStatus = string(status)
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	matchRefID, err := AllocateManualMatchRefs(tx, 1)
+	if err != nil {
+		return err
+	}
+	zone, kickoffAt := MatchKickoff(db, nullID(req.LeagueID), nullID(req.HomeTeamID), req.StartDate, req.StartTime)
 	sqlStr := `
 		INSERT INTO matches (
-			league_id, stage_id, start_date, start_time,
+			match_ref_id, league_id, stage_id, start_date, start_time,
 			home_team_id, away_team_id, home_score, away_score,
-			match_status, channel_id, live_channel_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			match_status, channel_id, live_channel_id,
+			home_score_ht, away_score_ht, home_score_et, away_score_et,
+			home_penalties, away_penalties, decided_by, timezone, kickoff_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	args := []interface{}{
-		req.LeagueID, req.StageID, req.StartDate, req.StartTime,
+		matchRefID, req.LeagueID, req.StageID, req.StartDate, req.StartTime,
 		req.HomeTeamID, req.AwayTeamID, req.HomeScore, req.AwayScore,
 		req.MatchStatus, req.ChannelID, req.LiveChannelID,
+		req.HomeScoreHT, req.AwayScoreHT, req.HomeScoreET, req.AwayScoreET,
+		req.HomePenalties, req.AwayPenalties, req.DecidedByArg(), zone, kickoffAt,
 	}
 	log.Printf("InsertMatch args: %+v\n", args)
-	res, err := db.Exec(sqlStr, args...)
+	res, err := tx.Exec(sqlStr, args...)
 	if err != nil {
 		log.Printf("InsertMatch error: %v\n", err)
-	} else {
-		id, _ := res.LastInsertId()
-		log.Printf("InsertMatch success, inserted id: %d\n", id)
+		return err
 	}
-	return err
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	id, _ := res.LastInsertId()
+	log.Printf("InsertMatch success, inserted id: %d\n", id)
+	return nil
 }
 
 // MatchUpdateRequest represents the structure for updating a match
@@ -106,21 +174,47 @@ type MatchUpdateRequest struct {
 	MatchStatus   string `json:"match_status"`
 	ChannelID     *int   `json:"channel_id"`
 	LiveChannelID *int   `json:"live_channel_id"`
+	ForceStatus   bool   `json:"force_status"`
+	MatchScoreDetail
+}
+
+func (req MatchUpdateRequest) input() MatchInput {
+	return MatchInput{
+		ID: req.ID, LeagueID: req.LeagueID, StageID: &req.StageID, StartDate: req.StartDate, StartTime: req.StartTime,
+		HomeTeamID: req.HomeTeamID, AwayTeamID: req.AwayTeamID, HomeScore: req.HomeScore, AwayScore: req.AwayScore,
+	}
 }
 
 // UpdateMatch updates a match in the database
+// match_status ว่าง = คงสถานะเดิม, ForceStatus = ข้ามการตรวจลำดับสถานะ (เหมือน PUT /api/matches/{id} ของ handlers)
 func UpdateMatch(db *sql.DB, req MatchUpdateRequest) error {
+	var current string
+	if err := db.QueryRow("SELECT match_status FROM matches WHERE id = ?", req.ID).Scan(&current); err != nil {
+		return err
+	}
+	status, msg := ResolveMatchStatus(req.MatchStatus, MatchStatus(current), req.ForceStatus)
+	if msg != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidMatchStatus, msg)
+	}
+	req.MatchStatus = string(status)
+	zone, kickoffAt := MatchKickoff(db, nullID(req.LeagueID), nullID(req.HomeTeamID), req.StartDate, req.StartTime)
 	sqlStr := `
 		UPDATE matches SET
 			league_id = ?, stage_id = ?, start_date = ?, start_time = ?,
 			home_team_id = ?, away_team_id = ?, home_score = ?, away_score = ?,
-			match_status = ?, channel_id = ?, live_channel_id = ?
+			match_status = ?, channel_id = ?, live_channel_id = ?,
+			home_score_ht = ?, away_score_ht = ?, home_score_et = ?, away_score_et = ?,
+			home_penalties = ?, away_penalties = ?, decided_by = ?,
+			timezone = ?, kickoff_at = ?
 		WHERE id = ?
 	`
 	args := []interface{}{
 		req.LeagueID, req.StageID, req.StartDate, req.StartTime,
 		req.HomeTeamID, req.AwayTeamID, req.HomeScore, req.AwayScore,
-		req.MatchStatus, req.ChannelID, req.LiveChannelID, req.ID,
+		req.MatchStatus, req.ChannelID, req.LiveChannelID,
+		req.HomeScoreHT, req.AwayScoreHT, req.HomeScoreET, req.AwayScoreET,
+		req.HomePenalties, req.AwayPenalties, req.DecidedByArg(),
+		zone, kickoffAt, req.ID,
 	}
 	_, err := db.Exec(sqlStr, args...)
 	return err
@@ -131,7 +225,10 @@ func GetMatchByID(db *sql.DB, id int) (*MatchDB, error) {
 	sqlStr := `
 		SELECT id, match_ref_id, start_date, start_time, league_id, stage_id,
 			home_team_id, away_team_id, channel_id, live_channel_id,
-			home_score, away_score, match_status
+			home_score, away_score, match_status,
+			home_score_ht, away_score_ht, home_score_et, away_score_et,
+			home_penalties, away_penalties, decided_by,
+			timezone, DATE_FORMAT(kickoff_at, '%Y-%m-%d %H:%i:%s')
 		FROM matches WHERE id = ?
 	`
 	var match MatchDB
@@ -139,6 +236,9 @@ func GetMatchByID(db *sql.DB, id int) (*MatchDB, error) {
 		&match.ID, &match.MatchRefID, &match.StartDate, &match.StartTime,
 		&match.LeagueID, &match.StageID, &match.HomeTeamID, &match.AwayTeamID,
 		&match.ChannelID, &match.LiveChannelID, &match.HomeScore, &match.AwayScore, &match.MatchStatus,
+		&match.HomeScoreHT, &match.AwayScoreHT, &match.HomeScoreET, &match.AwayScoreET,
+		&match.HomePenalties, &match.AwayPenalties, &match.DecidedBy,
+		&match.Timezone, &match.KickoffAt,
 	)
 	if err != nil {
 		return nil, err
@@ -155,7 +255,23 @@ func MatchCreateHandler(db *sql.DB) http.HandlerFunc {
 			return
 		}
 		log.Printf("Handler decoded req: %+v\n", req)
-		if err := InsertMatch(db, req); err != nil {
+		if msg := req.Validate(req.HomeScore, req.AwayScore); msg != "" {
+			http.Error(w, msg, http.StatusBadRequest)
+			return
+		}
+		errs, err := ValidateMatch(db, req.input())
+		if err != nil {
+			http.Error(w, "Validation failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if errs != nil {
+			WriteValidationErrors(w, errs)
+			return
+		}
+		if err := InsertMatch(db, req); errors.Is(err, ErrInvalidMatchStatus) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
 			log.Printf("Handler InsertMatch error: %v\n", err)
 			http.Error(w, "Insert failed: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -229,7 +345,26 @@ func MatchUpdateHandler(db *sql.DB) http.HandlerFunc {
 			http.Error(w, "Missing match id", http.StatusBadRequest)
 			return
 		}
-		if err := UpdateMatch(db, req); err != nil {
+		if msg := req.Validate(req.HomeScore, req.AwayScore); msg != "" {
+			http.Error(w, msg, http.StatusBadRequest)
+			return
+		}
+		errs, err := ValidateMatch(db, req.input())
+		if err != nil {
+			http.Error(w, "Validation failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if errs != nil {
+			WriteValidationErrors(w, errs)
+			return
+		}
+		if err := UpdateMatch(db, req); errors.Is(err, ErrInvalidMatchStatus) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		} else if err == sql.ErrNoRows {
+			http.Error(w, "Match not found", http.StatusNotFound)
+			return
+		} else if err != nil {
 			http.Error(w, "Update failed: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -244,7 +379,10 @@ func MatchListHandler(db *sql.DB) http.HandlerFunc {
 		sqlStr := `
 			SELECT id, match_ref_id, start_date, start_time, league_id, stage_id,
 				home_team_id, away_team_id, channel_id, live_channel_id,
-				home_score, away_score, match_status
+				home_score, away_score, match_status,
+				home_score_ht, away_score_ht, home_score_et, away_score_et,
+				home_penalties, away_penalties, decided_by,
+				timezone, DATE_FORMAT(kickoff_at, '%Y-%m-%d %H:%i:%s')
 			FROM matches
 			ORDER BY start_date DESC, start_time DESC
 		`
@@ -266,6 +404,9 @@ func MatchListHandler(db *sql.DB) http.HandlerFunc {
 				&match.ID, &match.MatchRefID, &match.StartDate, &match.StartTime,
 				&match.LeagueID, &match.StageID, &match.HomeTeamID, &match.AwayTeamID,
 				&match.ChannelID, &match.LiveChannelID, &match.HomeScore, &match.AwayScore, &match.MatchStatus,
+				&match.HomeScoreHT, &match.AwayScoreHT, &match.HomeScoreET, &match.AwayScoreET,
+				&match.HomePenalties, &match.AwayPenalties, &match.DecidedBy,
+				&match.Timezone, &match.KickoffAt,
 			)
 			if err != nil {
 				continue
@@ -321,3 +462,21 @@ func MatchDeleteHandler(db *sql.DB) http.HandlerFunc {
 		})
 	}
 }
+
+// TeamMeetingDB is one match between two specific teams (used for head-to-head)
+type TeamMeetingDB struct {
+	ID           int            `json:"id"`
+	StartDate    string         `json:"start_date"`
+	StartTime    string         `json:"start_time"`
+	LeagueID     sql.NullInt64  `json:"-"`
+	LeagueName   sql.NullString `json:"-"`
+	LeagueNameEN sql.NullString `json:"-"`
+	HomeTeamID   int            `json:"home_team_id"`
+	AwayTeamID   int            `json:"away_team_id"`
+	HomeScore    sql.NullInt64  `json:"-"`
+	AwayScore    sql.NullInt64  `json:"-"`
+	MatchStatus  sql.NullString `json:"-"`
+	Finished     bool           `json:"finished"`
+	KickoffAt    sql.NullString `json:"-"` // UTC (kickoff.DBLayout)
+	Timezone     sql.NullString `json:"-"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// ประเภทของโปรแกรมที่ชนกัน (match_conflicts.conflict_type)
+const (
+	ConflictRest    = "rest"    // ทีมลงเตะสองนัดห่างกันน้อยกว่าเวลาพักขั้นต่ำของลีก
+	ConflictStadium = "stadium" // สนามเดียวกันมีมากกว่าหนึ่งนัดในวันเดียวกัน
+	ConflictChannel = "channel" // ช่องถ่ายทอดเดียวกันมีสองนัดในช่วงเวลาที่ทับกัน
+	ConflictSeason  = "season"  // แมตช์อยู่นอกช่วงวันที่ของฤดูกาล
+)
+
+// DefaultMinRestHours คือเวลาพักขั้นต่ำเมื่อลีกไม่ได้ตั้ง min_rest_hours
+const DefaultMinRestHours = 48
+
+// MatchConflict represents the 'match_conflicts' table: ผลการตรวจโปรแกรมชนกันของแมตช์หนึ่งนัด
+// MatchID คือนัดที่เตะทีหลัง OtherMatchID คือนัดก่อนหน้าที่ชนกัน (nil สำหรับ season)
+type MatchConflict struct {
+	ID           int    `json:"id"`
+	Type         string `json:"type"`
+	MatchID      int    `json:"match_id"`
+	OtherMatchID *int   `json:"other_match_id"`
+	SubjectID    *int   `json:"subject_id"` // ทีม (rest), สนาม (stadium), ช่อง (channel)
+	Detail       string `json:"detail"`
+	DetectedAt   string `json:"detected_at,omitempty"`
+
+	// ข้อมูลประกอบสำหรับแสดงผล (อ่านจาก GetMatchConflicts)
+	LeagueID    *int    `json:"league_id,omitempty"`
+	LeagueName  *string `json:"league_name,omitempty"`
+	StartDate   string  `json:"start_date,omitempty"`
+	StartTime   string  `json:"start_time,omitempty"`
+	HomeTeam    *string `json:"home_team,omitempty"`
+	AwayTeam    *string `json:"away_team,omitempty"`
+	OtherDate   *string `json:"other_start_date,omitempty"`
+	OtherTime   *string `json:"other_start_time,omitempty"`
+	OtherHome   *string `json:"other_home_team,omitempty"`
+	OtherAway   *string `json:"other_away_team,omitempty"`
+	SubjectName *string `json:"subject_name,omitempty"`
+}
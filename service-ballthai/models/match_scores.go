@@ -0,0 +1,71 @@
+package models
+
+// MatchScoreDetail คือสกอร์เพิ่มเติมของแมตช์ (ครึ่งแรก ต่อเวลา ยิงจุดโทษ) ใช้ร่วมกันใน CreateMatch/UpdateMatch
+// ของ handlers และ MatchInsertRequest/MatchUpdateRequest
+// decided_by ว่าง = หาจากสกอร์ที่กรอก
+type MatchScoreDetail struct {
+	HomeScoreHT   *int   `json:"home_score_ht"`
+	AwayScoreHT   *int   `json:"away_score_ht"`
+	HomeScoreET   *int   `json:"home_score_et"`
+	AwayScoreET   *int   `json:"away_score_et"`
+	HomePenalties *int   `json:"home_penalties"`
+	AwayPenalties *int   `json:"away_penalties"`
+	DecidedBy     string `json:"decided_by"`
+}
+
+// Validate ตรวจว่าสกอร์แต่ละคู่กรอกครบทั้งสองฝั่ง สกอร์ครึ่งแรกไม่เกินสกอร์จบเกม สกอร์ต่อเวลา (รวม 90 นาที)
+// ไม่น้อยกว่าสกอร์จบเกม ผลจุดโทษต้องไม่เสมอและมีได้เมื่อเสมอกันก่อนยิงเท่านั้น และ decided_by ถูกต้อง
+// แล้วเติม decided_by เมื่อไม่ได้ระบุ คืนข้อความผิดพลาด ("" = ผ่าน)
+func (d *MatchScoreDetail) Validate(homeScore, awayScore *int) string {
+	pairs := []struct {
+		name       string
+		home, away *int
+	}{
+		{"half-time score", d.HomeScoreHT, d.AwayScoreHT},
+		{"extra-time score", d.HomeScoreET, d.AwayScoreET},
+		{"penalty score", d.HomePenalties, d.AwayPenalties},
+	}
+	for _, p := range pairs {
+		if (p.home == nil) != (p.away == nil) {
+			return p.name + " needs both home and away"
+		}
+		if p.home != nil && (*p.home < 0 || *p.away < 0) {
+			return p.name + " cannot be negative"
+		}
+	}
+	if homeScore != nil && awayScore != nil {
+		if d.HomeScoreHT != nil && (*d.HomeScoreHT > *homeScore || *d.AwayScoreHT > *awayScore) {
+			return "half-time score cannot be higher than the full-time score"
+		}
+		if d.HomeScoreET != nil && (*d.HomeScoreET < *homeScore || *d.AwayScoreET < *awayScore) {
+			return "extra-time score cannot be lower than the full-time score"
+		}
+	}
+	if d.HomePenalties != nil {
+		if *d.HomePenalties == *d.AwayPenalties {
+			return "penalty score cannot be level"
+		}
+		// จุดโทษใช้ตัดสินเมื่อสกอร์หลังต่อเวลา (หรือ 90 นาทีถ้าไม่มีต่อเวลา) เสมอเท่านั้น
+		home, away := homeScore, awayScore
+		if d.HomeScoreET != nil {
+			home, away = d.HomeScoreET, d.AwayScoreET
+		}
+		if home != nil && away != nil && *home != *away {
+			return "penalties need a level score after full time or extra time"
+		}
+	}
+	if d.DecidedBy == "" {
+		d.DecidedBy = InferDecidedBy(homeScore, awayScore, d.HomeScoreET, d.AwayScoreET, d.HomePenalties, d.AwayPenalties)
+	} else if !ValidDecidedBy(d.DecidedBy) {
+		return "decided_by must be regular_time, extra_time or penalties"
+	}
+	return ""
+}
+
+// DecidedByArg คืนค่า decided_by สำหรับบันทึก (nil เมื่อว่าง)
+func (d MatchScoreDetail) DecidedByArg() interface{} {
+	if d.DecidedBy == "" {
+		return nil
+	}
+	return d.DecidedBy
+}
@@ -0,0 +1,82 @@
+package models
+
+import "testing"
+
+func intp(v int) *int { return &v }
+
+func TestMatchScoreDetailValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		home, away    *int
+		detail        MatchScoreDetail
+		wantErr       string
+		wantDecidedBy string
+	}{
+		{
+			name: "regular time result", home: intp(2), away: intp(1),
+			detail:        MatchScoreDetail{HomeScoreHT: intp(1), AwayScoreHT: intp(1)},
+			wantDecidedBy: DecidedByRegularTime,
+		},
+		{
+			name: "half-time score above full time", home: intp(1), away: intp(1),
+			detail:  MatchScoreDetail{HomeScoreHT: intp(2), AwayScoreHT: intp(0)},
+			wantErr: "half-time score cannot be higher than the full-time score",
+		},
+		{
+			name: "extra-time score below full time", home: intp(2), away: intp(2),
+			detail:  MatchScoreDetail{HomeScoreET: intp(3), AwayScoreET: intp(1)},
+			wantErr: "extra-time score cannot be lower than the full-time score",
+		},
+		{
+			name: "extra time decides", home: intp(1), away: intp(1),
+			detail:        MatchScoreDetail{HomeScoreET: intp(2), AwayScoreET: intp(1)},
+			wantDecidedBy: DecidedByExtraTime,
+		},
+		{
+			name: "penalties after level extra time", home: intp(1), away: intp(1),
+			detail:        MatchScoreDetail{HomeScoreET: intp(2), AwayScoreET: intp(2), HomePenalties: intp(4), AwayPenalties: intp(3)},
+			wantDecidedBy: DecidedByPenalties,
+		},
+		{
+			name: "penalties after level full time", home: intp(0), away: intp(0),
+			detail:        MatchScoreDetail{HomePenalties: intp(5), AwayPenalties: intp(4)},
+			wantDecidedBy: DecidedByPenalties,
+		},
+		{
+			name: "penalties after a decided full time", home: intp(2), away: intp(1),
+			detail:  MatchScoreDetail{HomePenalties: intp(5), AwayPenalties: intp(4)},
+			wantErr: "penalties need a level score after full time or extra time",
+		},
+		{
+			name: "penalties after decided extra time", home: intp(1), away: intp(1),
+			detail:  MatchScoreDetail{HomeScoreET: intp(2), AwayScoreET: intp(1), HomePenalties: intp(5), AwayPenalties: intp(4)},
+			wantErr: "penalties need a level score after full time or extra time",
+		},
+		{
+			name: "level penalties", home: intp(1), away: intp(1),
+			detail:  MatchScoreDetail{HomePenalties: intp(4), AwayPenalties: intp(4)},
+			wantErr: "penalty score cannot be level",
+		},
+		{
+			name: "one-sided half-time score", home: intp(1), away: intp(0),
+			detail:  MatchScoreDetail{HomeScoreHT: intp(1)},
+			wantErr: "half-time score needs both home and away",
+		},
+		{
+			name: "unknown decided_by", home: intp(1), away: intp(0),
+			detail:  MatchScoreDetail{DecidedBy: "golden_goal"},
+			wantErr: "decided_by must be regular_time, extra_time or penalties",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.detail
+			if got := d.Validate(tt.home, tt.away); got != tt.wantErr {
+				t.Fatalf("Validate = %q, want %q", got, tt.wantErr)
+			}
+			if tt.wantErr == "" && d.DecidedBy != tt.wantDecidedBy {
+				t.Errorf("DecidedBy = %q, want %q", d.DecidedBy, tt.wantDecidedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-ballthai-scraper/kickoff"
+)
+
+// MatchStatus คือสถานะของแมตช์ที่เก็บใน matches.match_status
+type MatchStatus string
+
+const (
+	StatusScheduled MatchStatus = "scheduled"
+	StatusLive      MatchStatus = "live"
+	StatusHalfTime  MatchStatus = "half_time"
+	StatusFinished  MatchStatus = "finished"
+	StatusPostponed MatchStatus = "postponed"
+	StatusCancelled MatchStatus = "cancelled"
+	StatusAbandoned MatchStatus = "abandoned"
+	StatusSuspended MatchStatus = "suspended"
+)
+
+// AllMatchStatuses คือสถานะทั้งหมดตามลำดับที่แสดงในหน้า admin
+var AllMatchStatuses = []MatchStatus{
+	StatusScheduled, StatusLive, StatusHalfTime, StatusFinished,
+	StatusPostponed, StatusCancelled, StatusAbandoned, StatusSuspended,
+}
+
+// matchStatusTransitions คือสถานะถัดไปที่อนุญาตจากแต่ละสถานะ
+// finished/cancelled/abandoned เป็นสถานะสุดท้าย แก้ได้เฉพาะเมื่อบังคับ (force) จากหน้า admin
+var matchStatusTransitions = map[MatchStatus][]MatchStatus{
+	StatusScheduled: {StatusLive, StatusFinished, StatusPostponed, StatusCancelled},
+	StatusLive:      {StatusHalfTime, StatusFinished, StatusSuspended, StatusAbandoned},
+	StatusHalfTime:  {StatusLive, StatusFinished, StatusSuspended, StatusAbandoned},
+	StatusSuspended: {StatusLive, StatusFinished, StatusAbandoned, StatusPostponed},
+	StatusPostponed: {StatusScheduled, StatusCancelled},
+}
+
+// ParseMatchStatus แปลงข้อความเป็น MatchStatus (ไม่สนตัวพิมพ์เล็ก/ใหญ่)
+func ParseMatchStatus(s string) (MatchStatus, bool) {
+	st := MatchStatus(strings.ToLower(strings.TrimSpace(s)))
+	for _, v := range AllMatchStatuses {
+		if st == v {
+			return st, true
+		}
+	}
+	return "", false
+}
+
+// CanTransition ตรวจว่าเปลี่ยนสถานะจาก from เป็น to ได้หรือไม่ (สถานะเดิมซ้ำถือว่าได้)
+func CanTransition(from, to MatchStatus) bool {
+	if from == to || from == "" {
+		return true
+	}
+	for _, next := range matchStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition คืน error เมื่อเปลี่ยนสถานะไม่ได้
+func ValidateTransition(from, to MatchStatus) error {
+	if !CanTransition(from, to) {
+		return fmt.Errorf("cannot change match status from %s to %s", from, to)
+	}
+	return nil
+}
+
+// ErrInvalidMatchStatus คือ match_status ที่ส่งมาไม่ถูกต้องหรือเปลี่ยนจากสถานะเดิมไม่ได้
+var ErrInvalidMatchStatus = errors.New("invalid match_status")
+
+// ResolveMatchStatus ตรวจสถานะที่ส่งมาจากหน้า admin
+// ว่าง = คงสถานะเดิม (หรือ scheduled เมื่อสร้างใหม่), force = ข้ามการตรวจลำดับสถานะ เพื่อแก้ข้อมูลที่ผิด
+// คืนข้อความผิดพลาด ("" = ผ่าน)
+func ResolveMatchStatus(raw string, current MatchStatus, force bool) (MatchStatus, string) {
+	if strings.TrimSpace(raw) == "" {
+		if current == "" {
+			return StatusScheduled, ""
+		}
+		return current, ""
+	}
+	status, ok := ParseMatchStatus(raw)
+	if !ok {
+		return "", "invalid match_status"
+	}
+	if !force {
+		if err := ValidateTransition(current, status); err != nil {
+			return "", err.Error()
+		}
+	}
+	return status, ""
+}
+
+// upstreamStatusNames คือรหัสข้อความจาก API/ค่าเก่าในฐานข้อมูลที่รู้จัก
+var upstreamStatusNames = map[string]MatchStatus{
+	"":            StatusScheduled,
+	"add":         StatusScheduled,
+	"fixture":     StatusScheduled,
+	"ns":          StatusScheduled,
+	"not_started": StatusScheduled,
+	"live":        StatusLive,
+	"playing":     StatusLive,
+	"1h":          StatusLive,
+	"2h":          StatusLive,
+	"et":          StatusLive,
+	"ht":          StatusHalfTime,
+	"halftime":    StatusHalfTime,
+	"ft":          StatusFinished,
+	"aet":         StatusFinished,
+	"pen":         StatusFinished,
+	"end":         StatusFinished,
+	"slip":        StatusPostponed,
+	"pst":         StatusPostponed,
+	"canc":        StatusCancelled,
+	"canceled":    StatusCancelled,
+	"abd":         StatusAbandoned,
+	"susp":        StatusSuspended,
+}
+
+// upstreamStatusCodes คือรหัสตัวเลขจาก API ที่ยืนยันความหมายแล้ว
+// ยังไม่มีรหัสที่ยืนยันได้ จึงว่างไว้: scraper หาสถานะจาก MatchDB.DerivedStatus แทน (เพิ่มที่นี่เมื่อยืนยันจากข้อมูลจริง)
+var upstreamStatusCodes = map[int]MatchStatus{}
+
+// MapUpstreamStatus แปลง match_status จาก API (ตัวเลขหรือข้อความ) เป็น MatchStatus
+// คืน false เมื่อไม่รู้จักรหัส
+func MapUpstreamStatus(v interface{}) (MatchStatus, bool) {
+	switch s := v.(type) {
+	case nil:
+		return "", false
+	case float64:
+		st, ok := upstreamStatusCodes[int(s)]
+		return st, ok
+	case int:
+		st, ok := upstreamStatusCodes[s]
+		return st, ok
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+			st, ok := upstreamStatusCodes[n]
+			return st, ok
+		}
+		if st, ok := ParseMatchStatus(s); ok {
+			return st, true
+		}
+		st, ok := upstreamStatusNames[strings.ToLower(strings.TrimSpace(s))]
+		return st, ok
+	}
+	return "", false
+}
+
+// MatchPlayWindow คือเวลานับจากเริ่มเตะที่ถือว่าแมตช์ยังแข่งอยู่ (รวมต่อเวลาและยิงจุดโทษ)
+const MatchPlayWindow = 150 * time.Minute
+
+// DerivedStatus หาสถานะจากสกอร์และ kickoff_at เมื่อ API ไม่ส่งรหัสสถานะที่รู้จัก
+// เหมือนการแปลงค่าเก่าใน schema §25: มีสกอร์และเลยเวลาเตะมาเกิน MatchPlayWindow = finished,
+// เลยเวลาเตะแต่ยังไม่เกิน = live, นอกนั้น (ไม่มีสกอร์ ยังไม่ถึงเวลาเตะ หรือไม่รู้เวลาเตะ) = scheduled
+func (m MatchDB) DerivedStatus(now time.Time) MatchStatus {
+	if !m.HomeScore.Valid || !m.AwayScore.Valid || !m.KickoffAt.Valid {
+		return StatusScheduled
+	}
+	start, err := time.ParseInLocation(kickoff.DBLayout, m.KickoffAt.String, time.UTC)
+	if err != nil || now.Before(start) {
+		return StatusScheduled
+	}
+	if now.Before(start.Add(MatchPlayWindow)) {
+		return StatusLive
+	}
+	return StatusFinished
+}
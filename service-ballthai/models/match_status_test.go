@@ -0,0 +1,138 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestParseMatchStatus(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   MatchStatus
+		wantOK bool
+	}{
+		{"finished", StatusFinished, true},
+		{" Half_Time ", StatusHalfTime, true},
+		{"POSTPONED", StatusPostponed, true},
+		{"ft", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseMatchStatus(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseMatchStatus(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to MatchStatus
+		want     bool
+	}{
+		{"", StatusFinished, true},
+		{StatusScheduled, StatusScheduled, true},
+		{StatusScheduled, StatusLive, true},
+		{StatusLive, StatusHalfTime, true},
+		{StatusHalfTime, StatusLive, true},
+		{StatusSuspended, StatusPostponed, true},
+		{StatusPostponed, StatusScheduled, true},
+		{StatusScheduled, StatusHalfTime, false},
+		{StatusFinished, StatusLive, false},
+		{StatusCancelled, StatusScheduled, false},
+		{StatusAbandoned, StatusFinished, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMatchStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		current MatchStatus
+		force   bool
+		want    MatchStatus
+		wantErr string
+	}{
+		{name: "empty on create is scheduled", raw: "", current: "", want: StatusScheduled},
+		{name: "empty keeps the current status", raw: " ", current: StatusLive, want: StatusLive},
+		{name: "allowed transition", raw: "Finished", current: StatusLive, want: StatusFinished},
+		{name: "unknown status", raw: "ft", current: StatusLive, wantErr: "invalid match_status"},
+		{name: "blocked transition", raw: "live", current: StatusFinished,
+			wantErr: "cannot change match status from finished to live"},
+		{name: "force skips the transition check", raw: "live", current: StatusFinished, force: true, want: StatusLive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := ResolveMatchStatus(tt.raw, tt.current, tt.force)
+			if got != tt.want || msg != tt.wantErr {
+				t.Errorf("ResolveMatchStatus = %q, %q, want %q, %q", got, msg, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMapUpstreamStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     interface{}
+		want   MatchStatus
+		wantOK bool
+	}{
+		{"missing", nil, "", false},
+		{"status name", "half_time", StatusHalfTime, true},
+		{"legacy code", "FT", StatusFinished, true},
+		{"legacy postponed", "SLIP", StatusPostponed, true},
+		{"empty string", "", StatusScheduled, true},
+		{"unverified number", float64(3), "", false},
+		{"unverified numeric string", "3", "", false},
+		{"unknown text", "weather", "", false},
+		{"unsupported type", true, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MapUpstreamStatus(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MapUpstreamStatus(%v) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDerivedStatus(t *testing.T) {
+	now := time.Date(2025, 8, 9, 14, 0, 0, 0, time.UTC)
+	score := sql.NullInt64{Int64: 1, Valid: true}
+	tests := []struct {
+		name      string
+		kickoffAt string
+		noScore   bool
+		want      MatchStatus
+	}{
+		{name: "not kicked off", kickoffAt: "2025-08-09 15:00:00", want: StatusScheduled},
+		{name: "in play", kickoffAt: "2025-08-09 12:30:00", want: StatusLive},
+		{name: "past the play window", kickoffAt: "2025-08-09 11:30:00", want: StatusFinished},
+		{name: "no score", kickoffAt: "2025-08-09 11:30:00", noScore: true, want: StatusScheduled},
+		{name: "no kickoff_at", kickoffAt: "", want: StatusScheduled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MatchDB{HomeScore: score, AwayScore: score,
+				KickoffAt: sql.NullString{String: tt.kickoffAt, Valid: tt.kickoffAt != ""}}
+			if tt.noScore {
+				m.AwayScore = sql.NullInt64{}
+			}
+			if got := m.DerivedStatus(now); got != tt.want {
+				t.Errorf("DerivedStatus = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
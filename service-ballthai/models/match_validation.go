@@ -0,0 +1,200 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FieldError คือข้อผิดพลาดของฟิลด์หนึ่งในคำขอสร้าง/แก้ไขแมตช์
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	MatchID *int   `json:"match_id,omitempty"` // แมตช์ที่ซ้ำ (field = fixture)
+}
+
+// ValidationErrors คือรายการ FieldError
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, f := range e {
+		parts[i] = f.Field + ": " + f.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// has ตรวจว่ามีข้อผิดพลาดของฟิลด์ใดฟิลด์หนึ่งแล้วหรือไม่
+func (e ValidationErrors) has(fields ...string) bool {
+	for _, f := range e {
+		for _, name := range fields {
+			if f.Field == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchInput คือค่าที่ตรวจร่วมกันระหว่างการสร้างและแก้ไขแมตช์
+type MatchInput struct {
+	ID         int  // 0 = สร้างใหม่
+	MatchRefID *int // nil = ให้ระบบจัดสรร (ใช้ตอนสร้างเท่านั้น)
+	LeagueID   int
+	StageID    *int
+	StartDate  string
+	StartTime  string
+	HomeTeamID int
+	AwayTeamID int
+	HomeScore  *int
+	AwayScore  *int
+}
+
+// ValidateMatch ตรวจคำขอสร้าง/แก้ไขแมตช์ และหาแมตช์ที่ซ้ำ (ลีก วันที่ และคู่ทีมเดียวกัน ไม่สนเหย้า/เยือน)
+// คืน nil เมื่อผ่าน, error อื่นที่ไม่ใช่ ValidationErrors คือข้อผิดพลาดจากฐานข้อมูล
+func ValidateMatch(db *sql.DB, in MatchInput) (ValidationErrors, error) {
+	var errs ValidationErrors
+	exists := func(table string, id int) (bool, error) {
+		var n int
+		err := db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE id = ?", id).Scan(&n)
+		return n > 0, err
+	}
+
+	if in.LeagueID <= 0 {
+		errs.add("league_id", "is required")
+	} else if ok, err := exists("leagues", in.LeagueID); err != nil {
+		return nil, err
+	} else if !ok {
+		errs.add("league_id", "league %d not found", in.LeagueID)
+	}
+
+	if _, err := time.Parse("2006-01-02", in.StartDate); err != nil {
+		errs.add("start_date", "must be YYYY-MM-DD")
+	}
+	if _, err := time.Parse("15:04", in.StartTime); err != nil {
+		if _, err := time.Parse("15:04:05", in.StartTime); err != nil {
+			errs.add("start_time", "must be HH:MM or HH:MM:SS")
+		}
+	}
+
+	for _, t := range []struct {
+		field string
+		id    int
+	}{{"home_team_id", in.HomeTeamID}, {"away_team_id", in.AwayTeamID}} {
+		if t.id <= 0 {
+			errs.add(t.field, "is required")
+		} else if ok, err := exists("teams", t.id); err != nil {
+			return nil, err
+		} else if !ok {
+			errs.add(t.field, "team %d not found", t.id)
+		}
+	}
+	if in.HomeTeamID > 0 && in.HomeTeamID == in.AwayTeamID {
+		errs.add("away_team_id", "must be different from home_team_id")
+	}
+
+	if in.StageID != nil && *in.StageID > 0 {
+		if ok, err := exists("stage", *in.StageID); err != nil {
+			return nil, err
+		} else if !ok {
+			errs.add("stage_id", "stage %d not found", *in.StageID)
+		} else if in.LeagueID > 0 {
+			// stage ที่ยังไม่ผูกลีก (league_id NULL) ใช้ได้กับทุกลีก
+			var stageLeague sql.NullInt64
+			if err := db.QueryRow("SELECT league_id FROM stage WHERE id = ?", *in.StageID).Scan(&stageLeague); err != nil {
+				return nil, err
+			}
+			if stageLeague.Valid && int(stageLeague.Int64) != in.LeagueID {
+				errs.add("stage_id", "stage %d does not belong to league %d", *in.StageID, in.LeagueID)
+			}
+		}
+	}
+
+	if (in.HomeScore == nil) != (in.AwayScore == nil) {
+		errs.add("away_score", "home_score and away_score must be set together")
+	} else if in.HomeScore != nil && (*in.HomeScore < 0 || *in.AwayScore < 0) {
+		errs.add("home_score", "scores cannot be negative")
+	}
+
+	// เลขศูนย์และติดลบสงวนไว้ให้ AllocateManualMatchRefs
+	if in.ID == 0 && in.MatchRefID != nil {
+		if *in.MatchRefID <= 0 {
+			errs.add("match_ref_id", "match_ref_id must be a positive upstream id (omit it to allocate one)")
+		} else {
+			var n int
+			if err := db.QueryRow("SELECT COUNT(*) FROM matches WHERE match_ref_id = ?", *in.MatchRefID).Scan(&n); err != nil {
+				return nil, err
+			}
+			if n > 0 {
+				errs.add("match_ref_id", "match_ref_id %d is already used", *in.MatchRefID)
+			}
+		}
+	}
+
+	if !errs.has("league_id", "start_date", "home_team_id", "away_team_id") {
+		var dupID int
+		err := db.QueryRow(`
+			SELECT id FROM matches
+			WHERE league_id = ? AND start_date = ? AND id <> ?
+				AND ((home_team_id = ? AND away_team_id = ?) OR (home_team_id = ? AND away_team_id = ?))
+			LIMIT 1`, in.LeagueID, in.StartDate, in.ID,
+			in.HomeTeamID, in.AwayTeamID, in.AwayTeamID, in.HomeTeamID).Scan(&dupID)
+		if err == nil {
+			errs = append(errs, FieldError{Field: "fixture", Message: fmt.Sprintf("same fixture already exists as match %d", dupID), MatchID: &dupID})
+		} else if err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return errs, nil
+}
+
+// Execer คือ *sql.DB หรือ *sql.Tx
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// AllocateManualMatchRefs จัดสรร match_ref_id จำนวน n เลขสำหรับแมตช์ที่สร้างเองจากหน้า admin
+// ใช้เลขติดลบเพื่อไม่ชนกับ id จาก API ซึ่งเป็นเลขบวกเสมอ คืนเลขแรก (เลขถัดไปคือ first-1, first-2, ...)
+// ตัวนับ (จำนวนเลขที่จ่ายไปแล้ว) อยู่ในตาราง match_ref_sequence และเพิ่มค่าด้วย UPDATE เดียว จึงไม่มีสองคำขอได้เลขซ้ำกัน
+// เรียกด้วย *sql.Tx ของการ INSERT เพื่อให้ตัวนับย้อนกลับเมื่อ transaction ล้ม
+func AllocateManualMatchRefs(db Execer, n int) (int, error) {
+	res, err := db.Exec("UPDATE match_ref_sequence SET issued = LAST_INSERT_ID(issued + ?) WHERE id = 1", n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate manual match ref: %w", err)
+	}
+	issued, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate manual match ref: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		return 0, fmt.Errorf("failed to allocate manual match ref: match_ref_sequence is empty")
+	}
+	return -int(issued - int64(n) + 1), nil
+}
+
+// WriteValidationErrors ส่ง ValidationErrors กลับเป็น JSON
+// 409 เมื่อมีเพียงแมตช์ซ้ำ, 400 สำหรับกรณีอื่น
+func WriteValidationErrors(w http.ResponseWriter, errs ValidationErrors) {
+	code := http.StatusBadRequest
+	if len(errs) == 1 && errs[0].Field == "fixture" {
+		code = http.StatusConflict
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   "validation failed",
+		"errors":  errs,
+	})
+}
@@ -32,7 +32,55 @@ type PlayerDB struct {
 	PhotoURL      sql.NullString
 	MatchesPlayed int
 	Goals         int
+	Assists       sql.NullInt64
+	MinutesPlayed sql.NullInt64
 	YellowCards   int
 	RedCards      int
 	Status        int
 }
+
+// PlayerLeaderFilter คือเงื่อนไขของ leaderboard ผู้เล่น (ค่า 0/ว่าง = ไม่กรอง)
+type PlayerLeaderFilter struct {
+	Stat     string // goals, assists, yellow_cards, red_cards, matches_played, minutes
+	LeagueID int
+	Season   string
+	TeamID   int
+	Limit    int
+}
+
+// PlayerLeaderDB คือสถิติของผู้เล่นหนึ่งคนใน leaderboard
+type PlayerLeaderDB struct {
+	PlayerID      int
+	Name          string
+	NameEN        sql.NullString
+	PhotoURL      sql.NullString
+	TeamID        sql.NullInt64
+	TeamName      sql.NullString
+	TeamNameEN    sql.NullString
+	MatchesPlayed int
+	MinutesPlayed sql.NullInt64
+	Goals         int
+	Assists       sql.NullInt64
+	YellowCards   int
+	RedCards      int
+}
+
+// DuplicatePlayer คือข้อมูลผู้เล่นที่ใช้ตรวจและแสดงในรายการผู้เล่นซ้ำ
+type DuplicatePlayer struct {
+	PlayerID    int     `json:"player_id"`
+	PlayerRefID *int    `json:"player_ref_id"`
+	Name        string  `json:"name"`
+	FullNameEN  *string `json:"full_name_en"`
+	BirthDate   *string `json:"birth_date"`
+	Nationality *string `json:"nationality"`
+	LeagueID    *int    `json:"league_id"`
+	TeamName    *string `json:"team_name"`
+	Goals       int     `json:"goals"`
+	Matches     int     `json:"matches_played"`
+}
+
+// DuplicateGroup คือกลุ่มผู้เล่นที่น่าจะเป็นคนเดียวกัน พร้อมเหตุผลที่ตรงกัน
+type DuplicateGroup struct {
+	Players []DuplicatePlayer `json:"players"`
+	Reasons []string          `json:"reasons"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// กลุ่มสัญชาติสำหรับนับโควตา
+const (
+	QuotaGroupThai    = "thai"
+	QuotaGroupASEAN   = "asean"
+	QuotaGroupAFC     = "afc"
+	QuotaGroupOther   = "other"
+	QuotaGroupUnknown = "unknown" // ไม่มีข้อมูลสัญชาติ ไม่นับเป็นต่างชาติแต่ต้องตรวจเอง
+)
+
+// QuotaRules คือเพดานจำนวนผู้เล่นต่างชาติที่ลงทะเบียนได้ (nil = ไม่จำกัด)
+type QuotaRules struct {
+	LeagueID        int  `json:"league_id"`
+	SeasonID        *int `json:"season_id"`
+	MaxASEAN        *int `json:"max_asean"`
+	MaxAFC          *int `json:"max_afc"`
+	MaxOther        *int `json:"max_other"`
+	MaxForeignTotal *int `json:"max_foreign_total"`
+}
+
+// QuotaPlayer คือผู้เล่นหนึ่งคนพร้อมกลุ่มสัญชาติที่จัดได้
+type QuotaPlayer struct {
+	PlayerID      int     `json:"player_id"`
+	Name          string  `json:"name"`
+	Nationality   *string `json:"nationality"`
+	Group         string  `json:"group"`
+	MatchesPlayed int     `json:"matches_played"`
+}
+
+// TeamQuota คือจำนวนผู้เล่นแต่ละกลุ่มของทีมเทียบกับโควตา
+// Counts นับผู้เล่นที่ลงทะเบียน Fielded นับเฉพาะผู้เล่นที่ลงสนามแล้ว (matches_played > 0)
+type TeamQuota struct {
+	TeamID         int            `json:"team_id"`
+	TeamName       string         `json:"team_name"`
+	Counts         map[string]int `json:"counts"`
+	ForeignTotal   int            `json:"foreign_total"`
+	Fielded        map[string]int `json:"fielded"`
+	FieldedForeign int            `json:"fielded_foreign"`
+	Violations     []string       `json:"violations"`
+	Compliant      bool           `json:"compliant"`
+	Players        []QuotaPlayer  `json:"players,omitempty"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SanctionDB represents the 'sanctions' table: คะแนนที่ถูกตัด (ติดลบ) หรือได้เพิ่ม (บวก) ของทีมในลีก/ฤดูกาล
+// ใช้ปรับคะแนนบนตารางที่ดึงมาหรือคำนวณเอง แทนการแก้ standings.points ตรงๆ
+type SanctionDB struct {
+	ID           int     `json:"id"`
+	LeagueID     int     `json:"league_id"`
+	SeasonID     *int    `json:"season_id"`
+	StageID      *int    `json:"stage_id"` // nil = ทุกตารางของทีมในลีก/ฤดูกาล
+	TeamID       int     `json:"team_id"`
+	TeamName     *string `json:"team_name,omitempty"`
+	Points       int     `json:"points"`
+	Reason       string  `json:"reason"`
+	ReasonEN     *string `json:"reason_en"`
+	SanctionDate string  `json:"sanction_date"` // YYYY-MM-DD มีผลตั้งแต่วันนี้ (ตาราง as_of ก่อนหน้านี้จะไม่ถูกปรับ)
+}
+
+// Validate ตรวจค่าก่อนบันทึก และตัดช่องว่างของ reason/reason_en
+func (s *SanctionDB) Validate() ValidationErrors {
+	var errs ValidationErrors
+	s.Reason = strings.TrimSpace(s.Reason)
+	s.ReasonEN = normaliseOptional(s.ReasonEN, false)
+	if s.LeagueID <= 0 {
+		errs.add("league_id", "is required")
+	}
+	if s.TeamID <= 0 {
+		errs.add("team_id", "is required")
+	}
+	if s.Points == 0 {
+		errs.add("points", "must not be 0 (negative = deduction)")
+	}
+	if s.Reason == "" {
+		errs.add("reason", "is required")
+	}
+	if _, err := time.Parse("2006-01-02", s.SanctionDate); err != nil {
+		errs.add("sanction_date", "must be YYYY-MM-DD")
+	}
+	return errs
+}
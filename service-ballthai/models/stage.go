@@ -0,0 +1,48 @@
+package models
+
+import "strings"
+
+// ประเภทของ stage (stage.stage_type)
+const (
+	StageRegularSeason = "regular_season"
+	StageGroup         = "group"
+	StageKnockout      = "knockout"
+	StagePlayoff       = "playoff"
+)
+
+// IsStageType บอกว่าเป็นประเภท stage ที่รองรับหรือไม่
+func IsStageType(t string) bool {
+	switch t {
+	case StageRegularSeason, StageGroup, StageKnockout, StagePlayoff:
+		return true
+	}
+	return false
+}
+
+// GuessStageType เดาประเภท stage จากชื่อ ใช้ตอน scraper สร้าง stage ใหม่ (แก้ไขได้ภายหลังผ่าน API)
+func GuessStageType(name string) string {
+	n := strings.ToLower(name)
+	switch {
+	case strings.Contains(n, "เพลย์ออฟ") || strings.Contains(n, "play-off") || strings.Contains(n, "playoff"):
+		return StagePlayoff
+	case strings.Contains(n, "กลุ่ม") || strings.Contains(n, "group"):
+		return StageGroup
+	case strings.Contains(n, "รอบ") || strings.Contains(n, "final") || strings.Contains(n, "round of"):
+		return StageKnockout
+	}
+	return StageRegularSeason
+}
+
+// StageDB คือ stage ของลีกในฤดูกาลหนึ่ง (season_id NULL = ไม่ผูกฤดูกาล เช่นข้อมูลเก่าที่คร่อมหลายฤดูกาล)
+type StageDB struct {
+	ID          int     `json:"id"`
+	LeagueID    *int    `json:"league_id"`
+	LeagueName  *string `json:"league_name"`
+	SeasonID    *int    `json:"season_id"`
+	SeasonName  *string `json:"season_name"`
+	StageName   string  `json:"stage_name"`
+	StageNameEN *string `json:"stage_name_en"`
+	StageType   string  `json:"stage_type"`
+	StageOrder  int     `json:"stage_order"`
+	NextStageID *int    `json:"next_stage_id"`
+}
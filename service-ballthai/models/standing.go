@@ -41,3 +41,71 @@ type StandingDB struct {
 	Points         int           `json:"points"`
 	CurrentRank    sql.NullInt64 `json:"current_rank"`
 }
+
+// StandingSnapshotDB represents one row of the 'standing_snapshots' table
+// (ตารางคะแนนของทีม ณ วันที่ดึงข้อมูล)
+type StandingSnapshotDB struct {
+	ID             int           `json:"id"`
+	LeagueID       int           `json:"league_id"`
+	TeamID         int           `json:"team_id"`
+	StageID        sql.NullInt64 `json:"stage_id"`
+	SnapshotDate   string        `json:"snapshot_date"` // YYYY-MM-DD
+	Matchday       int           `json:"matchday"`
+	MatchesPlayed  int           `json:"matches_played"`
+	Wins           int           `json:"wins"`
+	Draws          int           `json:"draws"`
+	Losses         int           `json:"losses"`
+	GoalsFor       int           `json:"goals_for"`
+	GoalsAgainst   int           `json:"goals_against"`
+	GoalDifference int           `json:"goal_difference"`
+	Points         int           `json:"points"`
+	CurrentRank    sql.NullInt64 `json:"current_rank"`
+}
+
+// StandingRules represents the 'standing_rules' table: คะแนนต่อผลการแข่งขันและลำดับ tie-breaker ของลีก
+type StandingRules struct {
+	LeagueID    int      `json:"league_id"`
+	PointsWin   int      `json:"points_win"`
+	PointsDraw  int      `json:"points_draw"`
+	PointsLoss  int      `json:"points_loss"`
+	TieBreakers []string `json:"tiebreakers"`
+}
+
+// MatchResult is a finished match used to compute standings
+type MatchResult struct {
+	MatchID    int    `json:"match_id"`
+	StartDate  string `json:"start_date"`
+	HomeTeamID int    `json:"home_team_id"`
+	AwayTeamID int    `json:"away_team_id"`
+	HomeScore  int    `json:"home_score"`
+	AwayScore  int    `json:"away_score"`
+	// สกอร์ครึ่งแรก (nil = ไม่มีข้อมูล) ใช้แยกผลรายครึ่งในสถิติทีม
+	HomeScoreHT *int `json:"home_score_ht,omitempty"`
+	AwayScoreHT *int `json:"away_score_ht,omitempty"`
+}
+
+// โซนในตารางคะแนน (standing_zones.zone)
+const (
+	ZoneChampion              = "champion"
+	ZoneAFCChampionsLeague    = "afc_champions_league"
+	ZoneAFCChampionsLeagueTwo = "afc_champions_league_two"
+	ZonePromotion             = "promotion"
+	ZonePromotionPlayoff      = "promotion_playoff"
+	ZoneRelegationPlayoff     = "relegation_playoff"
+	ZoneRelegation            = "relegation"
+)
+
+// StandingZone represents the 'standing_zones' table: ช่วงอันดับที่มีความหมายของลีก/ฤดูกาล/stage
+// PositionFrom/PositionTo ติดลบคือนับจากท้ายตาราง (-1 = อันดับสุดท้าย), SeasonID/StageID nil = ใช้ทุกฤดูกาล/ทุก stage
+type StandingZone struct {
+	ID           int     `json:"id"`
+	LeagueID     int     `json:"league_id"`
+	SeasonID     *int    `json:"season_id"`
+	StageID      *int    `json:"stage_id"`
+	Zone         string  `json:"zone"`
+	PositionFrom int     `json:"position_from"`
+	PositionTo   int     `json:"position_to"`
+	Color        string  `json:"color"`
+	Label        string  `json:"label"`
+	LabelEN      *string `json:"label_en,omitempty"`
+}
@@ -40,3 +40,16 @@ type TeamPostAPI struct {
 type TeamPostAPIResponse struct {
 	Teams []TeamPostAPI `json:"team"`
 }
+
+// TeamScorerDB คือผู้เล่นที่ทำประตูสูงสุดของทีม (ใช้ในสถิติทีม)
+type TeamScorerDB struct {
+	PlayerID int    `json:"player_id"`
+	Name     string `json:"name"`
+	Goals    int    `json:"goals"`
+}
+
+// TeamDisciplineDB คือยอดรวมใบเหลือง/ใบแดงของผู้เล่นในทีม
+type TeamDisciplineDB struct {
+	YellowCards int `json:"yellow_cards"`
+	RedCards    int `json:"red_cards"`
+}
@@ -0,0 +1,519 @@
+// Package romanize ถอดชื่อภาษาไทยเป็นอักษรโรมันตามหลัก RTGS (ราชบัณฑิตยสถาน) แบบออฟไลน์
+// ใช้กฎการแบ่งพยางค์โดยประมาณ (ไม่มีพจนานุกรม) จึงเป็นเพียงชื่อที่ "แนะนำ" ให้ผู้ดูแลตรวจก่อนใช้งาน
+package romanize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// loanwords คือคำทับศัพท์ที่พบบ่อยในชื่อสโมสร ซึ่งควรคืนเป็นคำภาษาอังกฤษเดิมแทนการถอดเสียง
+var loanwords = map[string]string{
+	"ยูไนเต็ด":   "United",
+	"ยูไนเต็ต":   "United",
+	"ซิตี้":      "City",
+	"ซิตี":       "City",
+	"เอฟซี":      "FC",
+	"เอฟ.ซี.":    "FC",
+	"เอฟ.ซี":     "FC",
+	"อคาเดมี":    "Academy",
+	"อะคาเดมี":   "Academy",
+	"แอธเลติก":   "Athletic",
+	"เรนเจอร์ส":  "Rangers",
+	"สปอร์ต":     "Sport",
+	"สตาร์":      "Star",
+	"จูเนียร์":   "Junior",
+	"สเตเดียม":   "Stadium",
+	"อารีน่า":    "Arena",
+	"พาร์ค":      "Park",
+	"ฟุตบอล":     "Football",
+	"คลับ":       "Club",
+	"ฟุตบอลคลับ": "Football Club",
+	"ซ็อกเกอร์":  "Soccer",
+	"ซ็อคเกอร์":  "Soccer",
+}
+
+// loanwordIndex คือ loanwords ที่ตัดวรรณยุกต์ออกจาก key เพื่อให้ตรงได้ทั้งแบบมีและไม่มีวรรณยุกต์
+var loanwordIndex = func() map[string]string {
+	idx := make(map[string]string, len(loanwords))
+	for th, en := range loanwords {
+		idx[stripToneMarks(th)] = en
+	}
+	return idx
+}()
+
+func stripToneMarks(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isToneMark(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// initials คือเสียงพยัญชนะต้น, finals คือเสียงตัวสะกด (ย/ว เป็นส่วนของสระประสม)
+var initials = map[rune]string{
+	'ก': "k", 'ข': "kh", 'ฃ': "kh", 'ค': "kh", 'ฅ': "kh", 'ฆ': "kh", 'ง': "ng",
+	'จ': "ch", 'ฉ': "ch", 'ช': "ch", 'ซ': "s", 'ฌ': "ch", 'ญ': "y",
+	'ฎ': "d", 'ฏ': "t", 'ฐ': "th", 'ฑ': "th", 'ฒ': "th", 'ณ': "n",
+	'ด': "d", 'ต': "t", 'ถ': "th", 'ท': "th", 'ธ': "th", 'น': "n",
+	'บ': "b", 'ป': "p", 'ผ': "ph", 'ฝ': "f", 'พ': "ph", 'ฟ': "f", 'ภ': "ph", 'ม': "m",
+	'ย': "y", 'ร': "r", 'ล': "l", 'ว': "w", 'ศ': "s", 'ษ': "s", 'ส': "s",
+	'ห': "h", 'ฬ': "l", 'อ': "", 'ฮ': "h",
+}
+
+var finals = map[rune]string{
+	'ก': "k", 'ข': "k", 'ฃ': "k", 'ค': "k", 'ฅ': "k", 'ฆ': "k", 'ง': "ng",
+	'จ': "t", 'ฉ': "t", 'ช': "t", 'ซ': "t", 'ฌ': "t", 'ญ': "n",
+	'ฎ': "t", 'ฏ': "t", 'ฐ': "t", 'ฑ': "t", 'ฒ': "t", 'ณ': "n",
+	'ด': "t", 'ต': "t", 'ถ': "t", 'ท': "t", 'ธ': "t", 'น': "n",
+	'บ': "p", 'ป': "p", 'ผ': "p", 'ฝ': "p", 'พ': "p", 'ฟ': "p", 'ภ': "p", 'ม': "m",
+	'ย': "i", 'ร': "n", 'ล': "n", 'ว': "o", 'ศ': "t", 'ษ': "t", 'ส': "t",
+	'ห': "", 'ฬ': "n", 'อ': "", 'ฮ': "",
+}
+
+const (
+	thanthakhat = '์'
+	maiHanAkat  = 'ั'
+	maiTaiKhu   = '็'
+)
+
+// clusterHeads คือพยัญชนะที่ควบกล้ำกับ ร ล ว ได้
+var clusterHeads = map[rune]bool{
+	'ก': true, 'ข': true, 'ค': true, 'ต': true, 'ป': true, 'พ': true,
+	'ผ': true, 'บ': true, 'ด': true, 'ท': true, 'ฟ': true,
+}
+
+// sonorants คือพยัญชนะเสียงต่ำเดี่ยวที่ใช้ ห นำ (ห ไม่ออกเสียง)
+var sonorants = map[rune]bool{
+	'ง': true, 'ญ': true, 'น': true, 'ม': true, 'ย': true, 'ร': true, 'ล': true, 'ว': true,
+}
+
+func isConsonant(r rune) bool { _, ok := initials[r]; return ok }
+
+func isLeadingVowel(r rune) bool { return r >= 'เ' && r <= 'ไ' }
+
+// isFollowingVowel คือสระที่เขียนหลัง/บน/ล่างพยัญชนะต้น
+func isFollowingVowel(r rune) bool {
+	return (r >= 'ะ' && r <= 'ู') || r == maiTaiKhu
+}
+
+func isToneMark(r rune) bool { return r >= '่' && r <= '๋' }
+
+// Name ถอดชื่อภาษาไทยเป็นอักษรโรมันแบบ RTGS โดยขึ้นต้นแต่ละคำด้วยตัวพิมพ์ใหญ่
+// ตัวอักษรละตินและตัวเลขคงไว้ตามเดิม เช่น "บุรีรัมย์ ยูไนเต็ด" -> "Buriram United"
+func Name(thai string) string {
+	words := strings.Fields(thai)
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if en, ok := loanwordIndex[stripToneMarks(w)]; ok {
+			out = append(out, en)
+			continue
+		}
+		roman := word(w)
+		if roman == "" {
+			continue
+		}
+		if roman != w {
+			roman = capitalise(roman)
+		}
+		out = append(out, roman)
+	}
+	return strings.Join(out, " ")
+}
+
+// Slug สร้าง slug สำหรับ URL/ชื่อไฟล์ (a-z, 0-9, -) จากชื่อไทยหรืออังกฤษ
+func Slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(Name(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
+
+// Suggest คืนชื่อภาษาอังกฤษและ slug ที่แนะนำสำหรับชื่อภาษาไทย
+func Suggest(thai string) (nameEN, slug string) {
+	nameEN = Name(thai)
+	return nameEN, Slug(nameEN)
+}
+
+// capitalise ทำให้ตัวอักษรตัวแรกของคำเป็นตัวพิมพ์ใหญ่ (ข้ามวงเล็บ/เครื่องหมายนำหน้า)
+func capitalise(s string) string {
+	r := []rune(s)
+	for i, c := range r {
+		if unicode.IsLetter(c) {
+			r[i] = unicode.ToUpper(c)
+			break
+		}
+	}
+	return string(r)
+}
+
+// word ถอดหนึ่งคำ (ไม่มีช่องว่าง) โดยส่งส่วนที่ไม่ใช่อักษรไทยผ่านไปตามเดิม
+func word(w string) string {
+	var b strings.Builder
+	var thai []rune
+	flush := func() {
+		if len(thai) > 0 {
+			b.WriteString(transliterate(thai))
+			thai = thai[:0]
+		}
+	}
+	for _, r := range w {
+		switch {
+		case r >= '๐' && r <= '๙':
+			flush()
+			b.WriteRune('0' + (r - '๐'))
+		case r >= 'ก' && r <= '๛':
+			thai = append(thai, r)
+		default:
+			flush()
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// clean ตัดวรรณยุกต์ ไม้ยมก/ไปยาลน้อย และตัวการันต์ (พยัญชนะที่มี ์ พร้อมสระ ิ/ุ ที่ติดอยู่)
+func clean(in []rune) []rune {
+	out := make([]rune, 0, len(in))
+	for _, r := range in {
+		switch {
+		case isToneMark(r), r == 'ๆ', r == 'ฯ', r == 'ํ', r == 'ฺ':
+		case r == thanthakhat:
+			if n := len(out); n > 0 && (out[n-1] == 'ิ' || out[n-1] == 'ุ') {
+				out = out[:n-1]
+			}
+			if n := len(out); n > 0 && isConsonant(out[n-1]) {
+				out = out[:n-1]
+				// ตัวการันต์ที่ตามหลังตัวสะกด ไม่ออกเสียงทั้งคู่ เช่น จันทร์ กาญจน์ ราษฎร์
+				if n := len(out); n > 1 && isConsonant(out[n-1]) && isConsonant(out[n-2]) {
+					out = out[:n-1]
+				}
+			}
+		default:
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// parser ถอดทีละพยางค์
+type parser struct {
+	r []rune
+	b strings.Builder
+}
+
+func transliterate(in []rune) string {
+	p := &parser{r: clean(in)}
+	for i := 0; i < len(p.r); {
+		i = p.syllable(i)
+	}
+	return p.b.String()
+}
+
+func (p *parser) at(i int) rune {
+	if i < 0 || i >= len(p.r) {
+		return 0
+	}
+	return p.r[i]
+}
+
+// startsSyllable บอกว่าพยัญชนะที่ตำแหน่ง i เป็นพยัญชนะต้นของพยางค์ถัดไป (มีสระตามหลัง)
+func (p *parser) startsSyllable(i int) bool {
+	next := p.at(i + 1)
+	switch {
+	case isFollowingVowel(next):
+		return true
+	case next == 'อ':
+		// อ เป็นสระ -อ ของพยัญชนะนี้ ยกเว้นเมื่อ อ มีสระของตัวเอง (อา อิ อุ ...)
+		return !isFollowingVowel(p.at(i + 2))
+	case next == 'ร' && p.at(i+2) == 'ร':
+		// -รร- เช่น สุพรรณ
+		return true
+	case next == 'ว' && p.bare(i+2):
+		// -ว- เป็นสระอัว เช่น ม่วง สวน
+		return true
+	}
+	return p.cluster(i) && p.startsSyllable(i+1)
+}
+
+// cluster บอกว่าพยัญชนะที่ตำแหน่ง i กับตัวถัดไปเป็นพยัญชนะต้นคู่ (ควบกล้ำ, ห นำ, ศร/สร)
+func (p *parser) cluster(i int) bool {
+	c, next := p.at(i), p.at(i+1)
+	switch {
+	case c == 'ห':
+		return sonorants[next]
+	case c == 'ศ' || c == 'ส':
+		return next == 'ร' && p.at(i+2) != 'ะ'
+	}
+	return clusterHeads[c] && (next == 'ร' || next == 'ล' || next == 'ว')
+}
+
+// bare คือพยัญชนะที่ไม่มีสระตามหลัง (เป็นตัวสะกดหรือใช้สระอะ/โอะ ลดรูป)
+func (p *parser) bare(i int) bool {
+	return isConsonant(p.at(i)) && !p.startsSyllable(i)
+}
+
+// initial อ่านพยัญชนะต้น (รวม ห นำ, อ นำ ย และคำควบกล้ำ) คืนเสียงและตำแหน่งถัดไป
+func (p *parser) initial(i int, led bool) (string, int) {
+	c, next := p.at(i), p.at(i+1)
+	switch {
+	case c == 'ห' && sonorants[next] && (led || p.startsSyllable(i+1) || p.bare(i+2)):
+		return initials[next], i + 2
+	case c == 'อ' && next == 'ย' && (p.at(i+2) == 'า' || p.at(i+2) == 'ู'):
+		return "y", i + 2
+	case (c == 'ศ' || c == 'ส') && p.cluster(i) && (led || p.startsSyllable(i+1)):
+		// ศร/สร ออกเสียง ส เช่น ศรี สร้าง
+		return "s", i + 2
+	case p.cluster(i) && (led || p.startsSyllable(i+1) || (next != 'ว' && p.at(i+2) == 'ว' && p.bare(i+3))):
+		return initials[c] + initials[next], i + 2
+	}
+	return initials[c], i + 1
+}
+
+// final อ่านตัวสะกดที่ตำแหน่ง i ถ้ามี (ข้าม ร ที่ไม่ออกเสียงหลังตัวสะกด เช่น สมุทร จักร)
+// พยัญชนะเปล่า 1-2 ตัวสุดท้ายของคำถือเป็นพยางค์ใหม่แทน เช่น นายก = na-yok, มหานคร = ma-ha-na-khon
+func (p *parser) final(i int) int {
+	if !p.bare(i) {
+		return i
+	}
+	if m := p.bareRun(i + 1); (m == 1 || m == 2) && i+1+m == len(p.r) && !(m == 1 && p.at(i+1) == 'ร') {
+		return i
+	}
+	p.b.WriteString(finals[p.r[i]])
+	i++
+	if p.at(i) == 'ร' && !p.startsSyllable(i) && (i+1 >= len(p.r) || isConsonant(p.at(i+1)) || isLeadingVowel(p.at(i+1))) {
+		i++
+	}
+	return i
+}
+
+// syllable ถอดหนึ่งพยางค์เริ่มที่ตำแหน่ง i และคืนตำแหน่งถัดไป
+func (p *parser) syllable(i int) int {
+	c := p.at(i)
+	switch {
+	case c == 'ฤ':
+		// ฤ ที่มีตัวสะกดอ่าน ริ เช่น ฤทธิ์ = rit, ไม่มีตัวสะกดอ่าน รึ เช่น ฤดู = ruedu
+		if p.bare(i + 1) {
+			p.b.WriteString("ri")
+			return p.final(i + 1)
+		}
+		p.b.WriteString("rue")
+		return i + 1
+	case c == 'ฦ':
+		p.b.WriteString("lue")
+		return i + 1
+	case isLeadingVowel(c):
+		return p.ledSyllable(i)
+	case !isConsonant(c):
+		// สระลอยที่ไม่มีพยัญชนะต้น ถอดเฉพาะสระ
+		p.b.WriteString(plainVowel(c))
+		return i + 1
+	}
+
+	init, j := p.initial(i, false)
+	p.b.WriteString(init)
+	switch v := p.at(j); {
+	case v == maiHanAkat:
+		if p.at(j+1) == 'ว' {
+			p.b.WriteString("ua")
+			return p.final(j + 2)
+		}
+		if p.at(j+1) == 'ย' && !p.startsSyllable(j+1) {
+			p.b.WriteString("ai")
+			return j + 2
+		}
+		p.b.WriteString("a")
+		return p.final(j + 1)
+	case v == 'า':
+		if (p.at(j+1) == 'ย' || p.at(j+1) == 'ว') && p.bare(j+1) {
+			p.b.WriteString(map[rune]string{'ย': "ai", 'ว': "ao"}[p.at(j+1)])
+			return j + 2
+		}
+		p.b.WriteString("a")
+		return p.final(j + 1)
+	case v == 'ำ':
+		p.b.WriteString("am")
+		return j + 1
+	case v == 'ะ':
+		p.b.WriteString("a")
+		return j + 1
+	case v == 'ิ':
+		if p.at(j+1) == 'ว' && p.bare(j+1) {
+			p.b.WriteString("io")
+			return j + 2
+		}
+		p.b.WriteString("i")
+		return p.final(j + 1)
+	case v == 'ี':
+		p.b.WriteString("i")
+		return p.final(j + 1)
+	case v == 'ึ':
+		p.b.WriteString("ue")
+		return p.final(j + 1)
+	case v == 'ื':
+		p.b.WriteString("ue")
+		if p.at(j+1) == 'อ' {
+			j++
+		}
+		return p.final(j + 1)
+	case v == 'ุ':
+		if p.at(j+1) == 'ย' && p.bare(j+1) {
+			p.b.WriteString("ui")
+			return j + 2
+		}
+		p.b.WriteString("u")
+		return p.final(j + 1)
+	case v == 'ู':
+		p.b.WriteString("u")
+		return p.final(j + 1)
+	case v == maiTaiKhu:
+		p.b.WriteString("o")
+		return p.final(j + 1)
+	case v == 'อ' && !isFollowingVowel(p.at(j+1)):
+		if p.at(j+1) == 'ย' && p.bare(j+1) {
+			p.b.WriteString("oi")
+			return j + 2
+		}
+		p.b.WriteString("o")
+		return p.final(j + 1)
+	case v == 'ว' && p.bare(j+1):
+		// -ว- ระหว่างพยัญชนะ = สระอัว เช่น สวน ควร
+		p.b.WriteString("ua")
+		return p.final(j + 1)
+	case v == 'ร' && p.at(j+1) == 'ร' && !p.startsSyllable(j+1):
+		// -รร- = อัน หรือ อะ + ตัวสะกด เช่น สุพรรณ บรรจง
+		p.b.WriteString("a")
+		if k := p.final(j + 2); k != j+2 {
+			return k
+		}
+		p.b.WriteString("n")
+		return j + 2
+	}
+	return p.inherent(i, j)
+}
+
+// bareRun นับพยัญชนะเปล่าที่เรียงกันเริ่มที่ตำแหน่ง i
+func (p *parser) bareRun(i int) int {
+	n := 0
+	for p.bare(i + n) {
+		n++
+	}
+	return n
+}
+
+// inherent ถอดพยางค์ที่ไม่มีรูปสระ: นับพยัญชนะเปล่าที่เรียงกัน ถ้าเป็นเลขคี่ตัวแรกใช้สระอะ
+// ถ้าเป็นคู่ใช้สระโอะลดรูปกับตัวสะกด เช่น คน = khon, นคร = na-khon, สกลนคร = sa-kon-na-khon
+func (p *parser) inherent(i, j int) int {
+	if n := 1 + p.bareRun(j); n%2 == 1 {
+		p.b.WriteString("a")
+		return j
+	}
+	p.b.WriteString("o")
+	return p.final(j)
+}
+
+// ledSyllable ถอดพยางค์ที่ขึ้นต้นด้วยสระหน้า (เ แ โ ใ ไ)
+func (p *parser) ledSyllable(i int) int {
+	lead := p.at(i)
+	if !isConsonant(p.at(i + 1)) {
+		p.b.WriteString(plainVowel(lead))
+		return i + 1
+	}
+	init, j := p.initial(i+1, true)
+	p.b.WriteString(init)
+	v, v2 := p.at(j), p.at(j+1)
+	switch lead {
+	case 'ใ', 'ไ':
+		p.b.WriteString("ai")
+		if v == 'ย' && !p.startsSyllable(j) {
+			j++
+		}
+		return j
+	case 'โ':
+		p.b.WriteString("o")
+		if v == 'ะ' {
+			return j + 1
+		}
+		return p.final(j)
+	case 'แ':
+		p.b.WriteString("ae")
+		if v == 'ะ' {
+			return j + 1
+		}
+		if v == maiTaiKhu {
+			j++
+		}
+		return p.final(j)
+	}
+	// เ
+	switch {
+	case v == 'ี' && v2 == 'ย':
+		p.b.WriteString("ia")
+		return p.final(j + 2)
+	case v == 'ื' && v2 == 'อ':
+		if p.at(j+2) == 'ย' && p.bare(j+2) {
+			p.b.WriteString("ueai")
+			return j + 3
+		}
+		p.b.WriteString("uea")
+		return p.final(j + 2)
+	case v == 'า' && v2 == 'ะ':
+		p.b.WriteString("o")
+		return j + 2
+	case v == 'า':
+		p.b.WriteString("ao")
+		return j + 1
+	case v == 'อ':
+		p.b.WriteString("oe")
+		return p.final(j + 1)
+	case v == 'ิ':
+		p.b.WriteString("oe")
+		return p.final(j + 1)
+	case v == 'ะ':
+		p.b.WriteString("e")
+		return j + 1
+	case v == maiTaiKhu:
+		p.b.WriteString("e")
+		return p.final(j + 1)
+	case v == 'ย' && p.bare(j):
+		p.b.WriteString("oei")
+		return j + 1
+	}
+	p.b.WriteString("e")
+	return p.final(j)
+}
+
+// plainVowel คือเสียงของสระที่ไม่มีพยัญชนะต้นกำกับ
+func plainVowel(r rune) string {
+	switch r {
+	case 'ะ', 'ั', 'า':
+		return "a"
+	case 'ำ':
+		return "am"
+	case 'ิ', 'ี':
+		return "i"
+	case 'ึ', 'ื':
+		return "ue"
+	case 'ุ', 'ู':
+		return "u"
+	case 'เ':
+		return "e"
+	case 'แ':
+		return "ae"
+	case 'โ':
+		return "o"
+	case 'ใ', 'ไ':
+		return "ai"
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package romanize
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		thai     string
+		wantName string
+		wantSlug string
+	}{
+		{"บุรีรัมย์ ยูไนเต็ด", "Buriram United", "buriram-united"},
+		{"ชลบุรี เอฟซี", "Chonburi FC", "chonburi-fc"},
+		{"เชียงราย ยูไนเต็ด", "Chiangrai United", "chiangrai-united"},
+		{"สุโขทัย", "Sukhothai", "sukhothai"},
+		{"ตราด เอฟซี", "Trat FC", "trat-fc"},
+		{"ลำพูน", "Lamphun", "lamphun"},
+		{"BG Pathum United", "BG Pathum United", "bg-pathum-united"},
+		{"ทีม 2", "Thim 2", "thim-2"},
+		{"๑๒๓", "123", "123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.thai, func(t *testing.T) {
+			name, slug := Suggest(tt.thai)
+			if name != tt.wantName || slug != tt.wantSlug {
+				t.Errorf("Suggest(%q) = %q, %q; want %q, %q", tt.thai, name, slug, tt.wantName, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestSlugCanBeEmpty(t *testing.T) {
+	// ผู้เรียกต้องมีชื่อสำรองเมื่อชื่อไม่มีอักขระที่ถอดเป็น a-z/0-9 ได้
+	for _, name := range []string{"", "   ", "!!!", "(-)"} {
+		if got := Slug(name); got != "" {
+			t.Errorf("Slug(%q) = %q, want empty", name, got)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -93,3 +94,9 @@ func DownloadImage(imageURL, saveDir string) (string, error) {
 	log.Printf("Image downloaded successfully: %s", savePath)
 	return savePath, nil
 }
+
+// WebImagePath แปลง path ไฟล์ที่ DownloadImage คืนมา (เช่น img/player/x.png) เป็น web path (/img/player/x.png)
+func WebImagePath(savePath string) string {
+	p := filepath.ToSlash(filepath.Clean(savePath))
+	return "/" + strings.TrimPrefix(p, "/")
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"go-ballthai-scraper/database"
 	"go-ballthai-scraper/models"
@@ -32,6 +33,10 @@ func ScrapeJLeagueStandings(db *sql.DB) error {
 	if err != nil {
 		return fmt.Errorf("failed to get or create J-League: %v", err)
 	}
+	// แมตช์ของ J-League ใช้เวลาท้องถิ่นญี่ปุ่น (ไม่ทับค่าที่ตั้งไว้แล้ว)
+	if _, err := db.Exec("UPDATE leagues SET timezone = 'Asia/Tokyo' WHERE id = ? AND timezone IS NULL", leagueID); err != nil {
+		log.Printf("Warning: failed to set J-League timezone: %v", err)
+	}
 
 	// Scrape both EAST and WEST stages
 	stages := []struct {
@@ -58,7 +63,7 @@ func scrapeJLeagueStandingsByStage(db *sql.DB, leagueID int, stageName, url stri
 	log.Printf("Scraping J-League standings for %s stage from: %s", stageName, url)
 
 	// Get or create stage
-	stageID, err := getOrCreateStage(db, stageName)
+	stageID, err := database.GetStageID(db, stageName, leagueID)
 	if err != nil {
 		return fmt.Errorf("failed to get or create stage %s: %v", stageName, err)
 	}
@@ -195,7 +200,7 @@ func scrapeJLeagueStandingsByStage(db *sql.DB, leagueID int, stageName, url stri
 			GoalDifference: teamData.GoalDifference,
 			Points:         teamData.Points,
 			CurrentRank:    sql.NullInt64{Int64: int64(teamData.Position), Valid: teamData.Position != 0},
-			StageID:        sql.NullInt64{Int64: stageID, Valid: true}, // เพิ่ม stage_id
+			StageID:        sql.NullInt64{Int64: int64(stageID), Valid: true}, // เพิ่ม stage_id
 			Status:         sql.NullInt64{Valid: false}, // เพิ่ม status (null)
 		}
 
@@ -206,44 +211,15 @@ func scrapeJLeagueStandingsByStage(db *sql.DB, leagueID int, stageName, url stri
 		} else {
 			log.Printf("Successfully saved standing for %s (Position: %d, Points: %d)",
 				teamData.Name, teamData.Position, teamData.Points)
+			if err := database.SaveStandingSnapshot(db, standingDB, time.Now()); err != nil {
+				log.Printf("Error saving standing snapshot for team %s: %v", teamData.Name, err)
+			}
 		}
 	}
 
 	return nil
 }
 
-// getOrCreateStage gets stage ID or creates new stage if not exists
-func getOrCreateStage(db *sql.DB, stageName string) (int64, error) {
-	// Try to find existing stage
-	var stageID int64
-	query := `SELECT id FROM stage WHERE stage_name = ? LIMIT 1`
-	err := db.QueryRow(query, stageName).Scan(&stageID)
-
-	if err == nil {
-		log.Printf("Found existing stage: %s (ID: %d)", stageName, stageID)
-		return stageID, nil // Stage found
-	}
-
-	if err != sql.ErrNoRows {
-		return 0, fmt.Errorf("error searching for stage: %v", err)
-	}
-
-	// Stage not found, create new one
-	insertQuery := `INSERT INTO stage (stage_name) VALUES (?)`
-	result, err := db.Exec(insertQuery, stageName)
-	if err != nil {
-		return 0, fmt.Errorf("error creating new stage: %v", err)
-	}
-
-	newStageID, err := result.LastInsertId()
-	if err != nil {
-		return 0, fmt.Errorf("error getting new stage ID: %v", err)
-	}
-
-	log.Printf("Created new stage: %s (ID: %d)", stageName, newStageID)
-	return newStageID, nil
-}
-
 // JLeagueTeamData represents team data extracted from the table
 type JLeagueTeamData struct {
 	Position       int
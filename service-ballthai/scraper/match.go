@@ -38,10 +38,7 @@ func ensureTeamAndLogo(db *sql.DB, teamName string) error {
 		teams, _ := FetchTeamsByLeagueID("")
 		for _, team := range teams {
 			if team.Name == teamName {
-				baseName := team.NameEN
-				if baseName == "" {
-					baseName = slugify(team.Name)
-				}
+				baseName := logoBaseName(team)
 				var logoPath string
 				if team.Logo != "" {
 					ext := path.Ext(team.Logo)
@@ -128,19 +125,26 @@ func scrapeMatchesPage(db *sql.DB, baseURL string, page int, tournamentParam str
 	for _, apiMatch := range apiResponse.Results {
 		var stageID int
 		if apiMatch.StageName != "" {
-			sid, errStage := database.GetStageID(db, apiMatch.StageName, dbLeagueID)
+			sid, errStage := database.GetStageIDAt(db, apiMatch.StageName, dbLeagueID, apiMatch.StartDate)
 			if errStage != nil {
 				log.Printf("Warning: Failed to insert/update stage for match %d (%s): %v", apiMatch.ID, apiMatch.StageName, errStage)
 			} else {
 				stageID = sid
+				if err := database.SetStageNameEN(db, sid, apiMatch.StageNameEN); err != nil {
+					log.Printf("Warning: %v", err)
+				}
 			}
 		}
+		if err := database.SetLeagueNameEN(db, dbLeagueID, apiMatch.TournamentNameEN); err != nil {
+			log.Printf("Warning: %v", err)
+		}
 
-		var currentStatus string
-		err := db.QueryRow("SELECT match_status FROM matches WHERE match_ref_id = ?", apiMatch.ID).Scan(&currentStatus)
-		if err == nil && (currentStatus == "OFF" || currentStatus == "SLIP") {
-			log.Printf("Skip update match %d (status=%s)", apiMatch.ID, currentStatus)
-    		continue
+		var currentStatus sql.NullString
+		var locked bool
+		err := db.QueryRow("SELECT match_status, scrape_locked FROM matches WHERE match_ref_id = ?", apiMatch.ID).Scan(&currentStatus, &locked)
+		if err == nil && locked {
+			log.Printf("Skip update match %d (scrape locked, status=%s)", apiMatch.ID, currentStatus.String)
+			continue
 		}
 
 		var homeTeamID, awayTeamID int
@@ -152,6 +156,9 @@ func scrapeMatchesPage(db *sql.DB, baseURL string, page int, tournamentParam str
 				log.Printf("Warning: GetTeamIDByThaiName home team '%s' failed: %v", apiMatch.HomeTeamName, err)
 			} else {
 				homeTeamID = id
+				if err := database.SetTeamNameEN(db, id, apiMatch.HomeTeamNameEN); err != nil {
+					log.Printf("Warning: %v", err)
+				}
 			}
 			// If team has external logo, normalize it to local server path
 			if id != 0 {
@@ -174,6 +181,9 @@ func scrapeMatchesPage(db *sql.DB, baseURL string, page int, tournamentParam str
 				log.Printf("Warning: GetTeamIDByThaiName away team '%s' failed: %v", apiMatch.AwayTeamName, err)
 			} else {
 				awayTeamID = id
+				if err := database.SetTeamNameEN(db, id, apiMatch.AwayTeamNameEN); err != nil {
+					log.Printf("Warning: %v", err)
+				}
 			}
 			// If team has external logo, normalize it to local server path
 			if id != 0 {
@@ -245,22 +255,47 @@ func scrapeMatchesPage(db *sql.DB, baseURL string, page int, tournamentParam str
 			}
 		}
 
+		leagueID := sql.NullInt64{Valid: dbLeagueID != 0, Int64: int64(dbLeagueID)}
+		homeID := sql.NullInt64{Valid: homeTeamID != 0, Int64: int64(homeTeamID)}
+		zone, kickoffAt := models.MatchKickoff(db, leagueID, homeID, apiMatch.StartDate, apiMatch.StartTime)
+
 		log.Printf("Processing match %d: leagueID=%d stageID=%d home='%s' away='%s'", apiMatch.ID, dbLeagueID, stageID, apiMatch.HomeTeamName, apiMatch.AwayTeamName)
 		matchDB := models.MatchDB{
 			MatchRefID: apiMatch.ID,
 			StartDate:  apiMatch.StartDate,
 			StartTime:  apiMatch.StartTime,
-			LeagueID:   sql.NullInt64{Valid: dbLeagueID != 0, Int64: int64(dbLeagueID)},
+			LeagueID:   leagueID,
 			StageID:    sql.NullInt64{Valid: stageID != 0, Int64: int64(stageID)},
-			HomeTeamID: sql.NullInt64{Valid: homeTeamID != 0, Int64: int64(homeTeamID)},
+			HomeTeamID: homeID,
 			AwayTeamID: sql.NullInt64{Valid: awayTeamID != 0, Int64: int64(awayTeamID)},
 			ChannelID:  sql.NullInt64{Valid: false},
 			LiveChannelID: sql.NullInt64{Valid: false},
 			HomeScore:   sql.NullInt64{Valid: true, Int64: int64(apiMatch.HomeGoalCount)},
 			AwayScore:   sql.NullInt64{Valid: true, Int64: int64(apiMatch.AwayGoalCount)},
-			MatchStatus: sql.NullString{String: fmt.Sprint(apiMatch.MatchStatus), Valid: apiMatch.MatchStatus != nil},
+			Timezone:    sql.NullString{String: zone, Valid: true},
+			KickoffAt:   kickoffAt,
+			// ยังไม่พบสกอร์ครึ่งแรก/ต่อเวลา/จุดโทษใน API ของแมตช์ ค่าเหล่านี้กรอกโดยบรรณาธิการ
+			// (ส่ง NULL ไป InsertOrUpdateMatch จึงไม่ทับค่าที่กรอกไว้)
 		}
 
+		// แปลงสถานะจาก API ถ้าไม่รู้จักรหัส (ยังไม่มีรหัสตัวเลขที่ยืนยันได้) หาจากสกอร์และเวลาเตะแทน
+		// สถานะที่หาเองใช้เลื่อนสถานะไปข้างหน้าเท่านั้น และคงสถานะเดิมเมื่อเปลี่ยนสถานะไม่ได้
+		status := models.MatchStatus(currentStatus.String)
+		if mapped, ok := models.MapUpstreamStatus(apiMatch.MatchStatus); ok {
+			if err := models.ValidateTransition(status, mapped); err != nil {
+				log.Printf("Warning: match %d: %v", apiMatch.ID, err)
+			} else {
+				status = mapped
+			}
+		} else if derived := matchDB.DerivedStatus(time.Now()); derived != models.StatusScheduled &&
+			models.CanTransition(status, derived) {
+			status = derived
+		}
+		if status == "" {
+			status = models.StatusScheduled
+		}
+		matchDB.MatchStatus = sql.NullString{String: string(status), Valid: true}
+
 		if apiMatch.ChannelInfo.Name != "" {
 			if chID, err := database.GetChannelID(db, apiMatch.ChannelInfo.Name, channelLogoPath, "TV"); err == nil {
 				matchDB.ChannelID = sql.NullInt64{Valid: true, Int64: int64(chID)}
@@ -276,6 +311,15 @@ func scrapeMatchesPage(db *sql.DB, baseURL string, page int, tournamentParam str
 			log.Printf("Error saving match %d: %v", apiMatch.ID, err)
 		} else {
 			log.Printf("Saved match %d to DB", apiMatch.ID)
+			if knockout, err := database.IsKnockoutLeague(db, dbLeagueID); err != nil {
+				log.Printf("Warning: %v", err)
+			} else if knockout {
+				if err := database.SyncKnockoutMatch(db, apiMatch.ID); err != nil {
+					log.Printf("Warning: Failed to sync bracket for match %d: %v", apiMatch.ID, err)
+				} else if err := database.ApplyBracketProgression(db, dbLeagueID); err != nil {
+					log.Printf("Warning: Failed to apply bracket progression for league %d: %v", dbLeagueID, err)
+				}
+			}
 		}
 	}
 	return nil
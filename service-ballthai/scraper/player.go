@@ -32,6 +32,11 @@ func ScrapePlayers(db *sql.DB) error {
 		if !league.ThaileageID.Valid || league.ThaileageID.Int64 == 0 {
 			continue
 		}
+		// season ปัจจุบันของลีก ใช้บันทึกสถิติรายฤดูกาล (ถ้ายังไม่ sync seasons จะข้ามไป)
+		seasonID, err := database.GetCurrentSeasonID(db, league.ID)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
 		// paginate pages until empty results
 		maxPages := 50
 		for page := 1; page <= maxPages; page++ {
@@ -65,7 +70,7 @@ func ScrapePlayers(db *sql.DB) error {
 				if err != nil {
 					log.Printf("Warning: Failed to download player photo for %s: %v", apiPlayer.FullName, err)
 				} else {
-					photoPath = downloadedPath
+					photoPath = WebImagePath(downloadedPath)
 				}
 			}
 
@@ -128,6 +133,12 @@ func ScrapePlayers(db *sql.DB) error {
 			err = database.InsertOrUpdatePlayer(db, playerDB)
 			if err != nil {
 				log.Printf("Error saving player %s to DB: %v", apiPlayer.FullName, err)
+			} else {
+				if seasonID.Valid {
+					if err := database.SavePlayerSeasonStats(db, int(seasonID.Int64), playerDB); err != nil {
+						log.Printf("Warning: %v", err)
+					}
+				}
 			}
 		}
 			// be polite between pages — wait 10 seconds before next page
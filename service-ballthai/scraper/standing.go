@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"log"
 	"fmt"
+	"time"
 	"go-ballthai-scraper/database" // ตรวจสอบให้แน่ใจว่าชื่อโมดูลตรงกับ go.mod ของคุณ
 	"go-ballthai-scraper/models"   // ตรวจสอบให้แน่ใจว่าชื่อโมดูลตรงกับ go.mod ของคุณ
 )
@@ -15,6 +16,7 @@ func ScrapeStandings(db *sql.DB) error {
 	   if err != nil {
 		   return err
 	   }
+	   scrapedAt := time.Now()
 	for _, league := range leagues {
 		if !league.ThaileageID.Valid || league.ThaileageID.Int64 == 0 {
 			continue
@@ -51,6 +53,9 @@ func ScrapeStandings(db *sql.DB) error {
 					   tID = tID2
 				   }
 				   teamID = tID
+				   if err := database.SetTeamNameEN(db, teamID, apiStanding.TournamentTeamNameEN); err != nil {
+					   log.Printf("Warning: %v", err)
+				   }
 			   } else {
 				   log.Printf("Warning: Standing entry for %s has no team name, skipping.", league.Name)
 				   continue
@@ -62,6 +67,9 @@ func ScrapeStandings(db *sql.DB) error {
 				   id, err := database.GetStageID(db, apiStanding.StageName, league.ID)
 				   if err == nil {
 					   stageID = sql.NullInt64{Int64: int64(id), Valid: true}
+					   if err := database.SetStageNameEN(db, id, apiStanding.StageNameEN); err != nil {
+						   log.Printf("Warning: %v", err)
+					   }
 				   }
 			   }
 			   standingDB := models.StandingDB{
@@ -98,6 +106,10 @@ func ScrapeStandings(db *sql.DB) error {
 				   log.Printf("Error saving standing for team %s in league %s to DB: %v", apiStanding.TournamentTeamName, league.Name, err)
 			   } else {
 				   log.Printf("Saved standing for team %s in league %s", apiStanding.TournamentTeamName, league.Name)
+				   // เก็บ snapshot รายวันไว้ดูอันดับย้อนหลัง
+				   if err := database.SaveStandingSnapshot(db, standingDB, scrapedAt); err != nil {
+					   log.Printf("Error saving standing snapshot for team %s: %v", apiStanding.TournamentTeamName, err)
+				   }
 			   }
 		   }
 	   }
@@ -10,10 +10,10 @@ import (
    "net/http"
    "os"
    "path"
-   "strings"
 
    "go-ballthai-scraper/models"
    "go-ballthai-scraper/database"
+   "go-ballthai-scraper/romanize"
 )
 
 // SaveTeamsAndLogosByLeagueID ดึงทีมจาก API, บันทึกลง DB, ดาวน์โหลดโลโก้
@@ -25,10 +25,7 @@ func SaveTeamsAndLogosByLeagueID(db *sql.DB, leagueID string) (int, error) {
    imported := 0
    for _, team := range teams {
 	   var logoPath string
-	   baseName := team.NameEN
-	   if baseName == "" {
-		   baseName = slugify(team.Name)
-	   }
+	   baseName := logoBaseName(team)
 	   if team.Logo != "" {
 		   ext := path.Ext(team.Logo)
 		   if ext == "" {
@@ -85,24 +82,17 @@ func downloadLogoToFolder(logoURL, teamName string) error {
    return err
 }
 
-// slugify แปลงชื่อไทยเป็น slug ภาษาอังกฤษ (a-z, 0-9, -)
-func slugify(s string) string {
-   s = strings.ToLower(s)
-   // แทนที่อักขระที่ไม่ใช่ a-z, 0-9 ด้วย -
-   var b strings.Builder
-   for _, r := range s {
-	   if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
-		   b.WriteRune(r)
-	   } else {
-		   b.WriteRune('-')
-	   }
+// logoBaseName คืนชื่อไฟล์โลโก้ (ไม่รวมนามสกุล) ของทีม: ชื่อภาษาอังกฤษ, ถ้าไม่มีใช้ slug ที่ถอดจากชื่อไทย
+// ถ้าถอดแล้วว่าง (เช่นชื่อมีแต่อักขระที่ถอดไม่ได้) ใช้ "team-<id>" เพื่อไม่ให้หลายทีมได้ไฟล์ ".png" ชื่อเดียวกัน
+func logoBaseName(team models.TeamAPI) string {
+   if team.NameEN != "" {
+	   return team.NameEN
    }
-   // ลบ - ซ้ำกันและขอบ
-   slug := b.String()
-		slug = strings.ReplaceAll(slug, "--", "-")
-		slug = strings.Trim(slug, "-")
-		return slug
-	}
+   if slug := romanize.Slug(team.Name); slug != "" {
+	   return slug
+   }
+   return fmt.Sprintf("team-%d", team.ID)
+}
 
 // sanitizeFileName แปลงชื่อทีมให้เป็นชื่อไฟล์ที่ปลอดภัย
 
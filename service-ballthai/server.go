@@ -109,6 +109,15 @@ func main() {
 	handlers.SetDB(db)
 	database.SetDB(db)
 
+	// ตั้ง slug ให้ทีม/ผู้เล่นเก่าที่ยังไม่มี (แถวใหม่ได้ slug ตอน insert)
+	go func() {
+		if n, err := database.AssignMissingSlugs(db); err != nil {
+			log.Printf("[ERROR] AssignMissingSlugs: %v", err)
+		} else if n > 0 {
+			log.Printf("Assigned slugs to %d teams/players", n)
+		}
+	}()
+
 	// Apply middleware
 	router.Use(middleware.Logging)
 	router.Use(middleware.CORS)
@@ -124,24 +133,43 @@ func main() {
 	router.HandleFunc("/api/leagues", handlers.CreateLeague).Methods("POST")
 	router.HandleFunc("/api/leagues/{id}", handlers.UpdateLeague).Methods("PUT")
 	router.HandleFunc("/api/leagues/{id}", handlers.DeleteLeague).Methods("DELETE")
+	router.HandleFunc("/api/leagues/{id:[0-9]+}/logo", handlers.UploadLeagueLogo).Methods("POST")
+	router.HandleFunc("/api/leagues/{id:[0-9]+}/bracket", handlers.GetLeagueBracket).Methods("GET")
+	router.HandleFunc("/api/bracket/ties/{id:[0-9]+}", handlers.UpdateBracketTie).Methods("PUT")
+	router.HandleFunc("/api/bracket/rounds/{id:[0-9]+}", handlers.UpdateBracketRound).Methods("PUT")
 	router.HandleFunc("/api/teams", handlers.GetTeams).Methods("GET")
 	router.HandleFunc("/api/stages", handlers.GetStages).Methods("GET")
+	router.HandleFunc("/api/stages/{id:[0-9]+}", handlers.UpdateStage).Methods("PUT")
 	router.HandleFunc("/api/teams/search", handlers.SearchTeams).Methods("GET")
 	router.HandleFunc("/api/teams", handlers.CreateTeam).Methods("POST")
 	// Standings API
 	router.HandleFunc("/api/standings", handlers.GetStandings).Methods("GET")
+	router.HandleFunc("/api/standings/history", handlers.GetStandingsHistory).Methods("GET")
+	router.HandleFunc("/api/standing-zones", handlers.GetStandingZones).Methods("GET")
+	router.HandleFunc("/api/sanctions", handlers.GetSanctions).Methods("GET")
+	router.HandleFunc("/api/sanctions", handlers.CreateSanction).Methods("POST")
+	router.HandleFunc("/api/sanctions/{id:[0-9]+}", handlers.DeleteSanction).Methods("DELETE")
+	router.HandleFunc("/api/standings/computed", handlers.GetComputedStandings).Methods("GET")
+	router.HandleFunc("/api/standings/reconcile", handlers.ReconcileStandings).Methods("GET")
 	router.HandleFunc("/api/standings/{id:[0-9]+}", handlers.UpdateStanding).Methods("PUT")
 	router.HandleFunc("/api/standings/order", handlers.UpdateStandingsOrder).Methods("POST")
 	router.HandleFunc("/api/teams/{id}", handlers.GetTeamByID).Methods("GET")
 	router.HandleFunc("/api/teams/{id}", handlers.UpdateTeam).Methods("PUT")
 	router.HandleFunc("/api/teams/{id}", handlers.DeleteTeam).Methods("DELETE")
 	router.HandleFunc("/api/teams/{id}/logo", handlers.UploadTeamLogo).Methods("POST")
+	router.HandleFunc("/api/teams/{a:[0-9]+}/vs/{b:[0-9]+}", handlers.GetHeadToHead).Methods("GET")
+	router.HandleFunc("/api/teams/{id:[0-9]+}/stats", handlers.GetTeamStats).Methods("GET")
+	router.HandleFunc("/api/discipline", handlers.GetDiscipline).Methods("GET")
+	router.HandleFunc("/api/quota", handlers.GetQuotaCompliance).Methods("GET")
 	router.HandleFunc("/api/stadiums", handlers.GetStadiums).Methods("GET")
 	router.HandleFunc("/api/matches", handlers.GetMatches).Methods("GET")
 	router.HandleFunc("/api/matches", handlers.CreateMatch).Methods("POST")
+	router.HandleFunc("/api/matches/conflicts", handlers.GetMatchConflicts).Methods("GET")
 	router.HandleFunc("/api/matches/{id}", handlers.GetMatchByID).Methods("GET")
 	router.HandleFunc("/api/matches/{id}", handlers.DeleteMatch).Methods("DELETE")
 	router.HandleFunc("/api/matches/{id}", handlers.UpdateMatch).Methods("PUT")
+	router.HandleFunc("/api/fixtures/preview", handlers.PreviewFixtures).Methods("POST")
+	router.HandleFunc("/api/fixtures/commit", handlers.CommitFixtures).Methods("POST")
 	router.HandleFunc("/api/channels", handlers.GetChannels).Methods("GET")
 	// เพิ่ม route สำหรับ scraper
 	router.HandleFunc("/scraper/matches", handlers.ScrapeMatchesHandler).Methods("GET")
@@ -153,9 +181,18 @@ func main() {
 	// Player routes
 	router.HandleFunc("/api/players", handlers.GetPlayers).Methods("GET")
 	router.HandleFunc("/api/players/top-scorers", handlers.GetTopScorers).Methods("GET")
+	router.HandleFunc("/api/players/leaders", handlers.GetPlayerLeaders).Methods("GET")
+	router.HandleFunc("/api/players/duplicates", handlers.GetDuplicatePlayers).Methods("GET")
+	router.HandleFunc("/api/players/duplicates/ignore", handlers.IgnoreDuplicatePlayers).Methods("POST")
+	router.HandleFunc("/api/name-suggestions", handlers.GetNameSuggestions).Methods("GET")
+	router.HandleFunc("/api/name-suggestions/generate", handlers.GenerateNameSuggestions).Methods("POST")
+	router.HandleFunc("/api/name-suggestions/{id:[0-9]+}", handlers.ReviewNameSuggestion).Methods("PUT")
+	router.HandleFunc("/api/players/merge", handlers.MergePlayers).Methods("POST")
 	router.HandleFunc("/api/players/team/{team_id}", handlers.GetPlayersByTeamID).Methods("GET")
 	router.HandleFunc("/api/players/team-post/{team_post_id}", handlers.GetPlayersByTeamPost).Methods("GET")
 	router.HandleFunc("/api/players/{id:[0-9]+}", handlers.UpdatePlayer).Methods("PUT")
+	router.HandleFunc("/api/players/{id:[0-9]+}/photo", handlers.UploadPlayerPhoto).Methods("POST")
+	router.HandleFunc("/api/players/{id:[0-9]+}/photo/pin", handlers.SetPlayerPhotoPin).Methods("PUT")
 	router.Handle("/players.html", middleware.CheckAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		db := database.DB
 		if db == nil {
@@ -181,7 +218,7 @@ func main() {
 		}
 
 		baseQuery := `SELECT p.id, p.name, p.full_name_en, p.shirt_number, p.position, p.photo_url, p.matches_played, p.goals, p.yellow_cards, p.red_cards, p.status, t.name_th as team_name FROM players p LEFT JOIN teams t ON p.team_id = t.id`
-		var where []string
+		where := []string{"p.merged_into_id IS NULL"}
 		var args []interface{}
 		if teamID != "" {
 			where = append(where, "p.team_id = ?")
@@ -344,6 +381,42 @@ func main() {
 		tmpl.Execute(w, nil)
 	})))
 
+	router.Handle("/quota.html", middleware.CheckAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tmpl, err := template.ParseFiles("templates/quota.html", "templates/_nav.html")
+		if err != nil {
+			http.Error(w, "Template error", 500)
+			return
+		}
+		tmpl.Execute(w, nil)
+	})))
+
+	router.Handle("/player_duplicates.html", middleware.CheckAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tmpl, err := template.ParseFiles("templates/player_duplicates.html", "templates/_nav.html")
+		if err != nil {
+			http.Error(w, "Template error", 500)
+			return
+		}
+		tmpl.Execute(w, nil)
+	})))
+
+	router.Handle("/conflicts.html", middleware.CheckAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tmpl, err := template.ParseFiles("templates/conflicts.html", "templates/_nav.html")
+		if err != nil {
+			http.Error(w, "Template error", 500)
+			return
+		}
+		tmpl.Execute(w, nil)
+	})))
+
+	router.Handle("/name_suggestions.html", middleware.CheckAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tmpl, err := template.ParseFiles("templates/name_suggestions.html", "templates/_nav.html")
+		if err != nil {
+			http.Error(w, "Template error", 500)
+			return
+		}
+		tmpl.Execute(w, nil)
+	})))
+
 
 
 	// เพิ่ม route สำหรับหน้า login.html
@@ -360,6 +433,8 @@ func main() {
 	router.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
 	// Serve team logos from /img/teams/ -> ./img/teams/
 	router.PathPrefix("/img/teams/").Handler(http.StripPrefix("/img/teams/", http.FileServer(http.Dir("img/teams/"))))
+	// Serve league logos from /img/leagues/ -> ./img/leagues/
+	router.PathPrefix("/img/leagues/").Handler(http.StripPrefix("/img/leagues/", http.FileServer(http.Dir("img/leagues/"))))
 	// Serve channel images from /img/channels/ -> ./img/channels/
 	router.PathPrefix("/img/channels/").Handler(http.StripPrefix("/img/channels/", http.FileServer(http.Dir("img/channels/"))))
 	// Serve player images from /img/player/ -> ./img/player/
@@ -367,6 +442,7 @@ func main() {
 
 	// Ensure image directories exist to avoid 404s when files are created at runtime
 	os.MkdirAll("img/teams", 0755)
+	os.MkdirAll("img/leagues", 0755)
 	os.MkdirAll("img/channels", 0755)
 	os.MkdirAll("img/player", 0755)
 
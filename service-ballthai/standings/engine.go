@@ -0,0 +1,173 @@
+// Package standings คำนวณตารางคะแนนจากผลการแข่งขันในตาราง matches
+// โดยไม่ต้องพึ่ง stage-standing-public หรือ HTML จาก thscore
+package standings
+
+import (
+	"sort"
+
+	"go-ballthai-scraper/models"
+)
+
+// Tie-breaker keys ที่รองรับใน standing_rules.tiebreakers (คั่นด้วย comma ตามลำดับความสำคัญ)
+const (
+	TieBreakH2HPoints         = "h2h_points"
+	TieBreakH2HGoalDifference = "h2h_goal_difference"
+	TieBreakH2HGoalsFor       = "h2h_goals_for"
+	TieBreakGoalDifference    = "goal_difference"
+	TieBreakGoalsFor          = "goals_for"
+	TieBreakWins              = "wins"
+)
+
+// Row คือหนึ่งแถวของตารางคะแนนที่คำนวณได้
+type Row struct {
+	TeamID         int `json:"team_id"`
+	Rank           int `json:"rank"`
+	MatchesPlayed  int `json:"matches_played"`
+	Wins           int `json:"wins"`
+	Draws          int `json:"draws"`
+	Losses         int `json:"losses"`
+	GoalsFor       int `json:"goals_for"`
+	GoalsAgainst   int `json:"goals_against"`
+	GoalDifference int `json:"goal_difference"`
+	Points         int `json:"points"`
+}
+
+// Compute สร้างตารางคะแนนจากผลการแข่งขันที่จบแล้ว แล้วจัดอันดับตามกติกาของลีก
+// ทีมที่อยู่ใน teamIDs แต่ยังไม่ได้ลงเล่นจะถูกใส่ในตารางด้วยค่า 0
+func Compute(results []models.MatchResult, rules models.StandingRules, teamIDs ...int) []Row {
+	return ComputeAdjusted(results, rules, nil, teamIDs...)
+}
+
+// ComputeAdjusted เหมือน Compute แต่บวกคะแนนปรับ (team_id -> คะแนน จาก Adjustments) ก่อนจัดอันดับ
+// head-to-head ยังใช้คะแนนจากผลการแข่งขันจริง
+func ComputeAdjusted(results []models.MatchResult, rules models.StandingRules, adjustments map[int]int, teamIDs ...int) []Row {
+	table := tally(results, rules, nil)
+	for _, id := range teamIDs {
+		if _, ok := table[id]; !ok {
+			table[id] = &Row{TeamID: id}
+		}
+	}
+	for id, pts := range adjustments {
+		if r, ok := table[id]; ok {
+			r.Points += pts
+		}
+	}
+
+	rows := make([]*Row, 0, len(table))
+	for _, r := range table {
+		rows = append(rows, r)
+	}
+
+	// ตาราง head-to-head แยกตามกลุ่มทีมที่คะแนนเท่ากัน
+	needH2H := false
+	for _, tb := range rules.TieBreakers {
+		if tb == TieBreakH2HPoints || tb == TieBreakH2HGoalDifference || tb == TieBreakH2HGoalsFor {
+			needH2H = true
+		}
+	}
+	h2h := map[int]map[int]*Row{}
+	if needH2H {
+		groups := map[int]map[int]bool{}
+		for _, r := range rows {
+			if groups[r.Points] == nil {
+				groups[r.Points] = map[int]bool{}
+			}
+			groups[r.Points][r.TeamID] = true
+		}
+		for pts, members := range groups {
+			if len(members) > 1 {
+				h2h[pts] = tally(results, rules, members)
+			}
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		mini := h2h[a.Points]
+		for _, tb := range rules.TieBreakers {
+			if d := compareBy(tb, a, b, mini); d != 0 {
+				return d > 0
+			}
+		}
+		return a.TeamID < b.TeamID
+	})
+
+	out := make([]Row, len(rows))
+	for i, r := range rows {
+		r.Rank = i + 1
+		out[i] = *r
+	}
+	return out
+}
+
+// tally รวมสถิติจากผลการแข่งขัน ถ้า only ไม่ใช่ nil จะนับเฉพาะนัดที่ทั้งสองทีมอยู่ใน only (ใช้ทำ head-to-head)
+func tally(results []models.MatchResult, rules models.StandingRules, only map[int]bool) map[int]*Row {
+	table := map[int]*Row{}
+	get := func(id int) *Row {
+		if table[id] == nil {
+			table[id] = &Row{TeamID: id}
+		}
+		return table[id]
+	}
+	for _, m := range results {
+		if only != nil && (!only[m.HomeTeamID] || !only[m.AwayTeamID]) {
+			continue
+		}
+		home, away := get(m.HomeTeamID), get(m.AwayTeamID)
+		home.MatchesPlayed++
+		away.MatchesPlayed++
+		home.GoalsFor += m.HomeScore
+		home.GoalsAgainst += m.AwayScore
+		away.GoalsFor += m.AwayScore
+		away.GoalsAgainst += m.HomeScore
+		switch {
+		case m.HomeScore > m.AwayScore:
+			home.Wins++
+			away.Losses++
+			home.Points += rules.PointsWin
+			away.Points += rules.PointsLoss
+		case m.HomeScore < m.AwayScore:
+			away.Wins++
+			home.Losses++
+			away.Points += rules.PointsWin
+			home.Points += rules.PointsLoss
+		default:
+			home.Draws++
+			away.Draws++
+			home.Points += rules.PointsDraw
+			away.Points += rules.PointsDraw
+		}
+	}
+	for _, r := range table {
+		r.GoalDifference = r.GoalsFor - r.GoalsAgainst
+	}
+	return table
+}
+
+// compareBy คืนค่า >0 ถ้า a ดีกว่า b, <0 ถ้า b ดีกว่า, 0 ถ้าเท่ากันตามเกณฑ์ tb
+func compareBy(tb string, a, b *Row, mini map[int]*Row) int {
+	h2h := func(id int) Row {
+		if mini != nil && mini[id] != nil {
+			return *mini[id]
+		}
+		return Row{}
+	}
+	switch tb {
+	case TieBreakH2HPoints:
+		return h2h(a.TeamID).Points - h2h(b.TeamID).Points
+	case TieBreakH2HGoalDifference:
+		return h2h(a.TeamID).GoalDifference - h2h(b.TeamID).GoalDifference
+	case TieBreakH2HGoalsFor:
+		return h2h(a.TeamID).GoalsFor - h2h(b.TeamID).GoalsFor
+	case TieBreakGoalDifference:
+		return a.GoalDifference - b.GoalDifference
+	case TieBreakGoalsFor:
+		return a.GoalsFor - b.GoalsFor
+	case TieBreakWins:
+		return a.Wins - b.Wins
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package standings
+
+import (
+	"reflect"
+	"testing"
+
+	"go-ballthai-scraper/models"
+)
+
+func result(home, away, homeScore, awayScore int) models.MatchResult {
+	return models.MatchResult{HomeTeamID: home, AwayTeamID: away, HomeScore: homeScore, AwayScore: awayScore}
+}
+
+func TestCompute(t *testing.T) {
+	rules := func(tb ...string) models.StandingRules {
+		return models.StandingRules{PointsWin: 3, PointsDraw: 1, PointsLoss: 0, TieBreakers: tb}
+	}
+	// 1, 2 และ 4 ได้ 3 คะแนนเท่ากัน: 1 ผลต่างประตูดีสุด แต่แพ้ 2 ในนัดที่เจอกัน, 4 ชนะ 2
+	h2hResults := []models.MatchResult{result(1, 2, 0, 1), result(1, 3, 5, 0), result(2, 4, 0, 1)}
+
+	tests := []struct {
+		name       string
+		results    []models.MatchResult
+		rules      models.StandingRules
+		teamIDs    []int
+		wantOrder  []int
+		wantPoints []int
+	}{
+		{
+			name:       "goal difference breaks a points tie",
+			results:    []models.MatchResult{result(1, 2, 2, 0), result(2, 3, 1, 1)},
+			rules:      rules(TieBreakGoalDifference, TieBreakGoalsFor),
+			wantOrder:  []int{1, 3, 2},
+			wantPoints: []int{3, 1, 1},
+		},
+		{
+			name:       "overall goal difference without head-to-head",
+			results:    h2hResults,
+			rules:      rules(TieBreakGoalDifference),
+			wantOrder:  []int{1, 4, 2, 3},
+			wantPoints: []int{3, 3, 3, 0},
+		},
+		{
+			name:       "head-to-head points among tied teams come first",
+			results:    h2hResults,
+			rules:      rules(TieBreakH2HPoints, TieBreakGoalDifference),
+			wantOrder:  []int{4, 2, 1, 3},
+			wantPoints: []int{3, 3, 3, 0},
+		},
+		{
+			name:       "teams without matches are listed with zero and full ties fall back to team id",
+			results:    []models.MatchResult{result(2, 1, 1, 1)},
+			rules:      rules(TieBreakGoalDifference, TieBreakGoalsFor),
+			teamIDs:    []int{9, 1, 2},
+			wantOrder:  []int{1, 2, 9},
+			wantPoints: []int{1, 1, 0},
+		},
+		{
+			name:       "custom points per result",
+			results:    []models.MatchResult{result(1, 2, 0, 0), result(3, 1, 0, 1)},
+			rules:      models.StandingRules{PointsWin: 2, PointsDraw: 1, TieBreakers: []string{TieBreakWins}},
+			wantOrder:  []int{1, 2, 3},
+			wantPoints: []int{3, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := Compute(tt.results, tt.rules, tt.teamIDs...)
+			var order, points []int
+			for i, r := range rows {
+				if r.Rank != i+1 {
+					t.Errorf("row %d has rank %d", i, r.Rank)
+				}
+				order = append(order, r.TeamID)
+				points = append(points, r.Points)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(points, tt.wantPoints) {
+				t.Errorf("points = %v, want %v", points, tt.wantPoints)
+			}
+		})
+	}
+}
+
+func TestComputeCountsResults(t *testing.T) {
+	rows := Compute([]models.MatchResult{result(1, 2, 3, 1), result(2, 1, 2, 2)},
+		models.StandingRules{PointsWin: 3, PointsDraw: 1})
+	want := []Row{
+		{TeamID: 1, Rank: 1, MatchesPlayed: 2, Wins: 1, Draws: 1, GoalsFor: 5, GoalsAgainst: 3, GoalDifference: 2, Points: 4},
+		{TeamID: 2, Rank: 2, MatchesPlayed: 2, Draws: 1, Losses: 1, GoalsFor: 3, GoalsAgainst: 5, GoalDifference: -2, Points: 1},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Compute = %+v, want %+v", rows, want)
+	}
+}
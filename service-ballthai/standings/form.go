@@ -0,0 +1,108 @@
+package standings
+
+import (
+	"math"
+
+	"go-ballthai-scraper/models"
+)
+
+// FormEntry คือผลหนึ่งนัดในฟอร์มล่าสุดของทีม
+type FormEntry struct {
+	MatchID      int    `json:"match_id"`
+	Date         string `json:"date"`
+	Result       string `json:"result"` // W, D หรือ L
+	OpponentID   int    `json:"opponent_id"`
+	Home         bool   `json:"home"`
+	GoalsFor     int    `json:"goals_for"`
+	GoalsAgainst int    `json:"goals_against"`
+}
+
+// Streak คือผลติดต่อกันล่าสุด เช่น ชนะ 3 นัดติด = {Type: "W", Count: 3}
+type Streak struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// TeamForm รวมฟอร์มล่าสุด สถิติเหย้า/เยือน และคะแนนเฉลี่ยต่อนัดของทีม
+type TeamForm struct {
+	Last          []FormEntry `json:"form"` // เรียงจากเก่าไปใหม่
+	Streak        *Streak     `json:"streak,omitempty"`
+	Home          Row         `json:"home"`
+	Away          Row         `json:"away"`
+	PointsPerGame float64     `json:"points_per_game"`
+}
+
+// BuildForms คำนวณฟอร์มของทุกทีมจากผลการแข่งขัน (results ต้องเรียงตามวันที่จากเก่าไปใหม่)
+// last คือจำนวนนัดล่าสุดที่ต้องการในฟอร์ม (เช่น 5)
+func BuildForms(results []models.MatchResult, rules models.StandingRules, last int) map[int]*TeamForm {
+	forms := map[int]*TeamForm{}
+	history := map[int][]FormEntry{}
+	get := func(id int) *TeamForm {
+		if forms[id] == nil {
+			forms[id] = &TeamForm{Home: Row{TeamID: id}, Away: Row{TeamID: id}}
+		}
+		return forms[id]
+	}
+	for _, m := range results {
+		home, away := get(m.HomeTeamID), get(m.AwayTeamID)
+		addResult(&home.Home, m.HomeScore, m.AwayScore, rules)
+		addResult(&away.Away, m.AwayScore, m.HomeScore, rules)
+		history[m.HomeTeamID] = append(history[m.HomeTeamID], FormEntry{
+			MatchID: m.MatchID, Date: m.StartDate, Result: resultLetter(m.HomeScore, m.AwayScore),
+			OpponentID: m.AwayTeamID, Home: true, GoalsFor: m.HomeScore, GoalsAgainst: m.AwayScore,
+		})
+		history[m.AwayTeamID] = append(history[m.AwayTeamID], FormEntry{
+			MatchID: m.MatchID, Date: m.StartDate, Result: resultLetter(m.AwayScore, m.HomeScore),
+			OpponentID: m.HomeTeamID, Home: false, GoalsFor: m.AwayScore, GoalsAgainst: m.HomeScore,
+		})
+	}
+	for id, f := range forms {
+		h := history[id]
+		if len(h) > last {
+			f.Last = h[len(h)-last:]
+		} else {
+			f.Last = h
+		}
+		if n := len(h); n > 0 {
+			s := &Streak{Type: h[n-1].Result}
+			for i := n - 1; i >= 0 && h[i].Result == s.Type; i-- {
+				s.Count++
+			}
+			f.Streak = s
+		}
+		played := f.Home.MatchesPlayed + f.Away.MatchesPlayed
+		if played > 0 {
+			f.PointsPerGame = math.Round(float64(f.Home.Points+f.Away.Points)/float64(played)*100) / 100
+		}
+	}
+	return forms
+}
+
+// addResult บวกผลหนึ่งนัดเข้าแถวสถิติของทีม
+func addResult(r *Row, goalsFor, goalsAgainst int, rules models.StandingRules) {
+	r.MatchesPlayed++
+	r.GoalsFor += goalsFor
+	r.GoalsAgainst += goalsAgainst
+	r.GoalDifference = r.GoalsFor - r.GoalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		r.Wins++
+		r.Points += rules.PointsWin
+	case goalsFor < goalsAgainst:
+		r.Losses++
+		r.Points += rules.PointsLoss
+	default:
+		r.Draws++
+		r.Points += rules.PointsDraw
+	}
+}
+
+func resultLetter(goalsFor, goalsAgainst int) string {
+	switch {
+	case goalsFor > goalsAgainst:
+		return "W"
+	case goalsFor < goalsAgainst:
+		return "L"
+	}
+	return "D"
+}
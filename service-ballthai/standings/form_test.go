@@ -0,0 +1,93 @@
+package standings
+
+import (
+	"reflect"
+	"testing"
+
+	"go-ballthai-scraper/models"
+)
+
+func TestBuildForms(t *testing.T) {
+	rules := models.StandingRules{PointsWin: 3, PointsDraw: 1}
+	dated := func(id int, date string, m models.MatchResult) models.MatchResult {
+		m.MatchID, m.StartDate = id, date
+		return m
+	}
+	// ทีม 1: ชนะ 2 (เหย้า), แพ้ 3, ชนะ 4 (เหย้า), ชนะ 2 (เยือน), ชนะ 3 (เหย้า)
+	results := []models.MatchResult{
+		dated(1, "2025-08-01", result(1, 2, 2, 0)),
+		dated(2, "2025-08-08", result(3, 1, 1, 0)),
+		dated(3, "2025-08-15", result(1, 4, 3, 3)),
+		dated(4, "2025-08-22", result(2, 1, 1, 2)),
+		dated(5, "2025-08-29", result(1, 3, 1, 0)),
+	}
+
+	tests := []struct {
+		name       string
+		team, last int
+		wantForm   []string
+		wantStreak *Streak
+		wantHome   Row
+		wantAway   Row
+		wantPPG    float64
+	}{
+		{
+			name: "last n results in date order with the current streak", team: 1, last: 3,
+			wantForm:   []string{"D", "W", "W"},
+			wantStreak: &Streak{Type: "W", Count: 2},
+			wantHome:   Row{TeamID: 1, MatchesPlayed: 3, Wins: 2, Draws: 1, GoalsFor: 6, GoalsAgainst: 3, GoalDifference: 3, Points: 7},
+			wantAway:   Row{TeamID: 1, MatchesPlayed: 2, Wins: 1, Losses: 1, GoalsFor: 2, GoalsAgainst: 2, Points: 3},
+			wantPPG:    2,
+		},
+		{
+			name: "fewer matches than requested", team: 4, last: 5,
+			wantForm:   []string{"D"},
+			wantStreak: &Streak{Type: "D", Count: 1},
+			wantHome:   Row{TeamID: 4},
+			wantAway:   Row{TeamID: 4, MatchesPlayed: 1, Draws: 1, GoalsFor: 3, GoalsAgainst: 3, Points: 1},
+			wantPPG:    1,
+		},
+		{
+			name: "points per game covers home and away matches", team: 3, last: 5,
+			wantForm:   []string{"W", "L"},
+			wantStreak: &Streak{Type: "L", Count: 1},
+			wantHome:   Row{TeamID: 3, MatchesPlayed: 1, Wins: 1, GoalsFor: 1, GoalDifference: 1, Points: 3},
+			wantAway:   Row{TeamID: 3, MatchesPlayed: 1, Losses: 1, GoalsAgainst: 1, GoalDifference: -1},
+			wantPPG:    1.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := BuildForms(results, rules, tt.last)[tt.team]
+			if f == nil {
+				t.Fatalf("no form for team %d", tt.team)
+			}
+			var form []string
+			for _, e := range f.Last {
+				form = append(form, e.Result)
+			}
+			if !reflect.DeepEqual(form, tt.wantForm) {
+				t.Errorf("form = %v, want %v", form, tt.wantForm)
+			}
+			if !reflect.DeepEqual(f.Streak, tt.wantStreak) {
+				t.Errorf("streak = %+v, want %+v", f.Streak, tt.wantStreak)
+			}
+			if f.Home != tt.wantHome || f.Away != tt.wantAway {
+				t.Errorf("home/away = %+v / %+v, want %+v / %+v", f.Home, f.Away, tt.wantHome, tt.wantAway)
+			}
+			if f.PointsPerGame != tt.wantPPG {
+				t.Errorf("points per game = %v, want %v", f.PointsPerGame, tt.wantPPG)
+			}
+		})
+	}
+}
+
+func TestBuildFormsEntryDetail(t *testing.T) {
+	m := result(5, 6, 0, 2)
+	m.MatchID, m.StartDate = 9, "2025-09-01"
+	got := BuildForms([]models.MatchResult{m}, models.StandingRules{PointsWin: 3, PointsDraw: 1}, 5)[6].Last
+	want := []FormEntry{{MatchID: 9, Date: "2025-09-01", Result: "W", OpponentID: 5, Home: false, GoalsFor: 2, GoalsAgainst: 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("form = %+v, want %+v", got, want)
+	}
+}
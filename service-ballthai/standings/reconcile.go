@@ -0,0 +1,62 @@
+package standings
+
+import "go-ballthai-scraper/models"
+
+// Mismatch คือความต่างของหนึ่งฟิลด์ระหว่างตารางที่ดึงมา (scraped) กับตารางที่คำนวณเอง (computed)
+type Mismatch struct {
+	Field    string `json:"field"`
+	Scraped  int    `json:"scraped"`
+	Computed int    `json:"computed"`
+}
+
+// ReconcileRow คือทีมที่ตารางทั้งสองแบบไม่ตรงกัน
+type ReconcileRow struct {
+	TeamID     int        `json:"team_id"`
+	TeamName   *string    `json:"team_name,omitempty"`
+	StandingID int        `json:"standing_id,omitempty"`
+	Missing    string     `json:"missing,omitempty"` // "scraped" หรือ "computed" ถ้าทีมมีอยู่ฝั่งเดียว
+	Mismatches []Mismatch `json:"mismatches,omitempty"`
+}
+
+// Reconcile เทียบตาราง standings ที่ดึงมากับตารางที่คำนวณ คืนเฉพาะแถวที่ไม่ตรงกัน
+func Reconcile(scraped []models.StandingDB, computed []Row) []ReconcileRow {
+	byTeam := map[int]Row{}
+	for _, c := range computed {
+		byTeam[c.TeamID] = c
+	}
+	seen := map[int]bool{}
+	report := []ReconcileRow{}
+	for _, s := range scraped {
+		seen[s.TeamID] = true
+		c, ok := byTeam[s.TeamID]
+		if !ok {
+			report = append(report, ReconcileRow{TeamID: s.TeamID, TeamName: s.TeamName, StandingID: s.ID, Missing: "computed"})
+			continue
+		}
+		var diffs []Mismatch
+		check := func(field string, scrapedVal, computedVal int) {
+			if scrapedVal != computedVal {
+				diffs = append(diffs, Mismatch{Field: field, Scraped: scrapedVal, Computed: computedVal})
+			}
+		}
+		check("matches_played", s.MatchesPlayed, c.MatchesPlayed)
+		check("wins", s.Wins, c.Wins)
+		check("draws", s.Draws, c.Draws)
+		check("losses", s.Losses, c.Losses)
+		check("goals_for", s.GoalsFor, c.GoalsFor)
+		check("goals_against", s.GoalsAgainst, c.GoalsAgainst)
+		check("points", s.Points, c.Points)
+		if s.CurrentRank.Valid {
+			check("current_rank", int(s.CurrentRank.Int64), c.Rank)
+		}
+		if len(diffs) > 0 {
+			report = append(report, ReconcileRow{TeamID: s.TeamID, TeamName: s.TeamName, StandingID: s.ID, Mismatches: diffs})
+		}
+	}
+	for _, c := range computed {
+		if !seen[c.TeamID] {
+			report = append(report, ReconcileRow{TeamID: c.TeamID, Missing: "scraped"})
+		}
+	}
+	return report
+}
@@ -0,0 +1,98 @@
+package standings
+
+import (
+	"sort"
+
+	"go-ballthai-scraper/models"
+)
+
+// Adjustments รวมคะแนนปรับจาก sanctions ของแต่ละทีมสำหรับตารางของ stage ที่ระบุ (0 = ไม่มี stage)
+// sanction ที่ไม่ระบุ stage ใช้กับทุกตารางของทีม
+func Adjustments(sanctions []models.SanctionDB, stageID int64) map[int]int {
+	adj := map[int]int{}
+	for _, s := range sanctions {
+		if s.StageID != nil && int64(*s.StageID) != stageID {
+			continue
+		}
+		adj[s.TeamID] += s.Points
+	}
+	return adj
+}
+
+// AdjustStandings บวกคะแนนปรับจาก sanctions เข้ากับตารางที่ดึงมา แล้วจัดอันดับใหม่ภายในแต่ละ stage
+// ทีมที่คะแนนหลังปรับเท่ากันคงลำดับเดิม (ตารางต้นทางตัดสิน tie-breaker มาแล้ว)
+// rows ถูกแก้ไขและเรียงใหม่ตาม current_rank ภายในแต่ละ stage (stage คงลำดับเดิม); คืนคะแนนปรับของแต่ละแถวตามลำดับใหม่
+func AdjustStandings(rows []models.StandingDB, sanctions []models.SanctionDB) []int {
+	adj := make([]int, len(rows))
+	groups := map[int64][]int{}
+	stagePos := map[int64]int{} // ลำดับของ stage ตามที่ปรากฏครั้งแรกในตารางต้นทาง
+	for i := range rows {
+		key := rows[i].StageID.Int64
+		adj[i] = Adjustments(sanctions, key)[rows[i].TeamID]
+		if _, ok := groups[key]; !ok {
+			stagePos[key] = len(stagePos)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	hasAdj := false
+	for _, a := range adj {
+		hasAdj = hasAdj || a != 0
+	}
+	if !hasAdj {
+		return adj
+	}
+
+	for _, idx := range groups {
+		changed := false
+		for _, i := range idx {
+			if adj[i] != 0 {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		// อันดับเดิมของกลุ่ม (เรียงน้อยไปมาก) จะถูกแจกใหม่ตามคะแนนหลังปรับ
+		ranks := make([]int64, len(idx))
+		for n, i := range idx {
+			ranks[n] = rows[i].CurrentRank.Int64
+			if !rows[i].CurrentRank.Valid || ranks[n] <= 0 {
+				ranks[n] = int64(n + 1)
+			}
+		}
+		sort.Slice(ranks, func(a, b int) bool { return ranks[a] < ranks[b] })
+		order := append([]int(nil), idx...)
+		sort.SliceStable(order, func(a, b int) bool {
+			return rows[order[a]].Points+adj[order[a]] > rows[order[b]].Points+adj[order[b]]
+		})
+		for n, i := range order {
+			rows[i].CurrentRank.Int64, rows[i].CurrentRank.Valid = ranks[n], true
+		}
+	}
+
+	type adjusted struct {
+		row models.StandingDB
+		adj int
+	}
+	all := make([]adjusted, len(rows))
+	for i := range rows {
+		rows[i].Points += adj[i]
+		all[i] = adjusted{rows[i], adj[i]}
+	}
+	sort.SliceStable(all, func(a, b int) bool {
+		sa, sb := stagePos[all[a].row.StageID.Int64], stagePos[all[b].row.StageID.Int64]
+		if sa != sb {
+			return sa < sb
+		}
+		ra, rb := all[a].row.CurrentRank, all[b].row.CurrentRank
+		if ra.Valid != rb.Valid {
+			return ra.Valid
+		}
+		return ra.Int64 < rb.Int64
+	})
+	for i := range all {
+		rows[i], adj[i] = all[i].row, all[i].adj
+	}
+	return adj
+}
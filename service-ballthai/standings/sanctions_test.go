@@ -0,0 +1,121 @@
+package standings
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"go-ballthai-scraper/models"
+)
+
+func intPtr(v int) *int { return &v }
+
+func standing(stageID int64, teamID, points int, rank int64) models.StandingDB {
+	return models.StandingDB{
+		TeamID:      teamID,
+		StageID:     sql.NullInt64{Int64: stageID, Valid: stageID != 0},
+		Points:      points,
+		CurrentRank: sql.NullInt64{Int64: rank, Valid: true},
+	}
+}
+
+func TestAdjustments(t *testing.T) {
+	sanctions := []models.SanctionDB{
+		{TeamID: 1, Points: -3},
+		{TeamID: 1, Points: -2, StageID: intPtr(10)},
+		{TeamID: 2, Points: -6, StageID: intPtr(20)},
+	}
+	tests := []struct {
+		name    string
+		stageID int64
+		want    map[int]int
+	}{
+		{"sanctions without a stage apply to every table", 0, map[int]int{1: -3}},
+		{"stage sanctions add up with league-wide ones", 10, map[int]int{1: -5}},
+		{"other stages are ignored", 20, map[int]int{1: -3, 2: -6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Adjustments(sanctions, tt.stageID); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Adjustments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustStandings(t *testing.T) {
+	type team struct {
+		stage  int64
+		id     int
+		points int
+		rank   int64
+		adj    int
+	}
+	tests := []struct {
+		name      string
+		rows      []models.StandingDB
+		sanctions []models.SanctionDB
+		want      []team
+	}{
+		{
+			name:      "no sanctions keeps the table",
+			rows:      []models.StandingDB{standing(0, 1, 10, 1), standing(0, 2, 8, 2)},
+			sanctions: []models.SanctionDB{{TeamID: 9, Points: -3}},
+			want:      []team{{0, 1, 10, 1, 0}, {0, 2, 8, 2, 0}},
+		},
+		{
+			name:      "deduction drops a team and level points keep the source order",
+			rows:      []models.StandingDB{standing(0, 1, 10, 1), standing(0, 2, 8, 2), standing(0, 3, 7, 3)},
+			sanctions: []models.SanctionDB{{TeamID: 1, Points: -3}},
+			want:      []team{{0, 2, 8, 1, 0}, {0, 1, 7, 2, -3}, {0, 3, 7, 3, 0}},
+		},
+		{
+			name: "stages are re-ranked separately and stay grouped",
+			rows: []models.StandingDB{
+				standing(20, 4, 9, 1), standing(20, 5, 6, 2),
+				standing(10, 1, 10, 1), standing(10, 2, 8, 2),
+			},
+			sanctions: []models.SanctionDB{
+				{TeamID: 1, Points: -4, StageID: intPtr(10)},
+				{TeamID: 4, Points: -1},
+			},
+			want: []team{
+				{20, 4, 8, 1, -1}, {20, 5, 6, 2, 0},
+				{10, 2, 8, 1, 0}, {10, 1, 6, 2, -4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := append([]models.StandingDB(nil), tt.rows...)
+			adj := AdjustStandings(rows, tt.sanctions)
+			var got []team
+			for i, r := range rows {
+				got = append(got, team{r.StageID.Int64, r.TeamID, r.Points, r.CurrentRank.Int64, adj[i]})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AdjustStandings = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeAdjusted(t *testing.T) {
+	rules := models.StandingRules{PointsWin: 3, PointsDraw: 1, TieBreakers: []string{TieBreakGoalDifference}}
+	// ทีม 7 ไม่มีในตาราง คะแนนปรับของทีมนี้ต้องไม่ทำให้เกิดแถวใหม่
+	rows := ComputeAdjusted([]models.MatchResult{result(1, 2, 2, 0)}, rules, map[int]int{1: -6, 7: -3})
+	var order, points []int
+	for i, r := range rows {
+		if r.Rank != i+1 {
+			t.Errorf("row %d has rank %d", i, r.Rank)
+		}
+		order = append(order, r.TeamID)
+		points = append(points, r.Points)
+	}
+	if want := []int{2, 1}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if want := []int{0, -3}; !reflect.DeepEqual(points, want) {
+		t.Errorf("points = %v, want %v", points, want)
+	}
+}
@@ -0,0 +1,103 @@
+package standings
+
+import (
+	"math"
+
+	"go-ballthai-scraper/models"
+)
+
+// ResultSummary คือผลหนึ่งนัดที่ใช้แสดงชนะขาด/แพ้หนักที่สุด
+type ResultSummary struct {
+	MatchID      int    `json:"match_id"`
+	Date         string `json:"date"`
+	OpponentID   int    `json:"opponent_id"`
+	Home         bool   `json:"home"`
+	GoalsFor     int    `json:"goals_for"`
+	GoalsAgainst int    `json:"goals_against"`
+}
+
+// TeamStats คือสถิติรวมของทีมในช่วงผลการแข่งขันที่ให้มา
+type TeamStats struct {
+	Overall             Row            `json:"overall"`
+	Home                Row            `json:"home"`
+	Away                Row            `json:"away"`
+	GoalsForPerGame     float64        `json:"goals_for_per_game"`
+	GoalsAgainstPerGame float64        `json:"goals_against_per_game"`
+	CleanSheets         int            `json:"clean_sheets"`
+	FailedToScore       int            `json:"failed_to_score"`
+	BiggestWin          *ResultSummary `json:"biggest_win"`
+	BiggestLoss         *ResultSummary `json:"biggest_loss"`
+	ByHalf              *HalfStats     `json:"by_half"` // nil = ไม่มีนัดที่มีสกอร์ครึ่งแรก
+}
+
+// HalfStats คือผลแยกรายครึ่งจากนัดที่มีสกอร์ครึ่งแรก (ครึ่งหลัง = สกอร์เวลาปกติ - สกอร์ครึ่งแรก)
+// Matches คือจำนวนนัดที่นับได้ ซึ่งอาจน้อยกว่านัดทั้งหมดถ้าบางนัดไม่มีสกอร์ครึ่งแรก
+type HalfStats struct {
+	Matches    int `json:"matches"`
+	FirstHalf  Row `json:"first_half"`
+	SecondHalf Row `json:"second_half"`
+}
+
+// BuildTeamStats คำนวณสถิติของทีมจากผลการแข่งขันที่จบแล้ว (results ต้องเรียงจากเก่าไปใหม่)
+// เมื่อผลต่างประตูเท่ากัน ชนะขาด/แพ้หนักจะเลือกนัดที่มีประตูมากกว่า แล้วเลือกนัดล่าสุด
+func BuildTeamStats(teamID int, results []models.MatchResult, rules models.StandingRules) TeamStats {
+	s := TeamStats{Overall: Row{TeamID: teamID}, Home: Row{TeamID: teamID}, Away: Row{TeamID: teamID}}
+	for _, m := range results {
+		r := ResultSummary{MatchID: m.MatchID, Date: m.StartDate}
+		var side *Row
+		switch teamID {
+		case m.HomeTeamID:
+			r.Home, r.OpponentID, r.GoalsFor, r.GoalsAgainst = true, m.AwayTeamID, m.HomeScore, m.AwayScore
+			side = &s.Home
+		case m.AwayTeamID:
+			r.OpponentID, r.GoalsFor, r.GoalsAgainst = m.HomeTeamID, m.AwayScore, m.HomeScore
+			side = &s.Away
+		default:
+			continue
+		}
+		addResult(&s.Overall, r.GoalsFor, r.GoalsAgainst, rules)
+		addResult(side, r.GoalsFor, r.GoalsAgainst, rules)
+		if m.HomeScoreHT != nil && m.AwayScoreHT != nil {
+			forHT, againstHT := *m.HomeScoreHT, *m.AwayScoreHT
+			if !r.Home {
+				forHT, againstHT = againstHT, forHT
+			}
+			if s.ByHalf == nil {
+				s.ByHalf = &HalfStats{FirstHalf: Row{TeamID: teamID}, SecondHalf: Row{TeamID: teamID}}
+			}
+			s.ByHalf.Matches++
+			addResult(&s.ByHalf.FirstHalf, forHT, againstHT, rules)
+			addResult(&s.ByHalf.SecondHalf, r.GoalsFor-forHT, r.GoalsAgainst-againstHT, rules)
+		}
+		if r.GoalsAgainst == 0 {
+			s.CleanSheets++
+		}
+		if r.GoalsFor == 0 {
+			s.FailedToScore++
+		}
+		margin := r.GoalsFor - r.GoalsAgainst
+		if margin > 0 && (s.BiggestWin == nil || worseOrEqual(s.BiggestWin, margin, r.GoalsFor)) {
+			win := r
+			s.BiggestWin = &win
+		}
+		if margin < 0 && (s.BiggestLoss == nil || worseOrEqual(s.BiggestLoss, -margin, r.GoalsAgainst)) {
+			loss := r
+			s.BiggestLoss = &loss
+		}
+	}
+	if n := s.Overall.MatchesPlayed; n > 0 {
+		s.GoalsForPerGame = math.Round(float64(s.Overall.GoalsFor)/float64(n)*100) / 100
+		s.GoalsAgainstPerGame = math.Round(float64(s.Overall.GoalsAgainst)/float64(n)*100) / 100
+	}
+	return s
+}
+
+// worseOrEqual บอกว่าผลเดิมมีผลต่างประตู (และประตูของฝ่ายชนะ) ไม่มากกว่าผลใหม่
+func worseOrEqual(current *ResultSummary, margin, winnerGoals int) bool {
+	curMargin := current.GoalsFor - current.GoalsAgainst
+	curGoals := current.GoalsFor
+	if curMargin < 0 {
+		curMargin, curGoals = -curMargin, current.GoalsAgainst
+	}
+	return margin > curMargin || (margin == curMargin && winnerGoals >= curGoals)
+}
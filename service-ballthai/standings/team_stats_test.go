@@ -0,0 +1,58 @@
+package standings
+
+import (
+	"testing"
+
+	"go-ballthai-scraper/models"
+)
+
+func withHT(m models.MatchResult, home, away int) models.MatchResult {
+	m.HomeScoreHT, m.AwayScoreHT = &home, &away
+	return m
+}
+
+func TestBuildTeamStatsByHalf(t *testing.T) {
+	rules := models.StandingRules{PointsWin: 3, PointsDraw: 1}
+	tests := []struct {
+		name        string
+		results     []models.MatchResult
+		wantMatches int // 0 = by_half ต้องเป็น nil
+		wantFirst   [3]int
+		wantSecond  [3]int // ชนะ เสมอ แพ้
+	}{
+		{
+			name:    "no half-time scores",
+			results: []models.MatchResult{result(1, 2, 2, 0)},
+		},
+		{
+			name: "home and away sides are oriented to the team",
+			results: []models.MatchResult{
+				withHT(result(1, 2, 2, 1), 0, 1), // ครึ่งแรกแพ้ 0-1 ครึ่งหลังชนะ 2-0
+				withHT(result(3, 1, 1, 1), 1, 0), // ทีม 1 เยือน: ครึ่งแรกแพ้ 0-1 ครึ่งหลังชนะ 1-0
+				result(1, 4, 3, 0),               // ไม่มีสกอร์ครึ่งแรก ไม่นับ
+			},
+			wantMatches: 2,
+			wantFirst:   [3]int{0, 0, 2},
+			wantSecond:  [3]int{2, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := BuildTeamStats(1, tt.results, rules)
+			if tt.wantMatches == 0 {
+				if s.ByHalf != nil {
+					t.Fatalf("ByHalf = %+v, want nil", s.ByHalf)
+				}
+				return
+			}
+			if s.ByHalf == nil || s.ByHalf.Matches != tt.wantMatches {
+				t.Fatalf("ByHalf = %+v, want %d matches", s.ByHalf, tt.wantMatches)
+			}
+			first := [3]int{s.ByHalf.FirstHalf.Wins, s.ByHalf.FirstHalf.Draws, s.ByHalf.FirstHalf.Losses}
+			second := [3]int{s.ByHalf.SecondHalf.Wins, s.ByHalf.SecondHalf.Draws, s.ByHalf.SecondHalf.Losses}
+			if first != tt.wantFirst || second != tt.wantSecond {
+				t.Errorf("first = %v second = %v, want %v %v", first, second, tt.wantFirst, tt.wantSecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package standings
+
+import "go-ballthai-scraper/models"
+
+// Position คือสถานะปัจจุบันของทีมในตารางที่ใช้คำนวณว่าการันตีโซนแล้วหรือหมดโอกาสแล้ว
+type Position struct {
+	TeamID    int
+	Rank      int
+	Points    int
+	Remaining int // จำนวนนัดที่เหลือในขอบเขตเดียวกับตาราง
+}
+
+// ZoneFlags คือโซนที่ทีมการันตีแล้ว (จบในช่วงอันดับของโซนแน่นอน) และโซนที่หมดโอกาสแล้ว
+// สำหรับโซนตกชั้น eliminated หมายถึงรอดตกชั้นแน่นอน
+type ZoneFlags struct {
+	Clinched   []string `json:"clinched"`
+	Eliminated []string `json:"eliminated"`
+}
+
+// SelectZones เลือกโซนที่ใช้กับตารางของ season/stage ที่ระบุ (0 = ไม่ระบุ)
+// แถวที่ระบุ stage ตรงกันมาก่อนแถวที่ใช้ทุก stage และแถวของฤดูกาลมาก่อนค่าเริ่มต้นของลีก
+// คืนเฉพาะชุดที่เจาะจงที่สุด เพื่อไม่ให้ค่าเริ่มต้นปนกับค่าของฤดูกาล
+func SelectZones(zones []models.StandingZone, seasonID, stageID int) []models.StandingZone {
+	best := -1
+	var selected []models.StandingZone
+	for _, z := range zones {
+		score := 0
+		if z.StageID != nil {
+			if *z.StageID != stageID {
+				continue
+			}
+			score += 2
+		}
+		if z.SeasonID != nil {
+			if *z.SeasonID != seasonID {
+				continue
+			}
+			score++
+		}
+		if score > best {
+			best, selected = score, nil
+		}
+		if score == best {
+			selected = append(selected, z)
+		}
+	}
+	return selected
+}
+
+// zoneRange แปลงช่วงอันดับของโซนเป็นอันดับจริงในตารางที่มี size ทีม (ค่าติดลบนับจากท้ายตาราง)
+func zoneRange(z models.StandingZone, size int) (int, int) {
+	from, to := z.PositionFrom, z.PositionTo
+	if from < 0 {
+		from = size + from + 1
+	}
+	if to < 0 {
+		to = size + to + 1
+	}
+	if from > to {
+		from, to = to, from
+	}
+	return from, to
+}
+
+// ZoneFor คืนโซนแรกที่ครอบคลุมอันดับ rank ในตารางที่มี size ทีม (nil = ไม่อยู่ในโซนใด)
+func ZoneFor(zones []models.StandingZone, rank, size int) *models.StandingZone {
+	for i := range zones {
+		if from, to := zoneRange(zones[i], size); rank >= from && rank <= to {
+			return &zones[i]
+		}
+	}
+	return nil
+}
+
+// Flags คำนวณโซนที่แต่ละทีมการันตีแล้วหรือหมดโอกาสแล้ว จากคะแนนและจำนวนนัดที่เหลือ
+// maxPerMatch คือคะแนนสูงสุดที่ได้ต่อนัด (ปกติคือคะแนนชนะ)
+// การคำนวณเป็นแบบระมัดระวัง: ถือว่าคะแนนเท่ากันอาจแพ้ tie-breaker และไม่นับว่าทีมที่ไล่กันต้องเจอกันเอง
+// seasonOver บอกว่าฤดูกาลจบแล้ว (ผู้เรียกรู้จากวันสิ้นสุดฤดูกาล) เพราะ Remaining เป็น 0 ได้ทั้งตอนเตะครบ
+// และตอนที่ยังไม่ประกาศโปรแกรม ใช้อันดับจริง (ซึ่งตัดสิน tie-breaker แล้ว) เฉพาะเมื่อ seasonOver และไม่มีนัดค้าง
+func Flags(table []Position, zones []models.StandingZone, maxPerMatch int, seasonOver bool) map[int]ZoneFlags {
+	finished := seasonOver
+	for _, p := range table {
+		if p.Remaining > 0 {
+			finished = false
+			break
+		}
+	}
+
+	flags := make(map[int]ZoneFlags, len(table))
+	for _, p := range table {
+		best, worst := p.Rank, p.Rank
+		if !finished {
+			best, worst = 1, 1
+			maxP := p.Points + p.Remaining*maxPerMatch
+			for _, o := range table {
+				if o.TeamID == p.TeamID {
+					continue
+				}
+				if o.Points > maxP {
+					best++ // อยู่เหนือเราแน่นอน
+				}
+				if o.Points+o.Remaining*maxPerMatch >= p.Points {
+					worst++ // ยังมีโอกาสแซงหรือเสมอเรา
+				}
+			}
+		}
+		f := ZoneFlags{Clinched: []string{}, Eliminated: []string{}}
+		for _, z := range zones {
+			from, to := zoneRange(z, len(table))
+			switch {
+			case best >= from && worst <= to:
+				f.Clinched = append(f.Clinched, z.Zone)
+			case worst < from || best > to:
+				f.Eliminated = append(f.Eliminated, z.Zone)
+			}
+		}
+		flags[p.TeamID] = f
+	}
+	return flags
+}
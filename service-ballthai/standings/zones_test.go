@@ -0,0 +1,149 @@
+package standings
+
+import (
+	"reflect"
+	"testing"
+
+	"go-ballthai-scraper/models"
+)
+
+func zone(id int, name string, from, to int) models.StandingZone {
+	return models.StandingZone{ID: id, Zone: name, PositionFrom: from, PositionTo: to}
+}
+
+func TestSelectZones(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+	leagueDefault := zone(1, "champion", 1, 1)
+	season := zone(2, "champion", 1, 2)
+	season.SeasonID = ptr(10)
+	stage := zone(3, "promotion", 1, 2)
+	stage.StageID = ptr(7)
+	zones := []models.StandingZone{leagueDefault, season, stage}
+
+	tests := []struct {
+		name            string
+		seasonID, stage int
+		wantIDs         []int
+	}{
+		{"league default when the season has no rows", 11, 0, []int{1}},
+		{"season rows replace the league default", 10, 0, []int{2}},
+		{"stage rows come before season rows", 10, 7, []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []int
+			for _, z := range SelectZones(zones, tt.seasonID, tt.stage) {
+				ids = append(ids, z.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("SelectZones = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestZoneFor(t *testing.T) {
+	zones := []models.StandingZone{zone(1, "champion", 1, 1), zone(2, "relegation", -3, -1)}
+	tests := []struct {
+		rank, size int
+		want       string
+	}{
+		{1, 16, "champion"},
+		{2, 16, ""},
+		{13, 16, ""},
+		{14, 16, "relegation"},
+		{16, 16, "relegation"},
+		{10, 12, "relegation"},
+	}
+	for _, tt := range tests {
+		got := ""
+		if z := ZoneFor(zones, tt.rank, tt.size); z != nil {
+			got = z.Zone
+		}
+		if got != tt.want {
+			t.Errorf("ZoneFor(rank %d of %d) = %q, want %q", tt.rank, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestFlags(t *testing.T) {
+	zones := []models.StandingZone{zone(1, "champion", 1, 1), zone(2, "relegation", -1, -1)}
+	flags := func(clinched []string, eliminated ...string) ZoneFlags {
+		f := ZoneFlags{Clinched: []string{}, Eliminated: []string{}}
+		f.Clinched = append(f.Clinched, clinched...)
+		f.Eliminated = append(f.Eliminated, eliminated...)
+		return f
+	}
+	// ตารางที่คะแนนห่างกันมากพอจะการันตีโซนได้ก่อนจบฤดูกาล
+	decided := []Position{
+		{TeamID: 1, Rank: 1, Points: 30, Remaining: 2},
+		{TeamID: 2, Rank: 2, Points: 20, Remaining: 2},
+		{TeamID: 3, Rank: 3, Points: 18, Remaining: 2},
+		{TeamID: 4, Rank: 4, Points: 5, Remaining: 2},
+	}
+	// คะแนนเท่ากันที่หัวตารางและไม่มีนัดเหลือในโปรแกรม
+	level := []Position{
+		{TeamID: 1, Rank: 1, Points: 10},
+		{TeamID: 2, Rank: 2, Points: 10},
+		{TeamID: 3, Rank: 3, Points: 3},
+		{TeamID: 4, Rank: 4, Points: 0},
+	}
+
+	tests := []struct {
+		name       string
+		table      []Position
+		seasonOver bool
+		want       map[int]ZoneFlags
+	}{
+		{
+			name:  "points gap clinches before the end",
+			table: decided,
+			want: map[int]ZoneFlags{
+				1: flags([]string{"champion"}, "relegation"),
+				2: flags(nil, "champion", "relegation"),
+				3: flags(nil, "champion", "relegation"),
+				4: flags([]string{"relegation"}, "champion"),
+			},
+		},
+		{
+			name:  "no fixtures left is not treated as a finished season",
+			table: level,
+			want: map[int]ZoneFlags{
+				1: flags(nil, "relegation"),
+				2: flags(nil, "relegation"),
+				3: flags(nil, "champion", "relegation"),
+				4: flags([]string{"relegation"}, "champion"),
+			},
+		},
+		{
+			name:       "finished season uses the final ranks",
+			table:      level,
+			seasonOver: true,
+			want: map[int]ZoneFlags{
+				1: flags([]string{"champion"}, "relegation"),
+				2: flags(nil, "champion", "relegation"),
+				3: flags(nil, "champion", "relegation"),
+				4: flags([]string{"relegation"}, "champion"),
+			},
+		},
+		{
+			name:       "fixtures still to play keep an ended season open",
+			table:      decided,
+			seasonOver: true,
+			want: map[int]ZoneFlags{
+				1: flags([]string{"champion"}, "relegation"),
+				2: flags(nil, "champion", "relegation"),
+				3: flags(nil, "champion", "relegation"),
+				4: flags([]string{"relegation"}, "champion"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Flags(tt.table, zones, 3, tt.seasonOver)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flags = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+const CONFLICT_TYPE_LABELS = {
+    rest: 'ทีมพักไม่ครบ',
+    stadium: 'สนามซ้ำวันเดียวกัน',
+    channel: 'ช่องถ่ายทอดชนเวลา',
+    season: 'นอกช่วงฤดูกาล'
+};
+
+function escapeHtml(s) {
+    return String(s == null ? '' : s).replace(/[&<>"']/g, c => ({ '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;' }[c]));
+}
+
+async function fetchLeagues() {
+    const select = document.getElementById('league_select');
+    select.innerHTML = '<option value="">ทุกลีก</option>';
+    try {
+        const res = await fetch('/api/leagues');
+        const data = await res.json();
+        if (data && data.success && Array.isArray(data.data)) {
+            data.data.forEach(l => {
+                select.innerHTML += `<option value="${l.id}">${escapeHtml(l.name)}</option>`;
+            });
+        }
+    } catch (e) {
+        console.error('API /api/leagues error:', e);
+    }
+}
+
+function fixtureText(date, time, home, away) {
+    return `${escapeHtml(date || '-')} ${escapeHtml(time || '')} ${escapeHtml(home || '?')} - ${escapeHtml(away || '?')}`;
+}
+
+async function fetchConflicts(refresh) {
+    const container = document.getElementById('conflictContainer');
+    const params = new URLSearchParams();
+    const leagueId = document.getElementById('league_select').value;
+    const type = document.getElementById('type_select').value;
+    const from = document.getElementById('from_input').value;
+    if (leagueId) params.set('league_id', leagueId);
+    if (type) params.set('type', type);
+    if (from) params.set('from', from);
+    if (refresh) params.set('refresh', '1');
+    container.innerHTML = '<p>กำลังโหลด...</p>';
+    try {
+        const res = await fetch('/api/matches/conflicts?' + params.toString());
+        const data = await res.json();
+        if (!data.success) throw new Error(data.error || 'load failed');
+        const rows = data.data || [];
+        const counts = {};
+        rows.forEach(c => { counts[c.type] = (counts[c.type] || 0) + 1; });
+        document.getElementById('conflictSummary').innerHTML = rows.length === 0
+            ? '✅ ไม่พบโปรแกรมชนกัน'
+            : `พบ <b>${rows.length}</b> รายการ — ` + Object.keys(CONFLICT_TYPE_LABELS)
+                .filter(t => counts[t]).map(t => `${CONFLICT_TYPE_LABELS[t]} ${counts[t]}`).join(' | ');
+        if (rows.length === 0) {
+            container.innerHTML = '';
+            return;
+        }
+        let html = '<table class="standings-table" style="width:100%"><thead><tr><th>ประเภท</th><th>ลีก</th><th>แมตช์</th><th>ชนกับ</th><th>ทีม/สนาม/ช่อง</th><th>รายละเอียด</th><th>พบเมื่อ</th></tr></thead><tbody>';
+        rows.forEach(c => {
+            const other = c.other_match_id
+                ? `#${c.other_match_id} ${fixtureText(c.other_start_date, c.other_start_time, c.other_home_team, c.other_away_team)}`
+                : '-';
+            html += `<tr><td>${CONFLICT_TYPE_LABELS[c.type] || escapeHtml(c.type)}</td>` +
+                `<td style="text-align:left">${escapeHtml(c.league_name || '-')}</td>` +
+                `<td style="text-align:left">#${c.match_id} ${fixtureText(c.start_date, c.start_time, c.home_team, c.away_team)}</td>` +
+                `<td style="text-align:left">${other}</td>` +
+                `<td>${escapeHtml(c.subject_name || '-')}</td><td>${escapeHtml(c.detail)}</td><td>${escapeHtml(c.detected_at || '')}</td></tr>`;
+        });
+        html += '</tbody></table>';
+        container.innerHTML = html;
+    } catch (e) {
+        container.innerHTML = '<p>โหลดข้อมูลไม่สำเร็จ: ' + e.message + '</p>';
+    }
+}
+
+document.addEventListener('DOMContentLoaded', async () => {
+    await fetchLeagues();
+    fetchConflicts();
+});
@@ -85,7 +85,8 @@ function initializeEventListeners() {
 async function loadLeagues() {
     showLoading(true);
     try {
-        const response = await fetch(`${API_BASE_URL}/api/leagues`);
+        // หน้าจัดการแก้ไขชื่อภาษาไทยเสมอ ไม่ขึ้นกับภาษาของเบราว์เซอร์
+        const response = await fetch(`${API_BASE_URL}/api/leagues?lang=th&${leagueFilterQuery()}`);
         const data = await response.json();
         
         if (data.success) {
@@ -108,8 +109,8 @@ async function searchLeagues() {
     
     try {
         const url = searchTerm 
-            ? `${API_BASE_URL}/api/leagues/search?q=${encodeURIComponent(searchTerm)}`
-            : `${API_BASE_URL}/api/leagues`;
+            ? `${API_BASE_URL}/api/leagues/search?lang=th&q=${encodeURIComponent(searchTerm)}&${leagueFilterQuery()}`
+            : `${API_BASE_URL}/api/leagues?lang=th&${leagueFilterQuery()}`;
             
         const response = await fetch(url);
         const data = await response.json();
@@ -128,6 +129,19 @@ async function searchLeagues() {
     }
 }
 
+// leagueFilterQuery สร้าง query string จากตัวกรองประเภท/เพศ/ประเทศ/รุ่นอายุ
+function leagueFilterQuery() {
+    const params = new URLSearchParams();
+    const filters = { type: 'filterType', gender: 'filterGender', country: 'filterCountry', age_group: 'filterAgeGroup' };
+    for (const [key, id] of Object.entries(filters)) {
+        const value = document.getElementById(id).value.trim();
+        if (value) params.set(key, value);
+    }
+    return params.toString();
+}
+
+const competitionTypeLabels = { league: 'ลีก', cup: 'ถ้วย', super_cup: 'ซูเปอร์คัพ' };
+
 function renderLeagues(leaguesList) {
     const container = document.getElementById('leaguesGrid');
     if (!leaguesList || leaguesList.length === 0) {
@@ -142,9 +156,11 @@ function renderLeagues(leaguesList) {
     }
     container.innerHTML = leaguesList.map(league => `
         <div class="league-card">
-            <div class="league-info">
-                <h3>${escapeHtml(league.name)}</h3>
-                <p>ID: ${league.id}${league.thaileageid ? ` | ThaiLeagueID: ${league.thaileageid}` : ''}</p>
+            <div class="league-info" ${league.primary_color ? `style="border-left: 4px solid ${league.primary_color}; padding-left: 8px;"` : ''}>
+                <h3>${league.logo_url ? `<img src="${escapeHtml(league.logo_url)}" alt="" style="height: 24px; vertical-align: middle;"> ` : ''}${escapeHtml(league.name)}</h3>
+                <p>${competitionTypeLabels[league.competition_type] || league.competition_type}${league.tier ? ` | ระดับ ${league.tier}` : ''}${league.country ? ` | ${escapeHtml(league.country)}` : ''} | ${league.gender === 'women' ? 'หญิง' : 'ชาย'}${league.age_group ? ` ${escapeHtml(league.age_group)}` : ''}${league.sponsor_name ? ` | สปอนเซอร์: ${escapeHtml(league.sponsor_name)}` : ''} | ลำดับ ${league.sort_order}</p>
+                <p>ID: ${league.id}${league.slug ? ` | slug: ${escapeHtml(league.slug)}` : ''}${league.thaileageid ? ` | ThaiLeagueID: ${league.thaileageid}` : ''}</p>
+                ${league.aliases && league.aliases.length ? `<p>alias: ${escapeHtml(league.aliases.join(', '))}</p>` : ''}
             </div>
             <div class="league-actions">
                 <button onclick="editLeague(${league.id})" class="btn btn-edit">แก้ไข</button>
@@ -173,7 +189,21 @@ function editLeague(id) {
     currentLeague = league;
     document.getElementById('modalTitle').textContent = 'แก้ไขลีก';
     document.getElementById('leagueName').value = league.name;
+    document.getElementById('leagueNameEn').value = league.name_en || '';
+    document.getElementById('leagueSlug').value = league.slug || '';
+    document.getElementById('leagueAliases').value = (league.aliases || []).join(', ');
     document.getElementById('thaileageid').value = league.thaileageid || '';
+    document.getElementById('competitionType').value = league.competition_type || 'league';
+    document.getElementById('leagueTier').value = league.tier || '';
+    document.getElementById('leagueCountry').value = league.country || '';
+    document.getElementById('leagueGender').value = league.gender || 'men';
+    document.getElementById('leagueAgeGroup').value = league.age_group || '';
+    document.getElementById('leagueSponsor').value = league.sponsor_name || '';
+    document.getElementById('leaguePrimaryColor').value = league.primary_color || '';
+    document.getElementById('leagueSecondaryColor').value = league.secondary_color || '';
+    document.getElementById('leagueSortOrder').value = league.sort_order || 0;
+    document.getElementById('leagueMinRest').value = league.min_rest_hours || '';
+    document.getElementById('leagueLogo').value = '';
     document.getElementById('leagueModal').style.display = 'block';
     document.getElementById('leagueName').focus();
 }
@@ -189,8 +219,22 @@ async function saveLeague(event) {
     const formData = new FormData(event.target);
     const leagueData = {
         name: formData.get('name').trim(),
-        thaileageid: formData.get('thaileageid') ? parseInt(formData.get('thaileageid')) : null
+        name_en: formData.get('name_en').trim(),
+        slug: formData.get('slug').trim(),
+        aliases: formData.get('aliases').split(',').map(a => a.trim()).filter(a => a),
+        thaileageid: formData.get('thaileageid') ? parseInt(formData.get('thaileageid')) : null,
+        competition_type: formData.get('competition_type'),
+        tier: formData.get('tier') ? parseInt(formData.get('tier')) : null,
+        country: formData.get('country').trim(),
+        gender: formData.get('gender'),
+        age_group: formData.get('age_group').trim(),
+        sponsor_name: formData.get('sponsor_name').trim(),
+        primary_color: formData.get('primary_color').trim(),
+        secondary_color: formData.get('secondary_color').trim(),
+        sort_order: parseInt(formData.get('sort_order')) || 0,
+        min_rest_hours: formData.get('min_rest_hours') ? parseInt(formData.get('min_rest_hours')) : null
     };
+    const logoFile = document.getElementById('leagueLogo').files[0];
 
     // Validation
     if (!leagueData.name) {
@@ -218,9 +262,14 @@ async function saveLeague(event) {
         const data = await response.json();
         
         if (data.success) {
+            if (logoFile) {
+                await uploadLeagueLogo(currentLeague ? currentLeague.id : data.data.id, logoFile);
+            }
             showAlert(currentLeague ? 'แก้ไขลีกสำเร็จ' : 'เพิ่มลีกสำเร็จ', 'success');
             closeModal();
             loadLeagues(); // Reload the list
+        } else if (data.errors) {
+            showAlert(data.errors.map(e => `${e.field}: ${e.message}`).join(', '), 'error');
         } else {
             showAlert(data.error || 'เกิดข้อผิดพลาด', 'error');
         }
@@ -232,6 +281,15 @@ async function saveLeague(event) {
     }
 }
 
+async function uploadLeagueLogo(id, file) {
+    const form = new FormData();
+    form.append('logo', file);
+    const response = await fetch(`${API_BASE_URL}/api/leagues/${id}/logo`, { method: 'POST', body: form });
+    if (!response.ok) {
+        showAlert('อัปโหลดโลโก้ไม่สำเร็จ: ' + await response.text(), 'error');
+    }
+}
+
 function deleteLeague(id) {
     const league = leagues.find(l => l.id === id);
     if (!league) {
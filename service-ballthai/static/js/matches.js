@@ -3,9 +3,17 @@ function fetchMatches(dateParam) {
     if (!date) {
         date = document.getElementById('date').value;
     }
-    let url = '/api/matches';
+    const params = new URLSearchParams();
     if (date) {
-        url += `?date=${date}`;
+        params.set('date', date);
+    }
+    const statusFilter = document.getElementById('status_filter');
+    if (statusFilter && statusFilter.value) {
+        params.set('status', statusFilter.value);
+    }
+    let url = '/api/matches';
+    if (params.toString()) {
+        url += `?${params.toString()}`;
     }
     fetch(url)
         .then(res => res.json())
@@ -61,29 +69,16 @@ function fetchMatches(dateParam) {
                         
                         // แสดงสถานะการแข่งขันอย่างเหมาะสม
                         let statusDisplay = '';
-                        if (match.match_status) {
-                            switch (match.match_status) {
-                                case 'SLIP':
-                                    statusDisplay = ' <span style="color: #ff6b35; font-weight: bold;">[SLIP]</span>';
-                                    break;
-                                case 'FINISHED':
-                                    statusDisplay = ' <span style="color: #4caf50; font-weight: bold;">[จบ]</span>';
-                                    break;
-                                case 'OFF':
-                                    statusDisplay = ' <span style="color: #9e9e9e; font-weight: bold;">[ปิด]</span>';
-                                    break;
-                                case 'ADD':
-                                default:
-                                    statusDisplay = ' <span style="color: #2196f3; font-weight: bold;">[ใหม่]</span>';
-                                    break;
-                            }
+                        const status = MATCH_STATUS_LABELS[match.match_status];
+                        if (status) {
+                            statusDisplay = ` <span style="color: ${status.color}; font-weight: bold;">[${status.label}]</span>`;
                         }
                         
                         tbody.innerHTML += `
                             <tr>
                                 <td>${timeStr}${statusDisplay}</td>
                                 <td class="home-team">${match.home_team || ''}</td>
-                                <td class="score-center">${match.home_score ?? ''} - ${match.away_score ?? ''}</td>
+                                <td class="score-center">${match.home_score ?? ''} - ${match.away_score ?? ''}${scoreSuffix(match)}</td>
                                 <td>${match.away_team || ''}</td>
                                 <td class="actions">
                                     <button class="btn" onclick="editMatch(${match.id})">แก้ไข</button>
@@ -132,7 +127,7 @@ function addMatch() {
             if (stagesData.success && Array.isArray(stagesData.data) && stagesData.data.length > 0) {
                 stagesData.data.forEach(stage => {
                     if (stage.stage_name && stage.id) {
-                        stageSelect.innerHTML += `<option value="${stage.id}">${stage.stage_name}</option>`;
+                        stageSelect.innerHTML += `<option value="${stage.id}">${stageLabel(stage)}</option>`;
                     }
                 });
             } else {
@@ -211,7 +206,16 @@ document.addEventListener('DOMContentLoaded', function() {
                 away_score: formData.get('away_score') ? Number(formData.get('away_score')) : null,
                 match_status: formData.get('match_status'),
                 channel_id: formData.get('channel_id') ? Number(formData.get('channel_id')) : null,
-                live_channel_id: formData.get('live_channel_id') ? Number(formData.get('live_channel_id')) : null
+                live_channel_id: formData.get('live_channel_id') ? Number(formData.get('live_channel_id')) : null,
+                scrape_locked: formData.get('scrape_locked') === 'on',
+                force_status: formData.get('force_status') === 'on',
+                home_score_ht: optionalScore(formData, 'home_score_ht'),
+                away_score_ht: optionalScore(formData, 'away_score_ht'),
+                home_score_et: optionalScore(formData, 'home_score_et'),
+                away_score_et: optionalScore(formData, 'away_score_et'),
+                home_penalties: optionalScore(formData, 'home_penalties'),
+                away_penalties: optionalScore(formData, 'away_penalties'),
+                decided_by: formData.get('decided_by') || ''
             };
             // Only include stage_id when it's a real number. Setting it to undefined will omit it from JSON.
             if (_stageId !== undefined) {
@@ -242,8 +246,11 @@ document.addEventListener('DOMContentLoaded', function() {
                 if (result.success) {
                     closeAddMatchModal();
                     fetchMatches();
+                } else if (Array.isArray(result.errors)) {
+                    // แสดงข้อผิดพลาดรายฟิลด์จาก server
+                    alert('บันทึกไม่สำเร็จ:\n' + result.errors.map(e => `- ${e.field}: ${e.message}`).join('\n'));
                 } else {
-                    alert('เกิดข้อผิดพลาดในการบันทึกแมทช์');
+                    alert('เกิดข้อผิดพลาดในการบันทึกแมทช์' + (result.error ? `: ${result.error}` : ''));
                 }
             })
             .catch(() => alert('เกิดข้อผิดพลาดในการเชื่อมต่อเซิร์ฟเวอร์'));
@@ -291,7 +298,7 @@ function editMatch(id) {
                 if (stagesData.success && Array.isArray(stagesData.data) && stagesData.data.length > 0) {
                     stagesData.data.forEach(stage => {
                         if (stage.stage_name && stage.id) {
-                            stageSelect.innerHTML += `<option value="${stage.id}">${stage.stage_name}</option>`;
+                            stageSelect.innerHTML += `<option value="${stage.id}">${stageLabel(stage)}</option>`;
                         }
                     });
                 }
@@ -351,21 +358,13 @@ function editMatch(id) {
                 stadiumSelect.value = match.stadium_id != null ? String(match.stadium_id) : '';
                 document.getElementById('home_score').value = match.home_score ?? 0;
                 document.getElementById('away_score').value = match.away_score ?? 0;
-                // Ensure match_status options include 'ADD' and 'SLIP' and set value
-                const matchStatusSelect = document.getElementById('match_status_select');
-                if (!Array.from(matchStatusSelect.options).some(o => o.value === 'ADD')) {
-                    const opt = document.createElement('option');
-                    opt.value = 'ADD';
-                    opt.textContent = 'ADD - (ไม่ระบุ)';
-                    matchStatusSelect.insertBefore(opt, matchStatusSelect.firstChild);
-                }
-                if (!Array.from(matchStatusSelect.options).some(o => o.value === 'SLIP')) {
-                    const opt = document.createElement('option');
-                    opt.value = 'SLIP';
-                    opt.textContent = 'SLIP - สำหรับการเดิมพัน';
-                    matchStatusSelect.insertBefore(opt, matchStatusSelect.firstChild.nextSibling);
-                }
-                matchStatusSelect.value = match.match_status || 'ADD';
+                ['home_score_ht', 'away_score_ht', 'home_score_et', 'away_score_et', 'home_penalties', 'away_penalties'].forEach(field => {
+                    document.getElementById(field).value = match[field] ?? '';
+                });
+                document.getElementById('decided_by_select').value = match.decided_by || '';
+                document.getElementById('match_status_select').value = match.match_status || 'scheduled';
+                document.getElementById('scrape_locked').checked = !!match.scrape_locked;
+                document.getElementById('force_status').checked = false;
                 // เพิ่ม hidden input สำหรับ id
                 let idInput = document.getElementById('match_id');
                 if (!idInput) {
@@ -419,3 +418,39 @@ window.onload = function() {
     dateInput.value = todayStr;
     fetchMatches(todayStr);
 };
+
+// ป้ายสถานะการแข่งขันในตาราง
+const MATCH_STATUS_LABELS = {
+    scheduled: { label: 'ยังไม่แข่ง', color: '#2196f3' },
+    live: { label: 'กำลังแข่ง', color: '#e53935' },
+    half_time: { label: 'พักครึ่ง', color: '#e53935' },
+    finished: { label: 'จบ', color: '#4caf50' },
+    postponed: { label: 'เลื่อน', color: '#ff6b35' },
+    cancelled: { label: 'ยกเลิก', color: '#9e9e9e' },
+    abandoned: { label: 'ยุติ', color: '#9e9e9e' },
+    suspended: { label: 'พัก', color: '#ff9800' },
+};
+
+// ช่องสกอร์ที่เว้นว่างได้ (ว่าง = null)
+function optionalScore(formData, name) {
+    const v = formData.get(name);
+    return v === null || v === '' ? null : Number(v);
+}
+
+// ข้อความต่อท้ายสกอร์ เช่น " (ต่อเวลา 2-1)" หรือ " (จุดโทษ 4-3)"
+function scoreSuffix(match) {
+    let s = '';
+    if (match.home_score_et != null && match.away_score_et != null) {
+        s += ` (ต่อเวลา ${match.home_score_et}-${match.away_score_et})`;
+    }
+    if (match.home_penalties != null && match.away_penalties != null) {
+        s += ` (จุดโทษ ${match.home_penalties}-${match.away_penalties})`;
+    }
+    return s;
+}
+
+// ชื่อ stage พร้อมลีก/ฤดูกาล เพราะชื่อเดียวกันมีได้หลายลีกและหลายฤดูกาล
+function stageLabel(stage) {
+    const scope = [stage.league_name, stage.season_name].filter(Boolean).join(' ');
+    return scope ? `${stage.stage_name} (${scope})` : stage.stage_name;
+}
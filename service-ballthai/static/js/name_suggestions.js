@@ -0,0 +1,73 @@
+const entityLabels = { team: 'ทีม', player: 'ผู้เล่น', stadium: 'สนาม' };
+
+function escapeHTML(s) {
+    return String(s).replace(/[&<>"']/g, c => ({ '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;' }[c]));
+}
+
+async function fetchSuggestions() {
+    const container = document.getElementById('suggestionsContainer');
+    const entity = document.getElementById('entityFilter').value;
+    const status = document.getElementById('statusFilter').value;
+    try {
+        const res = await fetch(`/api/name-suggestions?entity=${entity}&status=${status}`);
+        const data = await res.json();
+        if (!data.success) throw new Error(data.error || 'load failed');
+        const items = data.data || [];
+        if (items.length === 0) {
+            container.innerHTML = '<p>ไม่มีคำแนะนำ</p>';
+            return;
+        }
+        const rows = items.map(s => `
+            <tr data-id="${s.id}">
+                <td>${entityLabels[s.entity_type] || s.entity_type}</td>
+                <td>${s.entity_id}</td>
+                <td style="text-align:left">${escapeHTML(s.source_name)}</td>
+                <td><input type="text" class="name-en" value="${escapeHTML(s.suggested_name)}" ${s.status !== 'pending' ? 'disabled' : ''}></td>
+                <td><input type="text" class="slug" value="${escapeHTML(s.suggested_slug)}" ${s.status !== 'pending' ? 'disabled' : ''}></td>
+                <td>${s.status === 'pending' ? `
+                    <button class="btn btn-success" onclick="reviewSuggestion(${s.id}, 'accept')">ใช้ชื่อนี้</button>
+                    <button class="btn btn-secondary" onclick="reviewSuggestion(${s.id}, 'reject')">ปฏิเสธ</button>` : s.status}</td>
+            </tr>`).join('');
+        container.innerHTML = `
+            <table class="standings-table" style="width:100%">
+                <thead><tr><th>ประเภท</th><th>ID</th><th>ชื่อไทย</th><th>ชื่ออังกฤษ</th><th>slug</th><th></th></tr></thead>
+                <tbody>${rows}</tbody>
+            </table>`;
+    } catch (e) {
+        container.innerHTML = '<p>โหลดข้อมูลไม่สำเร็จ: ' + e.message + '</p>';
+    }
+}
+
+async function generateSuggestions() {
+    const entity = document.getElementById('entityFilter').value;
+    const res = await fetch(`/api/name-suggestions/generate?entity=${entity}`, { method: 'POST' });
+    if (!res.ok) {
+        alert('สร้างคำแนะนำไม่สำเร็จ: ' + await res.text());
+        return;
+    }
+    fetchSuggestions();
+}
+
+async function reviewSuggestion(id, action) {
+    const row = document.querySelector(`tr[data-id="${id}"]`);
+    const res = await fetch(`/api/name-suggestions/${id}`, {
+        method: 'PUT',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({
+            action: action,
+            name_en: row.querySelector('.name-en').value.trim(),
+            slug: row.querySelector('.slug').value.trim()
+        })
+    });
+    if (!res.ok) {
+        alert('บันทึกไม่สำเร็จ: ' + await res.text());
+        return;
+    }
+    fetchSuggestions();
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    document.getElementById('entityFilter').addEventListener('change', fetchSuggestions);
+    document.getElementById('statusFilter').addEventListener('change', fetchSuggestions);
+    fetchSuggestions();
+});
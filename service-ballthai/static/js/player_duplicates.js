@@ -0,0 +1,74 @@
+async function fetchDuplicates() {
+    const container = document.getElementById('duplicatesContainer');
+    try {
+        const res = await fetch('/api/players/duplicates');
+        const data = await res.json();
+        if (!data.success) throw new Error(data.error || 'load failed');
+        const groups = data.data || [];
+        if (groups.length === 0) {
+            container.innerHTML = '<p>ไม่พบผู้เล่นที่น่าจะซ้ำกัน</p>';
+            return;
+        }
+        container.innerHTML = groups.map((g, gi) => {
+            const rows = g.players.map((p, pi) => `
+                <tr>
+                    <td><input type="radio" name="keep-${gi}" value="${p.player_id}" ${pi === 0 ? 'checked' : ''}></td>
+                    <td>${p.player_id}</td>
+                    <td style="text-align:left">${p.name}<br><small>${p.full_name_en || ''}</small></td>
+                    <td>${p.birth_date || '-'}</td>
+                    <td>${p.nationality || '-'}</td>
+                    <td>${p.team_name || '-'}</td>
+                    <td>${p.league_id || '-'}</td>
+                    <td>${p.matches_played} / ${p.goals}</td>
+                </tr>`).join('');
+            return `
+                <div style="margin-bottom:1.5rem;" data-group="${gi}" data-ids="${g.players.map(p => p.player_id).join(',')}">
+                    <div>ตรงกัน: ${g.reasons.join(', ')}</div>
+                    <table class="standings-table" style="width:100%">
+                        <thead><tr><th>หลัก</th><th>ID</th><th>ชื่อ</th><th>วันเกิด</th><th>สัญชาติ</th><th>ทีม</th><th>ลีก</th><th>นัด / ประตู</th></tr></thead>
+                        <tbody>${rows}</tbody>
+                    </table>
+                    <button class="btn btn-success" onclick="mergeGroup(${gi})">รวม</button>
+                    <button class="btn btn-secondary" onclick="ignoreGroup(${gi})">ไม่ใช่คนเดียวกัน</button>
+                </div>`;
+        }).join('');
+    } catch (e) {
+        container.innerHTML = '<p>โหลดข้อมูลไม่สำเร็จ: ' + e.message + '</p>';
+    }
+}
+
+function groupIds(gi) {
+    const el = document.querySelector(`[data-group="${gi}"]`);
+    return el.getAttribute('data-ids').split(',').map(Number);
+}
+
+async function mergeGroup(gi) {
+    const keep = Number(document.querySelector(`input[name="keep-${gi}"]:checked`).value);
+    const mergeIds = groupIds(gi).filter(id => id !== keep);
+    if (!confirm(`รวมผู้เล่น ${mergeIds.join(', ')} เข้ากับ ${keep} ใช่หรือไม่?`)) return;
+    const res = await fetch('/api/players/merge', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ keep_id: keep, merge_ids: mergeIds })
+    });
+    if (!res.ok) {
+        alert('รวมไม่สำเร็จ: ' + await res.text());
+        return;
+    }
+    fetchDuplicates();
+}
+
+async function ignoreGroup(gi) {
+    const res = await fetch('/api/players/duplicates/ignore', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ player_ids: groupIds(gi) })
+    });
+    if (!res.ok) {
+        alert('บันทึกไม่สำเร็จ: ' + await res.text());
+        return;
+    }
+    fetchDuplicates();
+}
+
+document.addEventListener('DOMContentLoaded', fetchDuplicates);
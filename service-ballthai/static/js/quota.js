@@ -0,0 +1,86 @@
+const QUOTA_GROUP_LABELS = { thai: 'ไทย', asean: 'อาเซียน', afc: 'AFC', other: 'อื่นๆ', unknown: 'ไม่ทราบ' };
+
+async function fetchLeagues() {
+    const select = document.getElementById('league_select');
+    select.innerHTML = '<option value="">-- เลือกลีก --</option>';
+    try {
+        const res = await fetch('/api/leagues');
+        const data = await res.json();
+        if (data && data.success && Array.isArray(data.data)) {
+            data.data.forEach(l => {
+                select.innerHTML += `<option value="${l.id}">${l.name}</option>`;
+            });
+        }
+    } catch (e) {
+        console.error('API /api/leagues error:', e);
+    }
+}
+
+function quotaParams(extra) {
+    const params = new URLSearchParams();
+    params.set('league_id', document.getElementById('league_select').value);
+    const season = document.getElementById('season_input').value.trim();
+    if (season) params.set('season', season);
+    Object.entries(extra || {}).forEach(([k, v]) => params.set(k, v));
+    return params.toString();
+}
+
+function limitText(v) {
+    return v === null || v === undefined ? 'ไม่จำกัด' : v;
+}
+
+async function fetchQuota() {
+    const leagueId = document.getElementById('league_select').value;
+    const container = document.getElementById('quotaContainer');
+    document.getElementById('quotaTeamPlayers').innerHTML = '';
+    if (!leagueId) {
+        container.innerHTML = '<p>กรุณาเลือกลีก</p>';
+        return;
+    }
+    container.innerHTML = '<p>กำลังโหลด...</p>';
+    try {
+        const res = await fetch('/api/quota?' + quotaParams());
+        const data = await res.json();
+        if (!data.success) throw new Error(data.error || 'load failed');
+        const rules = data.data.rules;
+        document.getElementById('quotaRules').innerHTML =
+            `โควตา: อาเซียน ${limitText(rules.max_asean)} | AFC ${limitText(rules.max_afc)} | อื่นๆ ${limitText(rules.max_other)} | ต่างชาติรวม ${limitText(rules.max_foreign_total)}` +
+            ` — ทีมที่เกินโควตา <b>${data.data.non_compliant}</b> ทีม`;
+        let html = '<table class="standings-table" style="width:100%"><thead><tr><th>ทีม</th><th>ไทย</th><th>อาเซียน</th><th>AFC</th><th>อื่นๆ</th><th>ไม่ทราบ</th><th>ต่างชาติรวม</th><th>ต่างชาติที่ลงสนาม</th><th>สถานะ</th></tr></thead><tbody>';
+        (data.data.teams || []).forEach(t => {
+            const status = t.compliant ? '✅ ผ่าน' : '❌ ' + t.violations.join(', ');
+            html += `<tr style="cursor:pointer" onclick="fetchTeamQuota(${t.team_id})">` +
+                `<td style="text-align:left">${t.team_name}</td><td>${t.counts.thai}</td><td>${t.counts.asean}</td>` +
+                `<td>${t.counts.afc}</td><td>${t.counts.other}</td><td>${t.counts.unknown}</td><td>${t.foreign_total}</td>` +
+                `<td>${t.fielded_foreign}</td><td>${status}</td></tr>`;
+        });
+        html += '</tbody></table>';
+        container.innerHTML = html;
+    } catch (e) {
+        container.innerHTML = '<p>โหลดข้อมูลไม่สำเร็จ: ' + e.message + '</p>';
+    }
+}
+
+async function fetchTeamQuota(teamId) {
+    const box = document.getElementById('quotaTeamPlayers');
+    box.innerHTML = '<p>กำลังโหลด...</p>';
+    try {
+        const res = await fetch('/api/quota?' + quotaParams({ team_id: teamId }));
+        const data = await res.json();
+        const team = (data.data.teams || [])[0];
+        if (!team) {
+            box.innerHTML = '<p>ไม่พบผู้เล่น</p>';
+            return;
+        }
+        let html = `<h3>${team.team_name}</h3><table class="standings-table" style="width:100%"><thead><tr><th>ผู้เล่น</th><th>สัญชาติ</th><th>กลุ่ม</th><th>ลงสนาม (นัด)</th></tr></thead><tbody>`;
+        (team.players || []).forEach(p => {
+            html += `<tr><td style="text-align:left">${p.name}</td><td>${p.nationality || '-'}</td><td>${QUOTA_GROUP_LABELS[p.group] || p.group}</td><td>${p.matches_played}</td></tr>`;
+        });
+        html += '</tbody></table>';
+        box.innerHTML = html;
+    } catch (e) {
+        box.innerHTML = '<p>โหลดข้อมูลไม่สำเร็จ: ' + e.message + '</p>';
+    }
+}
+
+document.addEventListener('DOMContentLoaded', fetchLeagues);
@@ -121,7 +121,7 @@ async function renderStandingsTableWithStage(standings) {
         }
         const stText = (st === 1) ? 'OFF - ปิดการดึง' : 'ON - เปิดการดึง';
         html += `<tr data-id="${s.id}" data-rank="${s.current_rank?.Int64||i+1}">
-            <td>${s.current_rank?.Int64||i+1}</td>
+            <td style="${s.zone_color ? `border-left:6px solid ${s.zone_color}` : ''}" title="${s.zone_label || ''}">${s.current_rank?.Int64||i+1}${zoneFlag(s)}</td>
             <td>${(s.team_name && typeof s.team_name === 'string') ? s.team_name : '-'}</td>
             <td>${s.matches_played}</td>
             <td>${s.wins}</td>
@@ -130,7 +130,7 @@ async function renderStandingsTableWithStage(standings) {
             <td>${s.goals_for}</td>
             <td>${s.goals_against}</td>
             <td>${s.goal_difference}</td>
-            <td>${s.points}</td>
+            <td>${s.points}${s.points_adjustment ? ` <small title="คะแนนก่อนปรับ ${s.raw_points}">(${s.points_adjustment > 0 ? '+' : ''}${s.points_adjustment})</small>` : ''}</td>
             <td>
                 <button class="move-btn" onclick="moveRow(this, -1)" ${i===0?'disabled':''}>⬆️</button>
                 <button class="move-btn" onclick="moveRow(this, 1)" ${i===filtered.length-1?'disabled':''}>⬇️</button>
@@ -315,6 +315,7 @@ async function onLeagueChange() {
     if (!leagueId) {
         document.getElementById('standingsContainer').innerHTML = '';
         document.getElementById('stageZoneContainer').innerHTML = '';
+        document.getElementById('sanctionsContainer').innerHTML = '';
         return;
     }
     // fetch standings
@@ -331,6 +332,67 @@ async function onLeagueChange() {
     // reset stage dropdown state ทุกครั้งที่เปลี่ยนลีก
     renderStandingsTableWithStage._selectedStageName = null;
     renderStandingsTableWithStage(standings);
+    loadSanctions(leagueId, standings);
+}
+
+// รายการหัก/เพิ่มคะแนน (sanctions) ของลีก ใช้แทนการแก้ points ในตารางโดยตรง
+async function loadSanctions(leagueId, standings) {
+    const box = document.getElementById('sanctionsContainer');
+    if (!box) return;
+    let sanctions = [];
+    try {
+        const res = await fetch('/api/sanctions?league_id=' + leagueId);
+        const data = await res.json();
+        if (data && data.success && Array.isArray(data.data)) sanctions = data.data;
+    } catch (e) {
+        console.error('API /api/sanctions error:', e);
+    }
+    const teams = {};
+    (standings || []).forEach(s => { teams[s.team_id] = s.team_name || ('#' + s.team_id); });
+    let html = '<h3>หัก/เพิ่มคะแนน</h3><table class="standings-table" border="1" cellpadding="4" style="width:100%"><thead><tr><th>วันที่</th><th>ทีม</th><th>คะแนน</th><th>เหตุผล</th><th></th></tr></thead><tbody>';
+    sanctions.forEach(s => {
+        html += `<tr><td>${s.sanction_date}</td><td>${s.team_name || teams[s.team_id] || s.team_id}</td><td>${s.points > 0 ? '+' : ''}${s.points}</td><td>${s.reason}</td>
+            <td><button onclick="deleteSanction(${s.id})">🗑️</button></td></tr>`;
+    });
+    if (sanctions.length === 0) html += '<tr><td colspan="5">ไม่มีรายการ</td></tr>';
+    html += '</tbody></table>';
+    html += `<form id="sanctionForm" style="margin:1rem 0;display:flex;gap:8px;flex-wrap:wrap;align-items:center">
+        <select name="team_id" required>${Object.keys(teams).map(id => `<option value="${id}">${teams[id]}</option>`).join('')}</select>
+        <input name="points" type="number" required placeholder="คะแนน (ติดลบ = หัก)" style="width:150px">
+        <input name="reason" required placeholder="เหตุผล" style="flex:1">
+        <input name="sanction_date" type="date" required value="${new Date().toISOString().slice(0, 10)}">
+        <button type="submit" class="btn-primary">➕ เพิ่ม</button>
+    </form>`;
+    box.innerHTML = html;
+    document.getElementById('sanctionForm').onsubmit = async (e) => {
+        e.preventDefault();
+        const f = e.target;
+        const body = {
+            league_id: parseInt(leagueId, 10),
+            team_id: parseInt(f.team_id.value, 10),
+            points: parseInt(f.points.value, 10),
+            reason: f.reason.value,
+            sanction_date: f.sanction_date.value
+        };
+        const res = await fetch('/api/sanctions', { method: 'POST', headers: { 'Content-Type': 'application/json' }, body: JSON.stringify(body) });
+        const data = await res.json().catch(() => null);
+        if (!res.ok || !data || !data.success) {
+            const errs = data && Array.isArray(data.errors) ? data.errors.map(x => x.field + ': ' + x.message).join('\n') : (data && data.error);
+            alert('บันทึกไม่สำเร็จ: ' + (errs || res.status));
+            return;
+        }
+        onLeagueChange();
+    };
+}
+
+async function deleteSanction(id) {
+    if (!confirm('ลบรายการนี้?')) return;
+    const res = await fetch('/api/sanctions/' + id, { method: 'DELETE' });
+    if (!res.ok) {
+        alert('ลบไม่สำเร็จ');
+        return;
+    }
+    onLeagueChange();
 }
 
 // โหลดลีกและ set event handler
@@ -404,3 +466,9 @@ function scrapeJLeague() {
             if (btn) { btn.disabled = false; btn.textContent = '🏟️ ดึง J-League'; }
         });
 }
+
+// เครื่องหมายโซนที่การันตีแล้ว (✔) หรือตกชั้นแน่นอน (✖) จาก clinched ของ API
+function zoneFlag(s) {
+    if (!s.zone || !Array.isArray(s.clinched) || !s.clinched.includes(s.zone)) return '';
+    return s.zone === 'relegation' ? ' ✖' : ' ✔';
+}
@@ -65,7 +65,8 @@ function logout() {
 async function loadTeams() {
     try {
         showLoading(true);
-        const response = await fetch(`${API_BASE_URL}/api/teams`);
+        // หน้าจัดการแก้ไขชื่อภาษาไทยเสมอ ไม่ขึ้นกับภาษาของเบราว์เซอร์
+        const response = await fetch(`${API_BASE_URL}/api/teams?lang=th`);
         const data = await response.json();
         
         if (data.success) {
@@ -222,6 +223,7 @@ function initializeEventListeners() {
 async function saveTeam() {
     const formData = {
         name: document.getElementById('teamName').value,
+        name_en: document.getElementById('teamNameEn').value.trim(),
         stadium_id: document.getElementById('teamStadium').value || null,
         team_post_id: document.getElementById('teamPostId').value || null
     };
@@ -267,7 +269,8 @@ function editTeam(teamId) {
 
     currentTeam = team;
     document.getElementById('modalTitle').textContent = 'แก้ไขทีม';
-    document.getElementById('teamName').value = team.name;
+    document.getElementById('teamName').value = team.name_th || team.name;
+    document.getElementById('teamNameEn').value = team.name_en || '';
     document.getElementById('teamStadium').value = team.stadium_id || '';
     document.getElementById('teamPostId').value = team.team_post_id || '';
     // removed teamLogoUrl field